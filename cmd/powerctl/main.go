@@ -2,6 +2,9 @@ package main
 
 import (
 	"os"
+	// Embedded so home timezones and --tz work without a system timezone
+	// database, as on Windows
+	_ "time/tzdata"
 
 	"github.com/kristofferrisa/powerctl-cli/internal/commands"
 )
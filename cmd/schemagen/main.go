@@ -0,0 +1,48 @@
+// Command schemagen generates Go models and selection sets from the checked-in
+// Tibber schema snapshot. It is run by go generate in internal/models.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/schema"
+)
+
+func main() {
+	configPath := flag.String("config", "schemagen.yaml", "generator config file")
+	flag.Parse()
+
+	if err := run(*configPath); err != nil {
+		fmt.Fprintf(os.Stderr, "schemagen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string) error {
+	cfg, err := schema.LoadGenConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	s, err := schema.Load(cfg.Schema)
+	if err != nil {
+		return err
+	}
+
+	models, selections, err := schema.Generate(s, cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(cfg.Models.Output, models, 0644); err != nil {
+		return err
+	}
+	if selections != nil {
+		if err := os.WriteFile(cfg.Selections.Output, selections, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// Package alerts watches the live stream for main fuse overloads, phase
+// imbalance and voltage deviations.
+package alerts
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/config"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+// Monitor evaluates measurements against thresholds and reports alerts
+// when they are raised or cleared
+type Monitor struct {
+	cfg      config.AlertConfig
+	fuseSize int
+
+	overSince map[int]time.Time
+	active    map[string]models.Alert
+}
+
+// NewMonitor creates a monitor. A fuseSize of 0 disables overload checks.
+func NewMonitor(cfg config.AlertConfig, fuseSize int) *Monitor {
+	return &Monitor{
+		cfg:       cfg,
+		fuseSize:  fuseSize,
+		overSince: make(map[int]time.Time),
+		active:    make(map[string]models.Alert),
+	}
+}
+
+// Check evaluates a measurement and returns alerts whose state changed
+func (mon *Monitor) Check(m *models.LiveMeasurement) []models.Alert {
+	var events []models.Alert
+
+	currents := []float64{m.CurrentL1, m.CurrentL2, m.CurrentL3}
+	voltages := []float64{m.VoltagePhase1, m.VoltagePhase2, m.VoltagePhase3}
+
+	// Main fuse overload, sustained for FuseSeconds
+	if mon.fuseSize > 0 {
+		limit := float64(mon.fuseSize) * mon.cfg.FusePercent / 100
+		hold := time.Duration(mon.cfg.FuseSeconds) * time.Second
+
+		for i, current := range currents {
+			phase := i + 1
+			over := current > limit
+			if !over {
+				delete(mon.overSince, phase)
+			} else if _, ok := mon.overSince[phase]; !ok {
+				mon.overSince[phase] = m.Timestamp
+			}
+
+			sustained := over && m.Timestamp.Sub(mon.overSince[phase]) >= hold
+			msg := fmt.Sprintf("L%d at %.1f A exceeds %.0f%% of the %d A main fuse for %ds",
+				phase, current, mon.cfg.FusePercent, mon.fuseSize, mon.cfg.FuseSeconds)
+			events = mon.update(events, sustained, models.Alert{
+				Kind: models.AlertFuseOverload, Phase: phase, Value: current, Limit: limit,
+				Timestamp: m.Timestamp, Message: msg,
+			}, fmt.Sprintf("L%d back to %.1f A, below the %.1f A limit", phase, current, limit))
+		}
+	}
+
+	// Phase imbalance, only meaningful when all three phases report; a
+	// raised alert clears when a phase stops reporting
+	if mon.cfg.ImbalanceAmps > 0 {
+		alert := models.Alert{Kind: models.AlertPhaseImbalance, Limit: mon.cfg.ImbalanceAmps, Timestamp: m.Timestamp}
+		if currents[0] > 0 && currents[1] > 0 && currents[2] > 0 {
+			lo, hi := currents[0], currents[0]
+			for _, c := range currents[1:] {
+				lo, hi = min(lo, c), max(hi, c)
+			}
+			spread := hi - lo
+			alert.Value = spread
+			alert.Message = fmt.Sprintf("Phase currents differ by %.1f A (%.1f–%.1f A)", spread, lo, hi)
+			events = mon.update(events, spread > mon.cfg.ImbalanceAmps, alert,
+				fmt.Sprintf("Phase currents back within %.1f A of each other (%.1f A apart)", mon.cfg.ImbalanceAmps, spread))
+		} else {
+			events = mon.update(events, false, alert, "Phase imbalance cleared, not all phases carry current")
+		}
+	}
+
+	// Voltage outside nominal ± tolerance; phases without a reading are skipped
+	if mon.cfg.NominalVoltage > 0 {
+		low := mon.cfg.NominalVoltage * (1 - mon.cfg.VoltageTolerance/100)
+		high := mon.cfg.NominalVoltage * (1 + mon.cfg.VoltageTolerance/100)
+
+		for i, v := range voltages {
+			if v == 0 {
+				continue
+			}
+			phase := i + 1
+			events = mon.update(events, v < low, models.Alert{
+				Kind: models.AlertVoltageLow, Phase: phase, Value: v, Limit: low, Timestamp: m.Timestamp,
+				Message: fmt.Sprintf("L%d voltage %.0f V is below %.0f V", phase, v, low),
+			}, fmt.Sprintf("L%d voltage back to %.0f V, above %.0f V", phase, v, low))
+			events = mon.update(events, v > high, models.Alert{
+				Kind: models.AlertVoltageHigh, Phase: phase, Value: v, Limit: high, Timestamp: m.Timestamp,
+				Message: fmt.Sprintf("L%d voltage %.0f V is above %.0f V", phase, v, high),
+			}, fmt.Sprintf("L%d voltage back to %.0f V, below %.0f V", phase, v, high))
+		}
+	}
+
+	return events
+}
+
+// Active returns the currently raised alerts, ordered by kind and phase
+func (mon *Monitor) Active() []models.Alert {
+	active := make([]models.Alert, 0, len(mon.active))
+	for _, a := range mon.active {
+		active = append(active, a)
+	}
+	sort.Slice(active, func(i, j int) bool {
+		if active[i].Kind != active[j].Kind {
+			return active[i].Kind < active[j].Kind
+		}
+		return active[i].Phase < active[j].Phase
+	})
+	return active
+}
+
+// update records the condition for alert and appends an event on change.
+// alert.Message describes the raised state; cleared replaces it when the
+// condition has passed.
+func (mon *Monitor) update(events []models.Alert, firing bool, alert models.Alert, cleared string) []models.Alert {
+	key := fmt.Sprintf("%s/%d", alert.Kind, alert.Phase)
+	alert.Type = "alert"
+
+	_, wasActive := mon.active[key]
+	switch {
+	case firing && !wasActive:
+		alert.State = models.AlertRaised
+		mon.active[key] = alert
+		events = append(events, alert)
+	case firing:
+		// Keep the latest reading for the active view
+		alert.State = models.AlertRaised
+		mon.active[key] = alert
+	case wasActive:
+		alert.State = models.AlertCleared
+		alert.Message = cleared
+		delete(mon.active, key)
+		events = append(events, alert)
+	}
+	return events
+}
@@ -0,0 +1,138 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/config"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+var start = time.Date(2025, 9, 1, 18, 0, 0, 0, time.UTC)
+
+func reading(offset time.Duration, l1, l2, l3 float64) *models.LiveMeasurement {
+	return &models.LiveMeasurement{
+		Timestamp:     start.Add(offset),
+		CurrentL1:     l1,
+		CurrentL2:     l2,
+		CurrentL3:     l3,
+		VoltagePhase1: 230,
+		VoltagePhase2: 230,
+		VoltagePhase3: 230,
+	}
+}
+
+func TestMonitor_FuseOverloadNeedsSustainedLoad(t *testing.T) {
+	mon := NewMonitor(config.DefaultAlerts, 25) // limit 20 A for 30 s
+
+	if events := mon.Check(reading(0, 22, 22, 22)); len(events) != 0 {
+		t.Fatalf("Check() = %v, want no events before the hold time", events)
+	}
+	events := mon.Check(reading(31*time.Second, 22, 15, 22))
+	if len(events) != 2 {
+		t.Fatalf("len(events) = %d, want 2 overloads (L1, L3)", len(events))
+	}
+	for _, e := range events {
+		if e.Kind != models.AlertFuseOverload || e.State != models.AlertRaised || e.Type != "alert" {
+			t.Errorf("event = %+v, want raised fuse_overload", e)
+		}
+	}
+	if want := "L1 at 22.0 A exceeds 80% of the 25 A main fuse for 30s"; events[0].Message != want {
+		t.Errorf("raised Message = %q, want %q", events[0].Message, want)
+	}
+
+	// Already raised: no duplicate events
+	if events := mon.Check(reading(35*time.Second, 22, 15, 22)); len(events) != 0 {
+		t.Errorf("Check() = %v, want no repeated events", events)
+	}
+
+	events = mon.Check(reading(40*time.Second, 5, 5, 5))
+	if len(events) != 2 || events[0].State != models.AlertCleared {
+		t.Fatalf("Check() = %+v, want 2 cleared events", events)
+	}
+	if want := "L1 back to 5.0 A, below the 20.0 A limit"; events[0].Message != want {
+		t.Errorf("cleared Message = %q, want %q", events[0].Message, want)
+	}
+	if len(mon.Active()) != 0 {
+		t.Errorf("Active() = %v, want none", mon.Active())
+	}
+}
+
+func TestMonitor_FuseChecksDisabledWithoutFuseSize(t *testing.T) {
+	mon := NewMonitor(config.DefaultAlerts, 0)
+
+	mon.Check(reading(0, 50, 50, 50))
+	if events := mon.Check(reading(time.Minute, 50, 50, 50)); len(events) != 0 {
+		t.Errorf("Check() = %v, want no fuse alerts without a fuse size", events)
+	}
+}
+
+func TestMonitor_PhaseImbalance(t *testing.T) {
+	mon := NewMonitor(config.DefaultAlerts, 0)
+
+	events := mon.Check(reading(0, 18, 4, 6))
+	if len(events) != 1 || events[0].Kind != models.AlertPhaseImbalance {
+		t.Fatalf("Check() = %+v, want one phase_imbalance", events)
+	}
+	if events[0].Value != 14 {
+		t.Errorf("spread = %v, want 14", events[0].Value)
+	}
+
+	// Single-phase homes report zero on the other phases
+	mon = NewMonitor(config.DefaultAlerts, 0)
+	if events := mon.Check(reading(0, 18, 0, 0)); len(events) != 0 {
+		t.Errorf("Check() = %+v, want no imbalance with missing phases", events)
+	}
+}
+
+func TestMonitor_PhaseImbalanceClearsWhenAPhaseDrops(t *testing.T) {
+	mon := NewMonitor(config.DefaultAlerts, 0)
+
+	if events := mon.Check(reading(0, 18, 4, 6)); len(events) != 1 {
+		t.Fatalf("Check() = %+v, want one phase_imbalance", events)
+	}
+	events := mon.Check(reading(time.Second, 18, 0, 6))
+	if len(events) != 1 || events[0].Kind != models.AlertPhaseImbalance || events[0].State != models.AlertCleared {
+		t.Fatalf("Check() = %+v, want phase_imbalance cleared", events)
+	}
+	if want := "Phase imbalance cleared, not all phases carry current"; events[0].Message != want {
+		t.Errorf("cleared Message = %q, want %q", events[0].Message, want)
+	}
+	if len(mon.Active()) != 0 {
+		t.Errorf("Active() = %v, want none", mon.Active())
+	}
+	if events := mon.Check(reading(2*time.Second, 18, 0, 6)); len(events) != 0 {
+		t.Errorf("Check() = %+v, want no repeated clear", events)
+	}
+}
+
+func TestMonitor_VoltageDeviation(t *testing.T) {
+	mon := NewMonitor(config.DefaultAlerts, 0)
+	m := reading(0, 0, 0, 0)
+	m.VoltagePhase2 = 200 // below 207 V
+	m.VoltagePhase3 = 260 // above 253 V
+
+	events := mon.Check(m)
+	if len(events) != 2 {
+		t.Fatalf("len(events) = %d, want 2", len(events))
+	}
+	if events[0].Kind != models.AlertVoltageLow || events[0].Phase != 2 {
+		t.Errorf("events[0] = %+v, want voltage_low on L2", events[0])
+	}
+	if events[1].Kind != models.AlertVoltageHigh || events[1].Phase != 3 {
+		t.Errorf("events[1] = %+v, want voltage_high on L3", events[1])
+	}
+	if want := "L2 voltage 200 V is below 207 V"; events[0].Message != want {
+		t.Errorf("raised Message = %q, want %q", events[0].Message, want)
+	}
+
+	events = mon.Check(reading(time.Minute, 0, 0, 0))
+	if len(events) != 2 || events[0].State != models.AlertCleared {
+		t.Fatalf("Check() = %+v, want 2 cleared events", events)
+	}
+	for i, want := range []string{"L2 voltage back to 230 V, above 207 V", "L3 voltage back to 230 V, below 253 V"} {
+		if events[i].Message != want {
+			t.Errorf("cleared Message = %q, want %q", events[i].Message, want)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/kristofferrisa/powerctl-cli/internal/models"
@@ -52,11 +53,29 @@ type GraphQLResponse struct {
 
 // GraphQLError represents a GraphQL error
 type GraphQLError struct {
-	Message string `json:"message"`
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
 }
 
 // execute sends a GraphQL request and returns the raw response
 func (c *Client) execute(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	gqlResp, err := c.Do(ctx, query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(gqlResp.Errors) > 0 {
+		return nil, fmt.Errorf("GraphQL error: %s", gqlResp.Errors[0].Message)
+	}
+
+	return gqlResp.Data, nil
+}
+
+// Do sends an arbitrary GraphQL document and returns the full response,
+// including partial data and every error. Only transport failures and
+// non-200 responses are returned as errors.
+func (c *Client) Do(ctx context.Context, query string, variables map[string]interface{}) (*GraphQLResponse, error) {
 	reqBody := GraphQLRequest{
 		Query:     query,
 		Variables: variables,
@@ -95,11 +114,35 @@ func (c *Client) execute(ctx context.Context, query string, variables map[string
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	if len(gqlResp.Errors) > 0 {
-		return nil, fmt.Errorf("GraphQL error: %s", gqlResp.Errors[0].Message)
+	return &gqlResp, nil
+}
+
+// mutate sends a GraphQL mutation. Mutations share the query transport but
+// are never retried by callers, since they change state on the server.
+func (c *Client) mutate(ctx context.Context, mutation string, variables map[string]interface{}) (json.RawMessage, error) {
+	if !strings.HasPrefix(strings.TrimSpace(mutation), "mutation") {
+		return nil, fmt.Errorf("not a mutation document")
 	}
+	return c.execute(ctx, mutation, variables)
+}
 
-	return gqlResp.Data, nil
+// Ping checks that the GraphQL endpoint answers HTTP requests at all and
+// returns the round-trip time. Any HTTP status counts as reachable; auth
+// is not sent so a bad token does not mask network problems.
+func (c *Client) Ping(ctx context.Context) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	resp.Body.Close()
+
+	return time.Since(start), nil
 }
 
 // GetHomes fetches all homes for the authenticated user
@@ -157,3 +200,205 @@ func (c *Client) GetPrices(ctx context.Context, homeID string) (*models.PriceInf
 
 	return nil, fmt.Errorf("no price information found")
 }
+
+// GetConsumption fetches consumption for a home in [from, to) at the given
+// resolution (models.EnergyResolutionHourly etc.), following pagination
+func (c *Client) GetConsumption(ctx context.Context, homeID, resolution string, from, to time.Time) ([]models.Consumption, error) {
+	var nodes []models.Consumption
+
+	// The after cursor is exclusive, so start one second before from
+	err := paginate(ctx, cursorAt(from.Add(-time.Second)), func(ctx context.Context, after string) (pageInfo, bool, error) {
+		data, err := c.execute(ctx, QueryConsumption, map[string]interface{}{
+			"homeId":     homeID,
+			"resolution": resolution,
+			"first":      DefaultPageSize,
+			"after":      after,
+		})
+		if err != nil {
+			return pageInfo{}, false, err
+		}
+
+		var result struct {
+			Viewer struct {
+				Home struct {
+					Consumption *struct {
+						PageInfo pageInfo             `json:"pageInfo"`
+						Nodes    []models.Consumption `json:"nodes"`
+					} `json:"consumption"`
+				} `json:"home"`
+			} `json:"viewer"`
+		}
+
+		if err := json.Unmarshal(data, &result); err != nil {
+			return pageInfo{}, false, fmt.Errorf("failed to parse consumption: %w", err)
+		}
+
+		conn := result.Viewer.Home.Consumption
+		if conn == nil {
+			return pageInfo{}, true, nil
+		}
+
+		stop := false
+		for _, n := range conn.Nodes {
+			if !n.From.Before(to) {
+				stop = true
+				break
+			}
+			if !n.From.Before(from) {
+				nodes = append(nodes, n)
+			}
+		}
+		return conn.PageInfo, stop, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// GetPriceRange fetches historical prices for a home in [from, to) at the
+// given resolution (models.PriceResolutionHourly or Daily), following
+// pagination
+func (c *Client) GetPriceRange(ctx context.Context, homeID string, from, to time.Time, resolution string) ([]models.Price, error) {
+	var prices []models.Price
+
+	// The after cursor is exclusive, so start one second before from
+	err := paginate(ctx, cursorAt(from.Add(-time.Second)), func(ctx context.Context, after string) (pageInfo, bool, error) {
+		data, err := c.execute(ctx, QueryPriceRange, map[string]interface{}{
+			"homeId":     homeID,
+			"resolution": resolution,
+			"first":      DefaultPageSize,
+			"after":      after,
+		})
+		if err != nil {
+			return pageInfo{}, false, err
+		}
+
+		var result struct {
+			Viewer struct {
+				Home struct {
+					CurrentSubscription *struct {
+						PriceInfo *struct {
+							Range *struct {
+								PageInfo pageInfo       `json:"pageInfo"`
+								Nodes    []models.Price `json:"nodes"`
+							} `json:"range"`
+						} `json:"priceInfo"`
+					} `json:"currentSubscription"`
+				} `json:"home"`
+			} `json:"viewer"`
+		}
+
+		if err := json.Unmarshal(data, &result); err != nil {
+			return pageInfo{}, false, fmt.Errorf("failed to parse price range: %w", err)
+		}
+
+		sub := result.Viewer.Home.CurrentSubscription
+		if sub == nil || sub.PriceInfo == nil || sub.PriceInfo.Range == nil {
+			return pageInfo{}, true, nil
+		}
+		conn := sub.PriceInfo.Range
+
+		stop := false
+		for _, p := range conn.Nodes {
+			if !p.StartsAt.Before(to) {
+				stop = true
+				break
+			}
+			if !p.StartsAt.Before(from) {
+				prices = append(prices, p)
+			}
+		}
+		return conn.PageInfo, stop, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+// GetPriceRating fetches the price rating of one period (models.RatingDaily
+// etc.) for a home; the other periods are left nil
+func (c *Client) GetPriceRating(ctx context.Context, homeID, period string) (*models.PriceRating, error) {
+	switch period {
+	case models.RatingHourly, models.RatingDaily, models.RatingMonthly:
+	default:
+		return nil, fmt.Errorf("invalid period %q", period)
+	}
+
+	data, err := c.execute(ctx, priceRatingQuery(period), map[string]interface{}{
+		"homeId": homeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Viewer struct {
+			Home struct {
+				CurrentSubscription *struct {
+					PriceRating *models.PriceRating `json:"priceRating"`
+				} `json:"currentSubscription"`
+			} `json:"home"`
+		} `json:"viewer"`
+	}
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse price rating: %w", err)
+	}
+
+	sub := result.Viewer.Home.CurrentSubscription
+	if sub == nil || sub.PriceRating == nil {
+		return nil, fmt.Errorf("no price rating found")
+	}
+	return sub.PriceRating, nil
+}
+
+// SendPushNotification sends a push notification to the account's devices
+// running the Tibber app
+func (c *Client) SendPushNotification(ctx context.Context, input models.PushNotificationInput) (*models.PushNotificationResult, error) {
+	data, err := c.mutate(ctx, MutationSendPushNotification, map[string]interface{}{
+		"input": input,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		SendPushNotification *models.PushNotificationResult `json:"sendPushNotification"`
+	}
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse push notification result: %w", err)
+	}
+	if result.SendPushNotification == nil {
+		return nil, fmt.Errorf("no push notification result returned")
+	}
+
+	return result.SendPushNotification, nil
+}
+
+// UpdateHome changes home settings; only non-nil input fields are sent
+func (c *Client) UpdateHome(ctx context.Context, input models.UpdateHomeInput) (*models.Home, error) {
+	data, err := c.mutate(ctx, MutationUpdateHome, map[string]interface{}{
+		"input": input,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		UpdateHome *models.Home `json:"updateHome"`
+	}
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse updated home: %w", err)
+	}
+	if result.UpdateHome == nil {
+		return nil, fmt.Errorf("no home returned")
+	}
+
+	return result.UpdateHome, nil
+}
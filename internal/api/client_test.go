@@ -5,8 +5,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+	"github.com/kristofferrisa/powerctl-cli/internal/schema"
 )
 
 func TestNewClient(t *testing.T) {
@@ -191,8 +195,8 @@ func TestClient_GetPrices_Success(t *testing.T) {
 	if prices.Current.Total != 0.45 {
 		t.Errorf("current.Total = %v, want 0.45", prices.Current.Total)
 	}
-	if prices.Current.Level != "NORMAL" {
-		t.Errorf("current.Level = %q, want NORMAL", prices.Current.Level)
+	if prices.Current.Level != models.PriceLevelNormal {
+		t.Errorf("current.Level = %v, want NORMAL", prices.Current.Level)
 	}
 	if len(prices.Today) != 1 {
 		t.Errorf("len(Today) = %d, want 1", len(prices.Today))
@@ -269,3 +273,310 @@ func TestGraphQLRequest_Marshaling(t *testing.T) {
 		t.Errorf("query = %v, want %v", result["query"], req.Query)
 	}
 }
+
+func TestClient_Ping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("Ping() should not send the token")
+		}
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.endpoint = server.URL
+
+	if _, err := client.Ping(context.Background()); err != nil {
+		t.Errorf("Ping() error = %v, want nil for any HTTP response", err)
+	}
+
+	server.Close()
+	if _, err := client.Ping(context.Background()); err == nil {
+		t.Error("Ping() should fail when the endpoint is unreachable")
+	}
+}
+
+func TestClient_GetConsumption_Paginates(t *testing.T) {
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(3 * time.Hour)
+
+	pages := map[string]string{
+		// First page: one node before the range, one inside, more to come
+		cursorAt(from.Add(-time.Second)): `{"pageInfo": {"hasNextPage": true, "endCursor": "page2"}, "nodes": [
+			{"from": "2025-08-31T23:00:00Z", "consumption": 9.9},
+			{"from": "2025-09-01T00:00:00Z", "consumption": 1.0, "currency": "NOK"}
+		]}`,
+		// Second page: runs past the end of the range
+		"page2": `{"pageInfo": {"hasNextPage": true, "endCursor": "page3"}, "nodes": [
+			{"from": "2025-09-01T01:00:00Z", "consumption": 2.0},
+			{"from": "2025-09-01T02:00:00Z", "consumption": 3.0},
+			{"from": "2025-09-01T03:00:00Z", "consumption": 4.0}
+		]}`,
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var req GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+		after, _ := req.Variables["after"].(string)
+		page, ok := pages[after]
+		if !ok {
+			t.Errorf("unexpected cursor %q", after)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"data": {"viewer": {"home": {"consumption": ` + page + `}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.endpoint = server.URL
+
+	nodes, err := client.GetConsumption(context.Background(), "home-123", "HOURLY", from, to)
+	if err != nil {
+		t.Fatalf("GetConsumption() error = %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2 (should stop once past the range)", requests)
+	}
+	if len(nodes) != 3 {
+		t.Fatalf("len(nodes) = %d, want 3", len(nodes))
+	}
+	if nodes[0].Consumption != 1.0 || nodes[2].Consumption != 3.0 {
+		t.Errorf("nodes = %+v, want consumption 1.0 .. 3.0", nodes)
+	}
+}
+
+func TestClient_SendPushNotification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+		if req.Query != MutationSendPushNotification {
+			t.Errorf("query = %q, want the push notification mutation", req.Query)
+		}
+		input, _ := req.Variables["input"].(map[string]interface{})
+		if input["message"] != "Cheap power now" || input["screenToOpen"] != "HOME" {
+			t.Errorf("input = %v, want message and screenToOpen", input)
+		}
+		if _, ok := input["title"]; ok {
+			t.Error("empty title should be omitted")
+		}
+		w.Write([]byte(`{"data": {"sendPushNotification": {"successful": true, "pushedToNumberOfDevices": 2}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.endpoint = server.URL
+
+	result, err := client.SendPushNotification(context.Background(), models.PushNotificationInput{
+		Message:      "Cheap power now",
+		ScreenToOpen: "HOME",
+	})
+	if err != nil {
+		t.Fatalf("SendPushNotification() error = %v", err)
+	}
+	if !result.Successful || result.PushedToNumberOfDevices != 2 {
+		t.Errorf("result = %+v, want successful to 2 devices", result)
+	}
+}
+
+func TestClient_MutateRejectsQueries(t *testing.T) {
+	client := NewClient("test-token")
+	if _, err := client.mutate(context.Background(), QueryHomes, nil); err == nil {
+		t.Error("mutate() should reject a query document")
+	}
+}
+
+func TestClient_UpdateHome_SendsOnlySetFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+		input, _ := req.Variables["input"].(map[string]interface{})
+		if len(input) != 3 || input["homeId"] != "home-123" || input["mainFuseSize"] != float64(32) || input["appNickname"] != "Cabin" {
+			t.Errorf("input = %v, want only homeId, appNickname and mainFuseSize", input)
+		}
+		w.Write([]byte(`{"data": {"updateHome": {"id": "home-123", "appNickname": "Cabin", "mainFuseSize": 32}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.endpoint = server.URL
+
+	nickname, fuse := "Cabin", 32
+	home, err := client.UpdateHome(context.Background(), models.UpdateHomeInput{
+		HomeID:       "home-123",
+		AppNickname:  &nickname,
+		MainFuseSize: &fuse,
+	})
+	if err != nil {
+		t.Fatalf("UpdateHome() error = %v", err)
+	}
+	if home.AppNickname != "Cabin" || home.MainFuseSize != 32 {
+		t.Errorf("home = %+v, want updated nickname and fuse", home)
+	}
+}
+
+func TestClient_Do_ReturnsDataAndAllErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Authorization header = %q, want Bearer test-token", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`{
+			"data": {"viewer": {"name": "Test"}},
+			"errors": [
+				{"message": "first", "path": ["viewer", "homes"]},
+				{"message": "second"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.endpoint = server.URL
+
+	resp, err := client.Do(context.Background(), "{ viewer { name homes { id } } }", nil)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if len(resp.Errors) != 2 {
+		t.Errorf("len(Errors) = %d, want 2", len(resp.Errors))
+	}
+	if len(resp.Errors[0].Path) != 2 {
+		t.Errorf("Errors[0].Path = %v, want [viewer homes]", resp.Errors[0].Path)
+	}
+	if !strings.Contains(string(resp.Data), `"Test"`) {
+		t.Errorf("Data = %s, want partial data kept", resp.Data)
+	}
+}
+
+func TestQueries_MatchSchemaSnapshot(t *testing.T) {
+	s, err := schema.Load("../schema/tibber.json")
+	if err != nil {
+		t.Fatalf("schema.Load() error = %v", err)
+	}
+
+	queries := map[string]string{
+		"QueryHomes":                   QueryHomes,
+		"QueryPrices":                  QueryPrices,
+		"QueryConsumption":             QueryConsumption,
+		"QueryPriceRange":              QueryPriceRange,
+		"SubscriptionLiveMeasurement":  SubscriptionLiveMeasurement,
+		"MutationSendPushNotification": MutationSendPushNotification,
+		"MutationUpdateHome":           MutationUpdateHome,
+	}
+	for _, period := range models.RatingPeriods {
+		queries["priceRatingQuery("+period+")"] = priceRatingQuery(period)
+	}
+	for name, doc := range queries {
+		if err := s.Check(doc); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestClient_GetPriceRating(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+		if !strings.Contains(req.Query, "monthly {") || strings.Contains(req.Query, "daily {") {
+			t.Errorf("query should only select the monthly period:\n%s", req.Query)
+		}
+		w.Write([]byte(`{"data": {"viewer": {"home": {"currentSubscription": {"priceRating": {
+			"thresholdPercentages": {"high": 10, "low": 10},
+			"monthly": {"currency": "NOK", "entries": [
+				{"time": "2026-09-01T00:00:00.000+02:00", "total": 1.2, "difference": -3.5, "level": "NORMAL"},
+				{"time": "2026-10-01T00:00:00.000+02:00", "total": 1.5, "difference": 12.4, "level": "HIGH"}
+			]}
+		}}}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.endpoint = server.URL
+
+	rating, err := client.GetPriceRating(context.Background(), "home-123", models.RatingMonthly)
+	if err != nil {
+		t.Fatalf("GetPriceRating() error = %v", err)
+	}
+	if rating.Monthly == nil || len(rating.Monthly.Entries) != 2 {
+		t.Fatalf("Monthly = %+v, want 2 entries", rating.Monthly)
+	}
+	if e := rating.Monthly.Entries[1]; e.Level != models.PriceRatingLevelHigh || e.Time.Month() != 10 {
+		t.Errorf("entry = %+v, want HIGH in October", e)
+	}
+
+	if _, err := client.GetPriceRating(context.Background(), "home-123", "weekly"); err == nil {
+		t.Error("GetPriceRating() should reject unknown periods")
+	}
+}
+
+func TestClient_GetPriceRange_Paginates(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+
+	pages := map[string]string{
+		cursorAt(from.Add(-time.Second)): `{"pageInfo": {"hasNextPage": true, "endCursor": "page2"}, "nodes": [
+			{"startsAt": "2025-01-01T00:00:00Z", "total": 1.0, "currency": "NOK"}
+		]}`,
+		"page2": `{"pageInfo": {"hasNextPage": false, "endCursor": "page3"}, "nodes": [
+			{"startsAt": "2025-01-01T01:00:00Z", "total": 2.0, "currency": "NOK"},
+			{"startsAt": "2025-01-01T02:00:00Z", "total": 3.0, "currency": "NOK"}
+		]}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+		if req.Variables["resolution"] != models.PriceResolutionHourly {
+			t.Errorf("resolution = %v, want HOURLY", req.Variables["resolution"])
+		}
+		after, _ := req.Variables["after"].(string)
+		page, ok := pages[after]
+		if !ok {
+			t.Errorf("unexpected cursor %q", after)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"data": {"viewer": {"home": {"currentSubscription": {"priceInfo": {"range": ` + page + `}}}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.endpoint = server.URL
+
+	prices, err := client.GetPriceRange(context.Background(), "home-123", from, to, models.PriceResolutionHourly)
+	if err != nil {
+		t.Fatalf("GetPriceRange() error = %v", err)
+	}
+	if len(prices) != 2 || prices[0].Total != 1.0 || prices[1].Total != 2.0 {
+		t.Errorf("prices = %+v, want the two hours inside the range", prices)
+	}
+}
+
+func TestPaginate_FailsAtPageLimit(t *testing.T) {
+	pages := 0
+	err := paginate(context.Background(), "", func(ctx context.Context, after string) (pageInfo, bool, error) {
+		pages++
+		return pageInfo{HasNextPage: true, EndCursor: after + "x"}, false, nil
+	})
+	if err == nil || pages != maxPages {
+		t.Errorf("paginate() = %v after %d pages, want an error after %d", err, pages, maxPages)
+	}
+}
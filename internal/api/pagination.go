@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// DefaultPageSize is the number of nodes requested per page
+const DefaultPageSize = 500
+
+// maxPages guards against endless paging if the API keeps returning cursors
+const maxPages = 100
+
+// pageInfo is the Relay-style page info returned by Tibber connections
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// cursorAt builds a connection cursor pointing at t. Tibber cursors are
+// base64-encoded timestamps, so ranges can start at arbitrary times.
+func cursorAt(t time.Time) string {
+	return base64.StdEncoding.EncodeToString([]byte(t.Format(time.RFC3339)))
+}
+
+// paginate calls fetch with successive after cursors, starting at after,
+// until fetch reports no next page or asks to stop. Running into maxPages
+// is an error, so callers never mistake a partial result for a full one.
+func paginate(ctx context.Context, after string, fetch func(ctx context.Context, after string) (page pageInfo, stop bool, err error)) error {
+	for i := 0; i < maxPages; i++ {
+		page, stop, err := fetch(ctx, after)
+		if err != nil {
+			return err
+		}
+		if stop || !page.HasNextPage || page.EndCursor == "" || page.EndCursor == after {
+			return nil
+		}
+		after = page.EndCursor
+	}
+	return fmt.Errorf("more than %d pages of results; try a shorter range", maxPages)
+}
@@ -1,31 +1,19 @@
 package api
 
-// GraphQL queries for Tibber API
+// GraphQL queries for Tibber API. Selections of model types come from the
+// generated *Fields constants so queries and models stay in sync with the
+// schema snapshot.
 
 // QueryHomes fetches all homes with their details
 const QueryHomes = `{
   viewer {
     homes {
-      id
-      appNickname
-      size
-      type
-      numberOfResidents
-      primaryHeatingSource
-      hasVentilationSystem
-      mainFuseSize
-      features {
-        realTimeConsumptionEnabled
-      }
-      address {
-        address1
-        address2
-        address3
-        postalCode
-        city
-        country
-        latitude
-        longitude
+      ` + homeFields + `
+      currentSubscription {
+        status
+        priceInfo {
+          current { ` + priceFields + ` }
+        }
       }
     }
   }
@@ -37,32 +25,7 @@ const QueryPrices = `{
     homes {
       id
       currentSubscription {
-        priceInfo {
-          current {
-            total
-            energy
-            tax
-            startsAt
-            level
-            currency
-          }
-          today {
-            total
-            energy
-            tax
-            startsAt
-            level
-            currency
-          }
-          tomorrow {
-            total
-            energy
-            tax
-            startsAt
-            level
-            currency
-          }
-        }
+        priceInfo { ` + priceInfoFields + ` }
       }
     }
   }
@@ -70,23 +33,68 @@ const QueryPrices = `{
 
 // SubscriptionLiveMeasurement is the GraphQL subscription for real-time data
 const SubscriptionLiveMeasurement = `subscription($homeId: ID!) {
-  liveMeasurement(homeId: $homeId) {
-    timestamp
-    power
-    powerProduction
-    accumulatedConsumption
-    accumulatedProduction
-    accumulatedCost
-    accumulatedReward
-    minPower
-    maxPower
-    averagePower
-    voltagePhase1
-    voltagePhase2
-    voltagePhase3
-    currentL1
-    currentL2
-    currentL3
-    currency
+  liveMeasurement(homeId: $homeId) { ` + liveMeasurementFields + ` }
+}`
+
+// priceRatingQuery fetches the price rating of one period (hourly, daily or
+// monthly) for a home
+func priceRatingQuery(period string) string {
+	return `query($homeId: ID!) {
+  viewer {
+    home(id: $homeId) {
+      currentSubscription {
+        priceRating {
+          thresholdPercentages { ` + priceRatingThresholdsFields + ` }
+          ` + period + ` { ` + priceRatingPeriodFields + ` }
+        }
+      }
+    }
+  }
+}`
+}
+
+// QueryConsumption fetches one page of consumption for a home, paging
+// forward from the $after cursor
+const QueryConsumption = `query($homeId: ID!, $resolution: EnergyResolution!, $first: Int!, $after: String) {
+  viewer {
+    home(id: $homeId) {
+      consumption(resolution: $resolution, first: $first, after: $after) {
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+        nodes { ` + consumptionFields + ` }
+      }
+    }
   }
 }`
+
+// QueryPriceRange fetches one page of historical prices for a home, paging
+// forward from the $after cursor
+const QueryPriceRange = `query($homeId: ID!, $resolution: PriceResolution!, $first: Int!, $after: String) {
+  viewer {
+    home(id: $homeId) {
+      currentSubscription {
+        priceInfo {
+          range(resolution: $resolution, first: $first, after: $after) {
+            pageInfo {
+              hasNextPage
+              endCursor
+            }
+            nodes { ` + priceFields + ` }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// MutationSendPushNotification sends a push notification to the Tibber app
+const MutationSendPushNotification = `mutation($input: PushNotificationInput!) {
+  sendPushNotification(input: $input) { ` + pushNotificationResultFields + ` }
+}`
+
+// MutationUpdateHome updates home settings and returns the updated home
+const MutationUpdateHome = `mutation($input: UpdateHomeInput!) {
+  updateHome(input: $input) { ` + homeFields + ` }
+}`
@@ -0,0 +1,20 @@
+// Code generated by schemagen from the Tibber schema snapshot. DO NOT EDIT.
+
+package api
+
+// Selection sets matching the generated models
+const (
+	homeFields                   = "id appNickname size type numberOfResidents primaryHeatingSource hasVentilationSystem mainFuseSize timeZone address { address1 address2 address3 postalCode city country latitude longitude } features { realTimeConsumptionEnabled }"
+	addressFields                = "address1 address2 address3 postalCode city country latitude longitude"
+	featuresFields               = "realTimeConsumptionEnabled"
+	subscriptionFields           = "status priceInfo { current { total energy tax startsAt level currency } today { total energy tax startsAt level currency } tomorrow { total energy tax startsAt level currency } }"
+	priceInfoFields              = "current { total energy tax startsAt level currency } today { total energy tax startsAt level currency } tomorrow { total energy tax startsAt level currency }"
+	priceFields                  = "total energy tax startsAt level currency"
+	priceRatingFields            = "thresholdPercentages { high low } hourly { minEnergy maxEnergy minTotal maxTotal currency entries { time energy total tax difference level } } daily { minEnergy maxEnergy minTotal maxTotal currency entries { time energy total tax difference level } } monthly { minEnergy maxEnergy minTotal maxTotal currency entries { time energy total tax difference level } }"
+	priceRatingThresholdsFields  = "high low"
+	priceRatingPeriodFields      = "minEnergy maxEnergy minTotal maxTotal currency entries { time energy total tax difference level }"
+	priceRatingEntryFields       = "time energy total tax difference level"
+	consumptionFields            = "from to cost unitPrice unitPriceVAT consumption consumptionUnit currency"
+	liveMeasurementFields        = "timestamp power powerProduction accumulatedConsumption accumulatedConsumptionLastHour accumulatedProduction accumulatedCost accumulatedReward minPower maxPower averagePower voltagePhase1 voltagePhase2 voltagePhase3 currentL1 currentL2 currentL3 currency"
+	pushNotificationResultFields = "successful pushedToNumberOfDevices"
+)
@@ -43,37 +43,24 @@ type wsMessage struct {
 	Payload json.RawMessage `json:"payload,omitempty"`
 }
 
-// Subscribe connects to the live measurement stream
-func (c *LiveClient) Subscribe(ctx context.Context, handler func(*models.LiveMeasurement) error) error {
-	// Connect with subprotocol and proper headers
-	headers := http.Header{}
-	headers.Set("User-Agent", UserAgent)
-
-	conn, _, err := websocket.Dial(ctx, WebSocketEndpoint, &websocket.DialOptions{
-		Subprotocols: []string{"graphql-transport-ws"},
-		HTTPHeader:   headers,
-	})
+// Handshake connects, completes the connection_init/connection_ack exchange
+// and closes again without subscribing. Used to diagnose connectivity.
+func (c *LiveClient) Handshake(ctx context.Context) error {
+	conn, err := c.connect(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to connect: %w", err)
-	}
-	defer conn.Close(websocket.StatusNormalClosure, "")
-
-	// Send connection_init
-	initPayload, _ := json.Marshal(map[string]string{
-		"token": c.token,
-	})
-	initMsg := wsMessage{
-		Type:    "connection_init",
-		Payload: initPayload,
-	}
-	if err := c.sendMessage(ctx, conn, initMsg); err != nil {
-		return fmt.Errorf("failed to send init: %w", err)
+		return err
 	}
+	conn.Close(websocket.StatusNormalClosure, "")
+	return nil
+}
 
-	// Wait for connection_ack
-	if err := c.waitForAck(ctx, conn); err != nil {
+// Subscribe connects to the live measurement stream
+func (c *LiveClient) Subscribe(ctx context.Context, handler func(*models.LiveMeasurement) error) error {
+	conn, err := c.connect(ctx)
+	if err != nil {
 		return err
 	}
+	defer conn.Close(websocket.StatusNormalClosure, "")
 
 	// Send subscription
 	subPayload, _ := json.Marshal(map[string]interface{}{
@@ -125,6 +112,42 @@ func (c *LiveClient) Subscribe(ctx context.Context, handler func(*models.LiveMea
 	}
 }
 
+// connect dials the WebSocket endpoint and completes connection_init
+func (c *LiveClient) connect(ctx context.Context) (*websocket.Conn, error) {
+	// Connect with subprotocol and proper headers
+	headers := http.Header{}
+	headers.Set("User-Agent", UserAgent)
+
+	conn, _, err := websocket.Dial(ctx, WebSocketEndpoint, &websocket.DialOptions{
+		Subprotocols: []string{"graphql-transport-ws"},
+		HTTPHeader:   headers,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	// Send connection_init
+	initPayload, _ := json.Marshal(map[string]string{
+		"token": c.token,
+	})
+	initMsg := wsMessage{
+		Type:    "connection_init",
+		Payload: initPayload,
+	}
+	if err := c.sendMessage(ctx, conn, initMsg); err != nil {
+		conn.Close(websocket.StatusNormalClosure, "")
+		return nil, fmt.Errorf("failed to send init: %w", err)
+	}
+
+	// Wait for connection_ack
+	if err := c.waitForAck(ctx, conn); err != nil {
+		conn.Close(websocket.StatusNormalClosure, "")
+		return nil, err
+	}
+
+	return conn, nil
+}
+
 func (c *LiveClient) sendMessage(ctx context.Context, conn *websocket.Conn, msg wsMessage) error {
 	data, err := json.Marshal(msg)
 	if err != nil {
@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	"github.com/kristofferrisa/powerctl-cli/internal/api"
 	"github.com/kristofferrisa/powerctl-cli/internal/config"
@@ -95,12 +94,9 @@ https://developer.tibber.com/settings/access-token`,
 		}
 
 		// Create config
-		configData := map[string]string{
-			"token":  token,
-			"format": format,
-		}
+		settings := []config.Setting{{Key: "token", Value: token}, {Key: "format", Value: format}}
 		if homeID != "" {
-			configData["home_id"] = homeID
+			settings = append(settings, config.Setting{Key: "home_id", Value: homeID})
 		}
 
 		// Ensure config directory exists
@@ -108,15 +104,10 @@ https://developer.tibber.com/settings/access-token`,
 			exitWithError("Failed to create config directory: %v", err)
 		}
 
-		// Write config file
+		// Write config file, keeping any other settings already in it
 		configPath := config.DefaultConfigPath()
-		yamlData, err := yaml.Marshal(configData)
-		if err != nil {
-			exitWithError("Failed to create config: %v", err)
-		}
-
-		if err := os.WriteFile(configPath, yamlData, 0600); err != nil {
-			exitWithError("Failed to write config file: %v", err)
+		if err := config.Set(configPath, settings...); err != nil {
+			exitWithError("%v", err)
 		}
 
 		fmt.Printf("\nConfiguration saved to %s\n", configPath)
@@ -143,25 +134,19 @@ var configShowCmd = &cobra.Command{
 		}
 
 		// Read and display config
-		data, err := os.ReadFile(configPath)
+		settings, err := config.Settings(configPath)
 		if err != nil {
 			exitWithError("Failed to read config: %v", err)
 		}
 
-		var configData map[string]string
-		if err := yaml.Unmarshal(data, &configData); err != nil {
-			exitWithError("Failed to parse config: %v", err)
-		}
-
 		fmt.Printf("Configuration file: %s\n\n", configPath)
 
-		// Mask token for security
-		if token, ok := configData["token"]; ok && len(token) > 8 {
-			configData["token"] = token[:4] + "..." + token[len(token)-4:]
-		}
-
-		for key, value := range configData {
-			fmt.Printf("  %s: %s\n", key, value)
+		for _, s := range settings {
+			// Mask token for security
+			if s.Key == "token" && len(s.Value) > 8 {
+				s.Value = s.Value[:4] + "..." + s.Value[len(s.Value)-4:]
+			}
+			fmt.Printf("  %s: %s\n", s.Key, s.Value)
 		}
 
 		// Show environment overrides
@@ -211,25 +196,10 @@ Available keys:
 			exitWithError("Failed to create config directory: %v", err)
 		}
 
+		// Update the value in place, or create the file
 		configPath := config.DefaultConfigPath()
-
-		// Read existing config or create new
-		configData := make(map[string]string)
-		if data, err := os.ReadFile(configPath); err == nil {
-			yaml.Unmarshal(data, &configData)
-		}
-
-		// Update value
-		configData[key] = value
-
-		// Write config
-		yamlData, err := yaml.Marshal(configData)
-		if err != nil {
-			exitWithError("Failed to create config: %v", err)
-		}
-
-		if err := os.WriteFile(configPath, yamlData, 0600); err != nil {
-			exitWithError("Failed to write config file: %v", err)
+		if err := config.Set(configPath, config.Setting{Key: key, Value: value}); err != nil {
+			exitWithError("%v", err)
 		}
 
 		fmt.Printf("Set %s in %s\n", key, configPath)
@@ -250,13 +220,14 @@ var configEditCmd = &cobra.Command{
 				exitWithError("Failed to create config directory: %v", err)
 			}
 
-			template := `# Tibber CLI Configuration
+			template := fmt.Sprintf(`# Tibber CLI Configuration
 # Get your token from: https://developer.tibber.com/settings/access-token
 
+config_version: %d
 token: ""
 # home_id: ""
 # format: pretty  # Options: pretty, json, markdown
-`
+`, config.CurrentConfigVersion)
 			if err := os.WriteFile(configPath, []byte(template), 0600); err != nil {
 				exitWithError("Failed to create config file: %v", err)
 			}
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/api"
+	"github.com/kristofferrisa/powerctl-cli/internal/currency"
+	"github.com/kristofferrisa/powerctl-cli/internal/dashboard"
+	"github.com/kristofferrisa/powerctl-cli/internal/i18n"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+	"github.com/kristofferrisa/powerctl-cli/internal/term"
+)
+
+var (
+	dashboardHomeID       string
+	dashboardWindowHours  int
+	dashboardSparkMinutes int
+)
+
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Full-screen dashboard of live power, prices and cost",
+	Long: `Show a full-screen terminal dashboard for one home: live power with a
+sparkline, today's and tomorrow's prices as a chart, the cheapest upcoming
+window of --window hours and today's accumulated consumption and cost.
+
+Live data requires a Tibber Pulse. Prices include the configured tariff and
+subsidy, as in 'powerctl prices'.
+
+Keys:
+  ←/→, n/p   switch home
+  r          refresh prices
+  q, Ctrl+C  quit`,
+	Example: `  powerctl dashboard
+  powerctl dashboard --window 4 --currency EUR`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cfg.Validate(); err != nil {
+			exitWithError("%v", err)
+		}
+		if dashboardWindowHours < 1 {
+			exitWithError("--window must be at least 1")
+		}
+		if dashboardSparkMinutes < 1 {
+			exitWithError("--spark-minutes must be at least 1")
+		}
+		if !term.IsTerminal(os.Stdin.Fd()) || !term.IsTerminal(os.Stdout.Fd()) {
+			exitWithError("The dashboard needs an interactive terminal; use 'powerctl live' for piped output")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigChan
+			cancel()
+		}()
+
+		client := api.NewClient(cfg.Token)
+		homes, err := client.GetHomes(ctx)
+		if err != nil {
+			exitWithError("Failed to fetch homes: %v", err)
+		}
+
+		homeID := dashboardHomeID
+		if homeID == "" {
+			homeID = cfg.HomeID
+		}
+		var dashHomes []dashboard.Home
+		selected := -1
+		for _, home := range homes {
+			if home.ID == homeID || (homeID == "" && selected < 0 && home.Features.RealTimeConsumptionEnabled) {
+				selected = len(dashHomes)
+			}
+			dashHomes = append(dashHomes, dashboard.Home{ID: home.ID, Name: dashboardHomeName(&home), Location: dashboardLocation(&home)})
+		}
+		if len(dashHomes) == 0 {
+			exitWithError("No homes found")
+		}
+		if homeID != "" && selected < 0 {
+			exitWithError("Home %s not found", homeID)
+		}
+		setHomeTimezone(homes, dashHomes[max(selected, 0)].ID)
+
+		converter := displayConverter(ctx)
+		live := func(id string) dashboard.LiveSource {
+			return &convertingLive{LiveClient: api.NewLiveClient(cfg.Token, id), converter: converter}
+		}
+		d := dashboard.New(dashHomes, live, &dashboardPrices{client: client, converter: converter})
+		d.WindowHours = dashboardWindowHours
+		d.SparkMinutes = dashboardSparkMinutes
+		d.Format = displayPriceFormat()
+		d.Style = outputStyle()
+		d.Locale = i18n.New(cfg.Lang)
+		d.Show(selected)
+
+		restore, err := term.RawInput(os.Stdin.Fd())
+		if err != nil {
+			exitWithError("Failed to set up the terminal: %v", err)
+		}
+		if err := term.EnableVT(os.Stdout.Fd()); err != nil {
+			restore()
+			exitWithError("Failed to set up the terminal: %v", err)
+		}
+
+		resize := make(chan os.Signal, 1)
+		term.NotifyResize(resize)
+		defer signal.Stop(resize)
+
+		err = d.Run(ctx, dashboard.Terminal{
+			In:  os.Stdin,
+			Out: os.Stdout,
+			Size: func() (int, int) {
+				w, h, err := term.Size(os.Stdout.Fd())
+				if err != nil {
+					return term.DefaultWidth, 24
+				}
+				return w, h
+			},
+			Resize: resize,
+		})
+		restore()
+		if err != nil {
+			exitWithError("%v", err)
+		}
+	},
+}
+
+// dashboardHomeName is the label shown for a home in the dashboard header
+func dashboardHomeName(home *models.HomeResponse) string {
+	switch {
+	case home.AppNickname != "":
+		return home.AppNickname
+	case home.Address.Address1 != "":
+		return home.Address.Address1
+	}
+	return home.ID
+}
+
+// dashboardLocation is the timezone a home's times are shown in: --tz if
+// set, otherwise the home's own
+func dashboardLocation(home *models.HomeResponse) *time.Location {
+	if tzFlag != "" {
+		return displayLoc
+	}
+	return homeLocation(&home.Home)
+}
+
+// dashboardPrices feeds the dashboard the same prices as `powerctl prices`
+type dashboardPrices struct {
+	client    *api.Client
+	converter *currency.Converter
+}
+
+func (p *dashboardPrices) Prices(ctx context.Context, homeID string) (*models.PriceInfo, error) {
+	return effectivePrices(ctx, p.client, homeID, p.converter)
+}
+
+// convertingLive converts live measurements to the display currency
+type convertingLive struct {
+	*api.LiveClient
+	converter *currency.Converter
+}
+
+func (l *convertingLive) Subscribe(ctx context.Context, handler func(*models.LiveMeasurement) error) error {
+	return l.LiveClient.Subscribe(ctx, func(m *models.LiveMeasurement) error {
+		if l.converter != nil {
+			if err := l.converter.LiveMeasurement(m); err != nil {
+				return err
+			}
+		}
+		return handler(m)
+	})
+}
+
+func init() {
+	dashboardCmd.Flags().StringVar(&dashboardHomeID, "home-id", "", "home to show first (default: configured or first home with Pulse)")
+	dashboardCmd.Flags().IntVar(&dashboardWindowHours, "window", 3, "length of the cheapest window, in hours")
+	dashboardCmd.Flags().IntVar(&dashboardSparkMinutes, "spark-minutes", 15, "minutes of history shown in the power sparkline")
+	rootCmd.AddCommand(dashboardCmd)
+}
@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/api"
+	"github.com/kristofferrisa/powerctl-cli/internal/config"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+// doctorTimeout bounds each network check
+const doctorTimeout = 15 * time.Second
+
+// doctorConfigErr is why the config file failed to load, if it did
+var doctorConfigErr error
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose setup problems",
+	Long: `Run a series of checks against your configuration and the Tibber API.
+
+Checks config file permissions, token presence, API reachability, token
+validity, Pulse and subscription status per home, and a WebSocket handshake.
+Exits with code 1 if any check fails.`,
+	// A config that fails to load is reported as a check; the rest run
+	// with the file's token and home ID where they can still be read
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		if cfg, err = config.Load(cfgFile); err != nil {
+			doctorConfigErr = err
+			cfg = config.LoadUnvalidated(cfgFile)
+		}
+		return setup()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		report := &models.DiagnosticReport{}
+		ctx := context.Background()
+
+		checkConfigFile(report)
+
+		if cfg.Token == "" && doctorConfigErr != nil {
+			report.Add("API token", models.CheckSkip, "not checked, the config file failed to load")
+			finishDoctor(report)
+			return
+		}
+		if cfg.Token == "" {
+			report.Add("API token", models.CheckFail,
+				fmt.Sprintf("not set; set TIBBER_TOKEN or run 'powerctl config init' (%s)", config.DefaultConfigPath()))
+			finishDoctor(report)
+			return
+		}
+		switch cfg.TokenSource {
+		case config.TokenSourceEnv:
+			report.Add("API token", models.CheckPass, "from TIBBER_TOKEN environment variable")
+		default:
+			report.Add("API token", models.CheckPass, "from "+cfg.Path)
+		}
+
+		client := api.NewClient(cfg.Token)
+
+		pingCtx, cancel := context.WithTimeout(ctx, doctorTimeout)
+		latency, err := client.Ping(pingCtx)
+		cancel()
+		if err != nil {
+			report.Add("API reachable", models.CheckFail, err.Error())
+			finishDoctor(report)
+			return
+		}
+		report.Add("API reachable", models.CheckPass, fmt.Sprintf("%s (%dms)", api.GraphQLEndpoint, latency.Milliseconds()))
+
+		homesCtx, cancel := context.WithTimeout(ctx, doctorTimeout)
+		homes, err := client.GetHomes(homesCtx)
+		cancel()
+		if err != nil {
+			report.Add("Token valid", models.CheckFail, err.Error())
+			finishDoctor(report)
+			return
+		}
+		report.Add("Token valid", models.CheckPass, fmt.Sprintf("%d home(s) found", len(homes)))
+
+		pulseHomeID := ""
+		for _, home := range homes {
+			name := doctorHomeName(&home)
+
+			if home.Features.RealTimeConsumptionEnabled {
+				report.Add(name+": Pulse", models.CheckPass, "real-time consumption enabled")
+				if pulseHomeID == "" || home.ID == cfg.HomeID {
+					pulseHomeID = home.ID
+				}
+			} else {
+				report.Add(name+": Pulse", models.CheckWarn, "not enabled; 'live' will not work for this home")
+			}
+
+			sub := home.CurrentSubscription
+			switch {
+			case sub == nil:
+				report.Add(name+": subscription", models.CheckFail, "no active subscription")
+			case sub.PriceInfo == nil || sub.PriceInfo.Current == nil:
+				report.Add(name+": subscription", models.CheckFail, fmt.Sprintf("status %q, but no prices available", sub.Status))
+			default:
+				report.Add(name+": subscription", models.CheckPass, fmt.Sprintf("status %q, prices available", sub.Status))
+			}
+		}
+
+		if cfg.HomeID != "" {
+			found := false
+			for _, home := range homes {
+				if home.ID == cfg.HomeID {
+					found = true
+					break
+				}
+			}
+			if found {
+				report.Add("Default home", models.CheckPass, cfg.HomeID)
+			} else {
+				report.Add("Default home", models.CheckFail, fmt.Sprintf("home_id %s not found on this account", cfg.HomeID))
+			}
+		}
+
+		if pulseHomeID == "" {
+			report.Add("WebSocket handshake", models.CheckSkip, "no home with Pulse")
+		} else {
+			wsCtx, cancel := context.WithTimeout(ctx, doctorTimeout)
+			err := api.NewLiveClient(cfg.Token, pulseHomeID).Handshake(wsCtx)
+			cancel()
+			if err != nil {
+				report.Add("WebSocket handshake", models.CheckFail, err.Error())
+			} else {
+				report.Add("WebSocket handshake", models.CheckPass, api.WebSocketEndpoint)
+			}
+		}
+
+		finishDoctor(report)
+	},
+}
+
+// checkConfigFile verifies the config file loads and is only readable by
+// its owner
+func checkConfigFile(report *models.DiagnosticReport) {
+	if doctorConfigErr != nil {
+		report.Add("Config file", models.CheckFail, doctorConfigErr.Error())
+		return
+	}
+	if cfg.Path == "" {
+		report.Add("Config file", models.CheckSkip, "none found at "+config.DefaultConfigPath())
+		return
+	}
+
+	info, err := os.Stat(cfg.Path)
+	if err != nil {
+		report.Add("Config file", models.CheckFail, err.Error())
+		return
+	}
+
+	// Windows does not have Unix permission bits
+	if runtime.GOOS == "windows" {
+		report.Add("Config file", models.CheckPass, cfg.Path)
+		return
+	}
+
+	if perm := info.Mode().Perm(); perm&0077 != 0 {
+		report.Add("Config file", models.CheckWarn,
+			fmt.Sprintf("%s has permissions %04o, expected 0600 (chmod 600 %s)", cfg.Path, perm, cfg.Path))
+		return
+	}
+	report.Add("Config file", models.CheckPass, fmt.Sprintf("%s (0600)", cfg.Path))
+}
+
+// finishDoctor prints the report and exits non-zero if any check failed
+func finishDoctor(report *models.DiagnosticReport) {
+	fmt.Println(formatter.FormatDiagnostics(report))
+	if report.Failed() {
+		os.Exit(1)
+	}
+}
+
+func doctorHomeName(home *models.HomeResponse) string {
+	if home.AppNickname != "" {
+		return home.AppNickname
+	}
+	if home.Address.Address1 != "" {
+		return home.Address.Address1
+	}
+	return home.ID
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -3,10 +3,12 @@ package commands
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/kristofferrisa/powerctl-cli/internal/api"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
 )
 
 var homeCmd = &cobra.Command{
@@ -46,6 +48,112 @@ var homeCmd = &cobra.Command{
 	},
 }
 
+var (
+	homeSetID          string
+	homeSetNickname    string
+	homeSetSize        int
+	homeSetType        string
+	homeSetResidents   int
+	homeSetHeating     string
+	homeSetVentilation bool
+	homeSetFuse        int
+)
+
+var homeSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Update home settings",
+	Long: `Update the settings Tibber uses to compare your home with similar homes.
+Only the flags you pass are changed.
+
+Home types: ` + strings.Join(models.HomeTypeNames(), ", ") + `
+Heating sources: ` + strings.Join(models.HeatingSourceNames(), ", "),
+	Example: `  powerctl home set --nickname "Cabin" --fuse 32 --residents 4
+  powerctl home set --type HOUSE --heating AIR2AIR_HEATPUMP --ventilation`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cfg.Validate(); err != nil {
+			exitWithError("%v", err)
+		}
+
+		flags := cmd.Flags()
+		input := models.UpdateHomeInput{}
+		changed := false
+
+		if flags.Changed("nickname") {
+			input.AppNickname = &homeSetNickname
+			changed = true
+		}
+		if flags.Changed("size") {
+			if homeSetSize <= 0 {
+				exitWithError("--size must be positive")
+			}
+			input.Size = &homeSetSize
+			changed = true
+		}
+		if flags.Changed("type") {
+			homeType, err := models.ParseHomeType(homeSetType)
+			if err != nil {
+				exitWithError("%v", err)
+			}
+			input.Type = &homeType
+			changed = true
+		}
+		if flags.Changed("residents") {
+			if homeSetResidents <= 0 {
+				exitWithError("--residents must be positive")
+			}
+			input.NumberOfResidents = &homeSetResidents
+			changed = true
+		}
+		if flags.Changed("heating") {
+			heating, err := models.ParseHeatingSource(homeSetHeating)
+			if err != nil {
+				exitWithError("%v", err)
+			}
+			input.PrimaryHeatingSource = &heating
+			changed = true
+		}
+		if flags.Changed("ventilation") {
+			input.HasVentilationSystem = &homeSetVentilation
+			changed = true
+		}
+		if flags.Changed("fuse") {
+			if homeSetFuse <= 0 {
+				exitWithError("--fuse must be positive")
+			}
+			input.MainFuseSize = &homeSetFuse
+			changed = true
+		}
+
+		if !changed {
+			exitWithError("Nothing to update. See 'powerctl home set --help' for available flags")
+		}
+
+		client := api.NewClient(cfg.Token)
+		ctx := context.Background()
+
+		input.HomeID = homeSetID
+		if input.HomeID == "" {
+			input.HomeID = defaultHomeID(ctx, client)
+		}
+
+		home, err := client.UpdateHome(ctx, input)
+		if err != nil {
+			exitWithError("Failed to update home: %v", err)
+		}
+
+		fmt.Println(formatter.FormatHome(&models.HomeResponse{Home: *home}))
+	},
+}
+
 func init() {
+	homeSetCmd.Flags().StringVar(&homeSetID, "home-id", "", "home to update (default: configured or first home)")
+	homeSetCmd.Flags().StringVar(&homeSetNickname, "nickname", "", "app nickname")
+	homeSetCmd.Flags().IntVar(&homeSetSize, "size", 0, "living area in m²")
+	homeSetCmd.Flags().StringVar(&homeSetType, "type", "", "home type")
+	homeSetCmd.Flags().IntVar(&homeSetResidents, "residents", 0, "number of residents")
+	homeSetCmd.Flags().StringVar(&homeSetHeating, "heating", "", "primary heating source")
+	homeSetCmd.Flags().BoolVar(&homeSetVentilation, "ventilation", false, "home has a ventilation system (--ventilation=false to clear)")
+	homeSetCmd.Flags().IntVar(&homeSetFuse, "fuse", 0, "main fuse size in A")
+	homeCmd.AddCommand(homeSetCmd)
 	rootCmd.AddCommand(homeCmd)
 }
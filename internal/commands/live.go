@@ -1,47 +1,81 @@
 package commands
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/kristofferrisa/powerctl-cli/internal/alerts"
 	"github.com/kristofferrisa/powerctl-cli/internal/api"
+	"github.com/kristofferrisa/powerctl-cli/internal/currency"
 	"github.com/kristofferrisa/powerctl-cli/internal/models"
+	"github.com/kristofferrisa/powerctl-cli/internal/peaks"
+	"github.com/kristofferrisa/powerctl-cli/internal/stats"
 )
 
 var (
-	liveHomeID string
+	liveHomeID       string
+	liveSparkMinutes int
+	livePeaks        bool
 )
 
+// livePriceRefresh is the minimum interval between price refetches
+const livePriceRefresh = 15 * time.Minute
+
 var liveCmd = &cobra.Command{
 	Use:   "live",
 	Short: "Stream real-time power consumption",
 	Long: `Stream live power consumption data from your Tibber Pulse.
 
 Requires a Tibber Pulse device connected to your home.
-Press Ctrl+C to stop the stream.`,
+Press Ctrl+C to stop the stream.
+
+The views add 1/5/15-minute averages, the session's peak and minimum, a
+power sparkline and a projection of the current hour's energy and cost;
+JSON output has them on a line with "type": "stats" after each measurement. With --peaks, a capacity tariff panel shows the
+month's top hourly peaks and warns when the running hour is projected to
+set a new one; JSON output adds it as a line with "type": "peaks".
+
+Phase currents are compared against the home's main fuse size, and phase
+imbalance and voltage deviations are flagged. Thresholds are configured
+under alerts in the config file. In JSON output, alerts are emitted as
+separate lines with "type": "alert" when raised or cleared.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := cfg.Validate(); err != nil {
 			exitWithError("%v", err)
 		}
 
+		if liveSparkMinutes < 1 {
+			exitWithError("--spark-minutes must be at least 1")
+		}
+
 		homeID := liveHomeID
 		if homeID == "" {
 			homeID = cfg.HomeID
 		}
 
-		// If no home ID, fetch homes and use first one with Pulse
-		if homeID == "" {
-			client := api.NewClient(cfg.Token)
-			homes, err := client.GetHomes(context.Background())
-			if err != nil {
+		client := api.NewClient(cfg.Token)
+
+		// Homes are needed to pick a Pulse home and for the main fuse size.
+		// With an explicit home ID the fetch is best effort.
+		homes, err := client.GetHomes(context.Background())
+		if err != nil {
+			if homeID == "" {
 				exitWithError("Failed to fetch homes: %v", err)
 			}
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch homes, fuse checks disabled: %v\n", err)
+		}
 
+		// If no home ID, use the first one with Pulse
+		if homeID == "" {
 			for _, home := range homes {
 				if home.Features.RealTimeConsumptionEnabled {
 					homeID = home.ID
@@ -54,6 +88,14 @@ Press Ctrl+C to stop the stream.`,
 			}
 		}
 
+		fuseSize := 0
+		for _, home := range homes {
+			if home.ID == homeID {
+				fuseSize = home.MainFuseSize
+			}
+		}
+		setHomeTimezone(homes, homeID)
+
 		// Set up signal handling for graceful shutdown
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
@@ -66,17 +108,61 @@ Press Ctrl+C to stop the stream.`,
 		}()
 
 		liveClient := api.NewLiveClient(cfg.Token, homeID)
+		converter := displayConverter(ctx)
+		prices := newLivePrices(client, homeID, converter)
+		window := stats.NewWindow(time.Duration(liveSparkMinutes) * time.Minute)
+		monitor := alerts.NewMonitor(cfg.Alerts, fuseSize)
+
+		var tracker *peaks.Tracker
+		if livePeaks {
+			tracker = newPeakTracker(ctx, client, homeID)
+		}
 
+		clearScreen := interactiveOutput()
 		fmt.Fprintf(os.Stderr, "Connecting to live stream...\n")
 
-		err := liveClient.Subscribe(ctx, func(m *models.LiveMeasurement) error {
+		err = liveClient.Subscribe(ctx, func(m *models.LiveMeasurement) error {
+			window.Add(m)
+			events := monitor.Check(m)
+
+			if converter != nil {
+				if err := converter.LiveMeasurement(m); err != nil {
+					return err
+				}
+			}
+
+			price := prices.at(ctx, m.Timestamp)
+			liveStats := window.Stats(m, price, liveSparkMinutes)
+			liveStats.Conversion = m.Conversion
+
+			var peakReport *models.PeakReport
+			if tracker != nil {
+				tracker.Observe(m)
+				peakReport = tracker.Report(liveStats.ProjectedHourEnergy)
+				// Capacity steps are configured in the home's currency
+				peakReport.Currency = m.Currency
+				if m.Conversion != nil {
+					peakReport.Currency = m.Conversion.From
+				}
+			}
+
 			// Clear screen for markdown, just print for JSON
 			if cfg.Format == "json" {
-				fmt.Println(formatter.FormatLiveMeasurement(m))
+				printLiveJSON(os.Stdout, m, liveStats, peakReport, events)
 			} else {
-				// ANSI escape to clear screen and move cursor to top
-				fmt.Print("\033[2J\033[H")
+				// ANSI escape to clear screen and move cursor to top;
+				// piped output gets one frame after another instead
+				if clearScreen {
+					fmt.Print("\033[2J\033[H")
+				}
 				fmt.Println(formatter.FormatLiveMeasurement(m))
+				if active := monitor.Active(); len(active) > 0 {
+					fmt.Println(formatter.FormatAlerts(active))
+				}
+				fmt.Println(formatter.FormatLiveStats(liveStats))
+				if peakReport != nil {
+					fmt.Println(formatter.FormatPeaks(peakReport))
+				}
 			}
 			return nil
 		})
@@ -87,7 +173,101 @@ Press Ctrl+C to stop the stream.`,
 	},
 }
 
+// printLiveJSON writes one measurement as JSON lines: the measurement, its
+// stats, the peak report when tracking peaks and any alerts that changed
+func printLiveJSON(w io.Writer, m *models.LiveMeasurement, liveStats *models.LiveStats, peakReport *models.PeakReport, events []models.Alert) {
+	fmt.Fprintln(w, formatter.FormatLiveMeasurement(m))
+	fmt.Fprintln(w, formatter.FormatLiveStats(liveStats))
+	if peakReport != nil {
+		// The peak report is indented for the peaks command; keep the
+		// stream to one object per line
+		var line bytes.Buffer
+		if err := json.Compact(&line, []byte(formatter.FormatPeaks(peakReport))); err == nil {
+			fmt.Fprintln(w, line.String())
+		}
+	}
+	if len(events) > 0 {
+		fmt.Fprintln(w, formatter.FormatAlerts(events))
+	}
+}
+
+// livePrices caches price info for the live view and refetches it when the
+// cached data no longer covers the current time
+type livePrices struct {
+	client    *api.Client
+	homeID    string
+	converter *currency.Converter
+	info      *models.PriceInfo
+	lastFetch time.Time
+}
+
+func newLivePrices(client *api.Client, homeID string, converter *currency.Converter) *livePrices {
+	return &livePrices{client: client, homeID: homeID, converter: converter}
+}
+
+// at returns the price slot covering t, or nil if prices are unavailable
+func (p *livePrices) at(ctx context.Context, t time.Time) *models.Price {
+	if p.info != nil {
+		if price := p.info.At(t); price != nil {
+			return price
+		}
+	}
+	if time.Since(p.lastFetch) < livePriceRefresh {
+		return nil
+	}
+
+	p.lastFetch = time.Now()
+	info, err := effectivePrices(ctx, p.client, p.homeID, p.converter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return nil
+	}
+	p.info = info
+	return p.info.At(t)
+}
+
+// effectivePrices fetches a home's prices with the configured tariff and
+// subsidy applied, converted for display if converter is set. Missing
+// subsidy history is a warning, as the subsidy is an estimate anyway.
+func effectivePrices(ctx context.Context, client *api.Client, homeID string, converter *currency.Converter) (*models.PriceInfo, error) {
+	info, err := client.GetPrices(ctx, homeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch prices: %w", err)
+	}
+	if tariff := activeTariff(false); tariff != nil {
+		tariff.ApplyInfo(info)
+	}
+	if subsidy := activeSubsidy(false); subsidy != nil {
+		now := time.Now().In(homeLoc)
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, homeLoc)
+		history, err := monthHistory(ctx, client, homeID, today, models.PriceResolutionHourly)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch price history: %v\n", err)
+		}
+		subsidy.ApplyInfo(info, history)
+	}
+	if converter != nil {
+		if err := converter.PriceInfo(info); err != nil {
+			return nil, fmt.Errorf("failed to convert prices: %w", err)
+		}
+	}
+	return info, nil
+}
+
+// newPeakTracker seeds a peak tracker with this month's hourly history.
+// History is best effort; the tracker still follows the stream without it.
+func newPeakTracker(ctx context.Context, client *api.Client, homeID string) *peaks.Tracker {
+	month := peaks.MonthStart(time.Now(), homeLoc)
+	nodes, err := client.GetConsumption(ctx, homeID, models.EnergyResolutionHourly, month, peaks.HourStart(time.Now(), homeLoc))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to fetch consumption history: %v\n", err)
+	}
+	return peaks.NewTracker(peaks.FromConsumption(nodes), cfg.CapacitySteps, homeLoc)
+}
+
 func init() {
 	liveCmd.Flags().StringVar(&liveHomeID, "home-id", "", "specific home ID to monitor")
+	liveCmd.Flags().BoolVar(&livePeaks, "peaks", false, "show the capacity tariff peak panel")
+	liveCmd.Flags().IntVar(&liveSparkMinutes, "spark-minutes", 15, "minutes of history shown in the power sparkline")
 	rootCmd.AddCommand(liveCmd)
 }
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+	"github.com/kristofferrisa/powerctl-cli/internal/output"
+	"github.com/kristofferrisa/powerctl-cli/internal/peaks"
+)
+
+func TestPrintLiveJSON_Peaks(t *testing.T) {
+	formatter = output.New("json", nil, output.Style{}, nil, nil)
+	defer func() { formatter = nil }()
+
+	now := time.Date(2025, 9, 1, 18, 30, 0, 0, time.UTC)
+	m := &models.LiveMeasurement{Timestamp: now, Power: 3000, AccumulatedConsumptionLastHour: 1.5, Currency: "NOK"}
+	tracker := peaks.NewTracker(nil, nil, time.UTC)
+	tracker.Observe(m)
+	report := tracker.Report(3)
+
+	var out bytes.Buffer
+	printLiveJSON(&out, m, &models.LiveStats{Type: "stats"}, report, nil)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("printLiveJSON() wrote %d lines, want measurement, stats and peaks:\n%s", len(lines), out.String())
+	}
+	var peak map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[2]), &peak); err != nil {
+		t.Fatalf("peaks line is not valid JSON: %v\n%s", err, lines[2])
+	}
+	if peak["type"] != "peaks" || peak["running"] == nil || peak["newPeak"] != true {
+		t.Errorf("peaks line = %s, want type peaks with the running hour as a new peak", lines[2])
+	}
+
+	out.Reset()
+	printLiveJSON(&out, m, &models.LiveStats{Type: "stats"}, nil, nil)
+	if strings.Contains(out.String(), "peaks") {
+		t.Errorf("printLiveJSON() without --peaks wrote a peaks line:\n%s", out.String())
+	}
+}
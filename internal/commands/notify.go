@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/api"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+var (
+	notifyTitle   string
+	notifyMessage string
+	notifyScreen  string
+)
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Send a push notification to the Tibber app",
+	Long: `Send a push notification to every device signed in to the Tibber app
+on this account.
+
+--screen selects the app screen opened when the notification is tapped:
+` + strings.Join(models.AppScreens, ", "),
+	Example: `  powerctl notify --title "Laundry" --message "Prices are very cheap now"
+  powerctl notify --message "Check this month's usage" --screen CONSUMPTION`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cfg.Validate(); err != nil {
+			exitWithError("%v", err)
+		}
+
+		if strings.TrimSpace(notifyMessage) == "" {
+			exitWithError("--message is required")
+		}
+
+		screen := strings.ToUpper(notifyScreen)
+		if screen != "" && !contains(models.AppScreens, screen) {
+			exitWithError("Invalid screen: %s. Valid screens: %s", notifyScreen, strings.Join(models.AppScreens, ", "))
+		}
+
+		client := api.NewClient(cfg.Token)
+		result, err := client.SendPushNotification(context.Background(), models.PushNotificationInput{
+			Title:        notifyTitle,
+			Message:      notifyMessage,
+			ScreenToOpen: screen,
+		})
+		if err != nil {
+			exitWithError("Failed to send push notification: %v", err)
+		}
+
+		fmt.Println(formatter.FormatPushResult(result))
+		if !result.Successful {
+			os.Exit(1)
+		}
+	},
+}
+
+// contains reports whether list holds s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	notifyCmd.Flags().StringVar(&notifyTitle, "title", "", "notification title")
+	notifyCmd.Flags().StringVar(&notifyMessage, "message", "", "notification message (required)")
+	notifyCmd.Flags().StringVar(&notifyScreen, "screen", "", "app screen to open, e.g. HOME or CONSUMPTION")
+	rootCmd.AddCommand(notifyCmd)
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/api"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+	"github.com/kristofferrisa/powerctl-cli/internal/peaks"
+)
+
+var (
+	peaksMonth string
+)
+
+var peaksCmd = &cobra.Command{
+	Use:   "peaks",
+	Short: "Show capacity tariff peaks for a month",
+	Long: `Show the month's capacity tariff (effekttariff) peaks.
+
+Norwegian grid companies bill by the average of the three highest hourly
+peaks in a month, each on a different day. This command computes them from
+hourly consumption history and shows the resulting capacity step.
+
+Configure your grid company's step table under capacity_steps in the
+config file.`,
+	Example: `  powerctl peaks
+  powerctl peaks --month 2025-09`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cfg.Validate(); err != nil {
+			exitWithError("%v", err)
+		}
+
+		client := api.NewClient(cfg.Token)
+		ctx := context.Background()
+
+		homeID := defaultHomeID(ctx, client)
+		useHomeTimezone(ctx, client, homeID)
+
+		// Months are calendar months in the home's timezone
+		month := peaks.MonthStart(time.Now(), homeLoc)
+		if peaksMonth != "" {
+			parsed, err := time.ParseInLocation("2006-01", peaksMonth, homeLoc)
+			if err != nil {
+				exitWithError("Invalid month %q, use YYYY-MM", peaksMonth)
+			}
+			month = parsed
+		}
+
+		report, err := monthPeaks(ctx, client, homeID, month)
+		if err != nil {
+			exitWithError("Failed to fetch consumption: %v", err)
+		}
+
+		fmt.Println(formatter.FormatPeaks(report))
+	},
+}
+
+// monthPeaks fetches hourly consumption for month and builds its peak report
+func monthPeaks(ctx context.Context, client *api.Client, homeID string, month time.Time) (*models.PeakReport, error) {
+	nodes, err := client.GetConsumption(ctx, homeID, models.EnergyResolutionHourly, month, month.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	report := peaks.BuildReport(month, peaks.FromConsumption(nodes), nil, cfg.CapacitySteps, homeLoc)
+	if len(nodes) > 0 {
+		report.Currency = nodes[0].Currency
+	}
+	return report, nil
+}
+
+func init() {
+	peaksCmd.Flags().StringVar(&peaksMonth, "month", "", "month to report, YYYY-MM (default: current month)")
+	rootCmd.AddCommand(peaksCmd)
+}
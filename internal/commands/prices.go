@@ -1,35 +1,434 @@
 package commands
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/kristofferrisa/powerctl-cli/internal/api"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+	"github.com/kristofferrisa/powerctl-cli/internal/peaks"
+	"github.com/kristofferrisa/powerctl-cli/internal/pricing"
+	"github.com/kristofferrisa/powerctl-cli/internal/term"
 )
 
+var (
+	pricesMinLevel   string
+	pricesMaxLevel   string
+	pricesFrom       string
+	pricesTo         string
+	pricesResolution string
+	pricesSpot       bool
+	pricesBreakdown  bool
+	pricesChart      bool
+
+	ratingPeriod string
+	ratingHomeID string
+
+	waitTimeout     time.Duration
+	waitMinInterval time.Duration
+	waitMaxInterval time.Duration
+)
+
+// exitTimeout is the exit code used when waiting times out
+const exitTimeout = 2
+
 var pricesCmd = &cobra.Command{
 	Use:   "prices",
 	Short: "Show electricity prices",
-	Long:  `Display current, today's, and tomorrow's electricity prices.`,
+	Long: `Display current, today's, and tomorrow's electricity prices.
+
+--min-level and --max-level limit today's and tomorrow's hours to a range of
+price levels, from VERY_CHEAP up to VERY_EXPENSIVE (bounds included).
+
+With a tariff in the config file, prices include the grid energy charge
+and fees for each hour. With subsidy rules, they are the effective price
+after the estimated state subsidy, based on the month's average so far.
+--spot shows the plain Tibber price instead.
+
+--breakdown splits each hour into energy and tax (and grid tariff and fees,
+if configured) as a stacked bar, with the share of each day's average that
+is tax.
+
+--chart draws today's and tomorrow's prices as one bar chart sized to the
+terminal, marking now and the cheapest and most expensive hour. When the
+output is not a terminal it is drawn 80 columns wide.
+
+--from and --to (YYYY-MM-DD, both inclusive) show historical prices instead,
+summarized as the overall average, lowest and highest price, and daily
+averages with min/max. --to defaults to today.`,
+	Example: `  powerctl prices
+  powerctl prices --max-level CHEAP
+  powerctl prices --min-level EXPENSIVE
+  powerctl prices --breakdown
+  powerctl prices --chart
+  powerctl prices --from 2025-01-01 --to 2025-01-31
+  powerctl prices --from 2024-01-01 --resolution daily --format json`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := cfg.Validate(); err != nil {
 			exitWithError("%v", err)
 		}
 
+		if pricesFrom != "" || pricesTo != "" {
+			if pricesBreakdown {
+				exitWithError("--breakdown cannot be combined with --from or --to")
+			}
+			if pricesChart {
+				exitWithError("--chart cannot be combined with --from or --to")
+			}
+			runPriceRange()
+			return
+		}
+
+		if pricesChart && pricesBreakdown {
+			exitWithError("--chart cannot be combined with --breakdown")
+		}
+		if pricesChart && (pricesMinLevel != "" || pricesMaxLevel != "") {
+			exitWithError("--chart cannot be combined with --min-level or --max-level")
+		}
+
+		minLevel, maxLevel := models.PriceLevelVeryCheap, models.PriceLevelVeryExpensive
+		var err error
+		if pricesMinLevel != "" {
+			if minLevel, err = models.ParsePriceLevel(pricesMinLevel); err != nil {
+				exitWithError("%v", err)
+			}
+		}
+		if pricesMaxLevel != "" {
+			if maxLevel, err = models.ParsePriceLevel(pricesMaxLevel); err != nil {
+				exitWithError("%v", err)
+			}
+		}
+		if minLevel > maxLevel {
+			exitWithError("--min-level %s is above --max-level %s", minLevel, maxLevel)
+		}
+
 		client := api.NewClient(cfg.Token)
 		ctx := context.Background()
+		useHomeTimezone(ctx, client, cfg.HomeID)
 
 		prices, err := client.GetPrices(ctx, cfg.HomeID)
 		if err != nil {
 			exitWithError("Failed to fetch prices: %v", err)
 		}
 
+		if tariff := activeTariff(pricesSpot); tariff != nil {
+			tariff.ApplyInfo(prices)
+		}
+		if subsidy := activeSubsidy(pricesSpot); subsidy != nil {
+			now := time.Now().In(homeLoc)
+			today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, homeLoc)
+			history, err := monthHistory(ctx, client, defaultHomeID(ctx, client), today, models.PriceResolutionHourly)
+			if err != nil {
+				exitWithError("Failed to fetch price history: %v", err)
+			}
+			subsidy.ApplyInfo(prices, history)
+		}
+		if converter := displayConverter(ctx); converter != nil {
+			if err := converter.PriceInfo(prices); err != nil {
+				exitWithError("Failed to convert prices: %v", err)
+			}
+		}
+
+		if pricesMinLevel != "" || pricesMaxLevel != "" {
+			prices.Today = filterPriceLevels(prices.Today, minLevel, maxLevel)
+			prices.Tomorrow = filterPriceLevels(prices.Tomorrow, minLevel, maxLevel)
+		}
+
+		if pricesBreakdown {
+			fmt.Println(formatter.FormatPriceBreakdown(pricing.Breakdown(prices, displayLoc)))
+			return
+		}
+		if pricesChart {
+			fmt.Println(formatter.FormatPriceChart(prices, term.Width(os.Stdout.Fd())))
+			return
+		}
 		fmt.Println(formatter.FormatPrices(prices, cfg.HomeID))
 	},
 }
 
+var pricesRatingCmd = &cobra.Command{
+	Use:   "rating",
+	Short: "Compare prices with the recent average",
+	Long: `Show how the current hour, day or month compares with the trailing
+average, using Tibber's price rating: each entry has its total price, the
+percentage difference from the trailing average and a LOW/NORMAL/HIGH level.
+
+Periods: hourly (last week and tomorrow), daily (last 30 days) and monthly
+(last 32 months).`,
+	Example: `  powerctl prices rating
+  powerctl prices rating --period monthly
+  powerctl prices rating --format json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cfg.Validate(); err != nil {
+			exitWithError("%v", err)
+		}
+		if !contains(models.RatingPeriods, ratingPeriod) {
+			exitWithError("Invalid period: %s. Valid periods: %s", ratingPeriod, strings.Join(models.RatingPeriods, ", "))
+		}
+
+		client := api.NewClient(cfg.Token)
+		ctx := context.Background()
+
+		homeID := ratingHomeID
+		if homeID == "" {
+			homeID = defaultHomeID(ctx, client)
+		}
+		useHomeTimezone(ctx, client, homeID)
+
+		rating, err := client.GetPriceRating(ctx, homeID, ratingPeriod)
+		if err != nil {
+			exitWithError("Failed to fetch price rating: %v", err)
+		}
+
+		report, err := rating.Report(ratingPeriod, time.Now())
+		if err != nil {
+			exitWithError("%v", err)
+		}
+
+		fmt.Println(formatter.FormatPriceRating(report))
+	},
+}
+
+var pricesWaitTomorrowCmd = &cobra.Command{
+	Use:   "wait-tomorrow [-- command [args...]]",
+	Short: "Wait until tomorrow's prices are published",
+	Long: `Poll prices until tomorrow's prices are published (usually around 13:00),
+then print them, or run a hook command. Prices include the configured
+tariff, subsidy and display currency, as in 'powerctl prices'.
+
+Polling starts at --min-interval and backs off to --max-interval. The hook
+command is run directly (not through a shell) with tomorrow's prices as
+JSON on stdin and POWERCTL_TOMORROW_DATE set in its environment.
+
+Exits with code 2 if --timeout passes first, so it composes with cron.`,
+	Example: `  powerctl prices wait-tomorrow --timeout 3h
+  powerctl prices wait-tomorrow -- ./plan-heating.sh`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cfg.Validate(); err != nil {
+			exitWithError("%v", err)
+		}
+		if waitMinInterval <= 0 || waitMaxInterval < waitMinInterval {
+			exitWithError("--min-interval must be positive and not above --max-interval")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), waitTimeout)
+		defer cancel()
+
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigChan
+			cancel()
+		}()
+
+		client := api.NewClient(cfg.Token)
+		useHomeTimezone(ctx, client, cfg.HomeID)
+		if err := waitForTomorrow(ctx, client, cfg.HomeID); err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				fmt.Fprintf(os.Stderr, "Error: tomorrow's prices not published within %s\n", waitTimeout)
+				os.Exit(exitTimeout)
+			}
+			exitWithError("%v", err)
+		}
+
+		// Polling only checks for tomorrow's prices; print and pass on the
+		// same numbers as 'powerctl prices'
+		info, err := effectivePrices(ctx, client, cfg.HomeID, displayConverter(ctx))
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		tomorrow := info.Tomorrow
+		if len(tomorrow) == 0 {
+			exitWithError("Tomorrow's prices are no longer available")
+		}
+
+		if len(args) == 0 {
+			fmt.Println(formatter.FormatPrices(&models.PriceInfo{Tomorrow: tomorrow}, cfg.HomeID))
+			return
+		}
+
+		if err := runTomorrowHook(args, tomorrow); err != nil {
+			exitWithError("Hook failed: %v", err)
+		}
+	},
+}
+
+// waitForTomorrow polls prices with exponential backoff until tomorrow's
+// prices are available or ctx ends
+func waitForTomorrow(ctx context.Context, client *api.Client, homeID string) error {
+	delay := waitMinInterval
+
+	for {
+		prices, err := client.GetPrices(ctx, homeID)
+		switch {
+		case err != nil && ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch prices: %v\n", err)
+		case len(prices.Tomorrow) > 0:
+			return nil
+		default:
+			fmt.Fprintf(os.Stderr, "Tomorrow's prices not yet published, checking again in %s\n", delay)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, waitMaxInterval)
+	}
+}
+
+// runTomorrowHook runs args[0] with tomorrow's prices as JSON on stdin
+func runTomorrowHook(args []string, tomorrow []models.Price) error {
+	data, err := json.Marshal(tomorrow)
+	if err != nil {
+		return fmt.Errorf("failed to encode prices: %w", err)
+	}
+
+	hook := exec.Command(args[0], args[1:]...)
+	hook.Stdin = bytes.NewReader(data)
+	hook.Stdout = os.Stdout
+	hook.Stderr = os.Stderr
+	hook.Env = append(os.Environ(), "POWERCTL_TOMORROW_DATE="+tomorrow[0].StartsAt.In(homeLoc).Format("2006-01-02"))
+
+	return hook.Run()
+}
+
+// runPriceRange prints a summary of historical prices for --from/--to
+func runPriceRange() {
+	if pricesFrom == "" {
+		exitWithError("--to requires --from")
+	}
+	if pricesMinLevel != "" || pricesMaxLevel != "" {
+		exitWithError("--min-level and --max-level cannot be combined with --from/--to")
+	}
+
+	resolution := strings.ToUpper(pricesResolution)
+	if !contains(models.PriceResolutions, resolution) {
+		exitWithError("Invalid resolution: %s. Valid resolutions: hourly, daily", pricesResolution)
+	}
+
+	client := api.NewClient(cfg.Token)
+	ctx := context.Background()
+
+	homeID := defaultHomeID(ctx, client)
+	useHomeTimezone(ctx, client, homeID)
+
+	// Dates are days in the displayed timezone
+	from, err := time.ParseInLocation("2006-01-02", pricesFrom, displayLoc)
+	if err != nil {
+		exitWithError("Invalid --from %q, use YYYY-MM-DD", pricesFrom)
+	}
+	to := time.Now().In(displayLoc)
+	if pricesTo != "" {
+		if to, err = time.ParseInLocation("2006-01-02", pricesTo, displayLoc); err != nil {
+			exitWithError("Invalid --to %q, use YYYY-MM-DD", pricesTo)
+		}
+	}
+	// Make --to inclusive by ending at the start of the next day
+	to = time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, displayLoc)
+	if !from.Before(to) {
+		exitWithError("--from must not be after --to")
+	}
+	prices, err := client.GetPriceRange(ctx, homeID, from, to, resolution)
+	if err != nil {
+		exitWithError("Failed to fetch prices: %v", err)
+	}
+
+	if tariff := activeTariff(pricesSpot); tariff != nil {
+		if resolution == models.PriceResolutionDaily {
+			tariff.ApplyDaily(prices)
+		} else {
+			tariff.Apply(prices)
+		}
+	}
+	if subsidy := activeSubsidy(pricesSpot); subsidy != nil {
+		history, err := monthHistory(ctx, client, homeID, from, resolution)
+		if err != nil {
+			exitWithError("Failed to fetch price history: %v", err)
+		}
+		subsidy.Apply(prices, append(history, prices...))
+	}
+
+	var conversion *models.Conversion
+	if converter := displayConverter(ctx); converter != nil {
+		if conversion, err = converter.Prices(prices); err != nil {
+			exitWithError("Failed to convert prices: %v", err)
+		}
+	}
+
+	report := pricing.Summarize(prices, from, to, resolution, displayLoc)
+	report.Conversion = conversion
+	fmt.Println(formatter.FormatPriceRange(report))
+}
+
+// filterPriceLevels keeps prices with a known level in [minLevel, maxLevel]
+func filterPriceLevels(prices []models.Price, minLevel, maxLevel models.PriceLevel) []models.Price {
+	var kept []models.Price
+	for _, p := range prices {
+		if p.Level >= minLevel && p.Level <= maxLevel {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// activeTariff returns the configured tariff, or nil when none is
+// configured or spotOnly is set
+func activeTariff(spotOnly bool) *pricing.Tariff {
+	if spotOnly || !cfg.Tariff.Configured() {
+		return nil
+	}
+	return pricing.NewTariff(cfg.Tariff, homeLoc)
+}
+
+// activeSubsidy returns the configured subsidy, or nil when no rules are
+// configured or spotOnly is set
+func activeSubsidy(spotOnly bool) *pricing.Subsidy {
+	if spotOnly || len(cfg.Subsidy.Rules) == 0 {
+		return nil
+	}
+	return pricing.NewSubsidy(cfg.Subsidy, homeLoc)
+}
+
+// monthHistory fetches the prices of until's month before until, which the
+// subsidy needs for the monthly average
+func monthHistory(ctx context.Context, client *api.Client, homeID string, until time.Time, resolution string) ([]models.Price, error) {
+	month := peaks.MonthStart(until, homeLoc)
+	if !month.Before(until) {
+		return nil, nil
+	}
+	return client.GetPriceRange(ctx, homeID, month, until, resolution)
+}
+
 func init() {
+	pricesWaitTomorrowCmd.Flags().DurationVar(&waitTimeout, "timeout", 3*time.Hour, "give up after this long")
+	pricesWaitTomorrowCmd.Flags().DurationVar(&waitMinInterval, "min-interval", time.Minute, "initial polling interval")
+	pricesWaitTomorrowCmd.Flags().DurationVar(&waitMaxInterval, "max-interval", 10*time.Minute, "maximum polling interval")
+	pricesCmd.Flags().StringVar(&pricesMinLevel, "min-level", "", "only show hours at or above this price level")
+	pricesCmd.Flags().StringVar(&pricesMaxLevel, "max-level", "", "only show hours at or below this price level")
+	pricesCmd.Flags().StringVar(&pricesFrom, "from", "", "show historical prices from this date, YYYY-MM-DD")
+	pricesCmd.Flags().StringVar(&pricesTo, "to", "", "last date of the historical range, YYYY-MM-DD (default: today)")
+	pricesCmd.Flags().StringVar(&pricesResolution, "resolution", "hourly", "historical price resolution: hourly or daily")
+	pricesCmd.Flags().BoolVar(&pricesSpot, "spot", false, "show spot prices without the configured tariff and subsidy")
+	pricesCmd.Flags().BoolVar(&pricesBreakdown, "breakdown", false, "show each price split into energy and tax")
+	pricesCmd.Flags().BoolVar(&pricesChart, "chart", false, "show prices as a bar chart sized to the terminal")
+	pricesRatingCmd.Flags().StringVar(&ratingPeriod, "period", models.RatingDaily, "rating period: hourly, daily or monthly")
+	pricesRatingCmd.Flags().StringVar(&ratingHomeID, "home-id", "", "home to rate (default: configured or first home)")
+	pricesCmd.AddCommand(pricesRatingCmd)
+	pricesCmd.AddCommand(pricesWaitTomorrowCmd)
 	rootCmd.AddCommand(pricesCmd)
 }
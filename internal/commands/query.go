@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/api"
+)
+
+var (
+	queryFile     string
+	queryVars     []string
+	queryVarsJSON []string
+	queryVarsFile string
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query [document]",
+	Short: "Run a raw GraphQL query",
+	Long: `Send a GraphQL document to the Tibber API using your configured token.
+
+The document is read from the argument, from --file, or from stdin when
+neither is given (or the argument is "-"). Variables come from --vars-file
+(a JSON object) and from --var and --var-json flags, which take precedence.
+--var values are always sent as strings, which suits ID and String
+variables; use --var-json key=<json> for numbers, booleans and objects.
+
+The data object is printed as JSON on stdout and every error on stderr.
+Exits with code 1 if the response contains errors.`,
+	Example: `  powerctl query '{ viewer { name } }'
+  powerctl query --file homes.graphql
+  echo 'query($id: ID!) { viewer { home(id: $id) { appNickname } } }' | powerctl query --var id=abc123
+  powerctl query --file consumption.graphql --var homeId=abc123 --var-json last=24`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cfg.Validate(); err != nil {
+			exitWithError("%v", err)
+		}
+
+		document, err := readQueryDocument(args)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+
+		variables, err := readQueryVariables()
+		if err != nil {
+			exitWithError("%v", err)
+		}
+
+		client := api.NewClient(cfg.Token)
+		resp, err := client.Do(context.Background(), document, variables)
+		if err != nil {
+			exitWithError("Query failed: %v", err)
+		}
+
+		if len(resp.Data) > 0 && string(resp.Data) != "null" {
+			var out bytes.Buffer
+			if err := json.Indent(&out, resp.Data, "", "  "); err != nil {
+				exitWithError("Failed to format response: %v", err)
+			}
+			fmt.Println(out.String())
+		}
+
+		for _, e := range resp.Errors {
+			data, _ := json.Marshal(e)
+			fmt.Fprintf(os.Stderr, "GraphQL error: %s\n", data)
+		}
+		if len(resp.Errors) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// readQueryDocument returns the document from the argument, --file or stdin
+func readQueryDocument(args []string) (string, error) {
+	var data []byte
+	var err error
+
+	switch {
+	case queryFile != "" && len(args) > 0:
+		return "", fmt.Errorf("pass the document as an argument or with --file, not both")
+	case queryFile != "":
+		data, err = os.ReadFile(queryFile)
+	case len(args) > 0 && args[0] != "-":
+		data = []byte(args[0])
+	default:
+		data, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read query: %w", err)
+	}
+
+	document := strings.TrimSpace(string(data))
+	if document == "" {
+		return "", fmt.Errorf("empty query document")
+	}
+	return document, nil
+}
+
+// readQueryVariables merges --vars-file with --var and --var-json flags
+func readQueryVariables() (map[string]interface{}, error) {
+	variables := make(map[string]interface{})
+
+	if queryVarsFile != "" {
+		data, err := os.ReadFile(queryVarsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read variables: %w", err)
+		}
+		if err := json.Unmarshal(data, &variables); err != nil {
+			return nil, fmt.Errorf("variables file must be a JSON object: %w", err)
+		}
+	}
+
+	for _, kv := range queryVars {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var %q, use key=value", kv)
+		}
+		variables[key] = value
+	}
+
+	for _, kv := range queryVarsJSON {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var-json %q, use key=<json>", kv)
+		}
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+			return nil, fmt.Errorf("invalid JSON in --var-json %s: %w", key, err)
+		}
+		variables[key] = parsed
+	}
+
+	if len(variables) == 0 {
+		return nil, nil
+	}
+	return variables, nil
+}
+
+func init() {
+	queryCmd.Flags().StringVar(&queryFile, "file", "", "read the GraphQL document from a file")
+	queryCmd.Flags().StringArrayVar(&queryVars, "var", nil, "string variable as key=value (repeatable)")
+	queryCmd.Flags().StringArrayVar(&queryVarsJSON, "var-json", nil, "JSON variable as key=<json>, e.g. last=24 (repeatable)")
+	queryCmd.Flags().StringVar(&queryVarsFile, "vars-file", "", "read variables from a JSON file")
+	rootCmd.AddCommand(queryCmd)
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/api"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+	"github.com/kristofferrisa/powerctl-cli/internal/peaks"
+	"github.com/kristofferrisa/powerctl-cli/internal/pricing"
+)
+
+var (
+	reportMonth string
+	reportTop   int
+	reportSpot  bool
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Reconcile a month's consumption and cost",
+	Long: `Summarize a month's hourly consumption and cost, for checking against the
+Tibber invoice.
+
+The report compares the volume-weighted average price you paid with the
+month's average spot price: a negative timing percentage means you used
+more power in cheaper hours than a flat load would. It also lists the most
+expensive hours and breaks consumption down by price level.
+
+With a tariff in the config file, hourly costs include the grid energy
+charge and fees, and the monthly fee is added to the total. With subsidy
+rules, the subsidy under the rules in effect that month is deducted.
+--spot leaves both out.
+
+Use --format markdown for a report to paste into a wiki.`,
+	Example: `  powerctl report --month 2025-09
+  powerctl report --month 2025-09 --format markdown
+  powerctl report --top 10 --format json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cfg.Validate(); err != nil {
+			exitWithError("%v", err)
+		}
+
+		if reportTop < 1 {
+			exitWithError("--top must be at least 1")
+		}
+
+		client := api.NewClient(cfg.Token)
+		ctx := context.Background()
+
+		homeID := defaultHomeID(ctx, client)
+		useHomeTimezone(ctx, client, homeID)
+
+		// Months are calendar months in the home's timezone
+		month := peaks.MonthStart(time.Now(), homeLoc)
+		if reportMonth != "" {
+			parsed, err := time.ParseInLocation("2006-01", reportMonth, homeLoc)
+			if err != nil {
+				exitWithError("Invalid month %q, use YYYY-MM", reportMonth)
+			}
+			month = parsed
+		}
+		end := month.AddDate(0, 1, 0)
+
+		nodes, err := client.GetConsumption(ctx, homeID, models.EnergyResolutionHourly, month, end)
+		if err != nil {
+			exitWithError("Failed to fetch consumption: %v", err)
+		}
+		prices, err := client.GetPriceRange(ctx, homeID, month, end, models.PriceResolutionHourly)
+		if err != nil {
+			exitWithError("Failed to fetch prices: %v", err)
+		}
+
+		fixedFee := 0.0
+		if tariff := activeTariff(reportSpot); tariff != nil {
+			tariff.Apply(prices)
+			fixedFee = tariff.MonthlyFee()
+		}
+		if subsidy := activeSubsidy(reportSpot); subsidy != nil {
+			subsidy.Apply(prices, prices)
+		}
+
+		report := pricing.BuildCostReport(month.Format("2006-01"), nodes, prices, fixedFee, reportTop)
+		if converter := displayConverter(ctx); converter != nil {
+			if err := converter.CostReport(report); err != nil {
+				exitWithError("Failed to convert costs: %v", err)
+			}
+		}
+		fmt.Println(formatter.FormatCostReport(report))
+	},
+}
+
+func init() {
+	reportCmd.Flags().StringVar(&reportMonth, "month", "", "month to report, YYYY-MM (default: current month)")
+	reportCmd.Flags().IntVar(&reportTop, "top", pricing.DefaultTopHours, "number of most expensive hours to list")
+	reportCmd.Flags().BoolVar(&reportSpot, "spot", false, "leave out the configured tariff and subsidy")
+	rootCmd.AddCommand(reportCmd)
+}
@@ -1,20 +1,40 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/kristofferrisa/powerctl-cli/internal/api"
 	"github.com/kristofferrisa/powerctl-cli/internal/config"
+	"github.com/kristofferrisa/powerctl-cli/internal/currency"
+	"github.com/kristofferrisa/powerctl-cli/internal/i18n"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
 	"github.com/kristofferrisa/powerctl-cli/internal/output"
+	"github.com/kristofferrisa/powerctl-cli/internal/term"
 )
 
 var (
-	cfgFile    string
-	formatFlag string
-	cfg        *config.Config
-	formatter  output.Formatter
+	cfgFile      string
+	formatFlag   string
+	currencyFlag string
+	colorFlag    string
+	asciiFlag    bool
+	langFlag     string
+	tzFlag       string
+	cfg          *config.Config
+	formatter    output.Formatter
+
+	// homeLoc is the home's timezone, which tariffs, subsidies and
+	// capacity peaks follow; displayLoc is the one times are shown in,
+	// the home's unless --tz is set. Both are the system's until
+	// useHomeTimezone knows better.
+	homeLoc    = time.Local
+	displayLoc = time.Local
 )
 
 // rootCmd represents the base command
@@ -27,22 +47,60 @@ Get real-time power consumption from your Tibber Pulse,
 view electricity prices, and manage your Tibber homes.
 
 Set your API token via TIBBER_TOKEN environment variable
-or in ~/.tibber/config.yaml`,
+or in $XDG_CONFIG_HOME/powerctl/config.yaml
+(default: ~/.config/powerctl/config.yaml)`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		var err error
-		cfg, err = config.Load(cfgFile)
-		if err != nil {
+		if cfg, err = config.Load(cfgFile); err != nil {
 			return err
 		}
+		return setup()
+	},
+}
 
-		// Override format if flag is set
-		if formatFlag != "" {
-			cfg.Format = formatFlag
+// setup prints the config's notices, applies the global flags to cfg and
+// creates the formatter
+func setup() error {
+	var err error
+	for _, notice := range cfg.Notices {
+		fmt.Fprintln(os.Stderr, notice)
+	}
+
+	// Override format if flag is set
+	if formatFlag != "" {
+		cfg.Format = formatFlag
+	}
+	if currencyFlag != "" {
+		cfg.Currency.Display = strings.ToUpper(currencyFlag)
+		if err := config.ValidateCurrencyCode(cfg.Currency.Display); err != nil {
+			return err
 		}
+	}
 
-		formatter = output.New(cfg.Format)
-		return nil
-	},
+	if colorFlag != "" {
+		cfg.Display.Color = strings.ToLower(colorFlag)
+		if err := config.ValidateColorMode(cfg.Display.Color); err != nil {
+			return err
+		}
+	}
+	if asciiFlag {
+		cfg.Display.ASCII = true
+	}
+	if langFlag != "" {
+		if cfg.Lang, err = i18n.Parse(langFlag); err != nil {
+			return err
+		}
+	}
+
+	homeLoc, displayLoc = time.Local, time.Local
+	if tzFlag != "" {
+		if displayLoc, err = time.LoadLocation(tzFlag); err != nil {
+			return fmt.Errorf("invalid --tz %q: %w", tzFlag, err)
+		}
+	}
+
+	formatter = newFormatter()
+	return nil
 }
 
 // Execute runs the root command
@@ -51,8 +109,122 @@ func Execute() error {
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default: ~/.tibber/config.yaml)")
+	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default: ~/.config/powerctl/config.yaml)")
 	rootCmd.PersistentFlags().StringVarP(&formatFlag, "format", "f", "", "output format: json, markdown (default: pretty)")
+	rootCmd.PersistentFlags().StringVar(&currencyFlag, "currency", "", "convert prices and costs to this currency, e.g. EUR")
+	rootCmd.PersistentFlags().StringVar(&colorFlag, "color", "", "colored output: auto, always or never (default: auto)")
+	rootCmd.PersistentFlags().BoolVar(&asciiFlag, "ascii", false, "replace emoji and box-drawing characters with plain ASCII")
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "language of labels, numbers and dates: en, nb, sv or de (default: en)")
+	rootCmd.PersistentFlags().StringVar(&tzFlag, "tz", "", "timezone to show times in, e.g. Europe/Oslo or UTC (default: the home's)")
+}
+
+// newFormatter creates the formatter for the output flags and displayLoc
+func newFormatter() output.Formatter {
+	return output.New(cfg.Format, displayPriceFormat(), outputStyle(), i18n.New(cfg.Lang), displayLoc)
+}
+
+// outputStyle decides on colors and glyphs. In auto mode colors are used
+// only on a terminal that supports them, and NO_COLOR or TERM=dumb turn
+// them off; --color always and never override all of that.
+func outputStyle() output.Style {
+	style := output.Style{ASCII: cfg.Display.ASCII}
+	switch cfg.Display.Color {
+	case config.ColorAlways:
+		style.Color = true
+	case config.ColorNever:
+		style.Color = false
+	default:
+		style.Color = os.Getenv("NO_COLOR") == "" && interactiveOutput()
+	}
+	return style
+}
+
+// interactiveOutput reports whether stdout is a terminal that understands
+// escape sequences, so the screen may be cleared and redrawn
+func interactiveOutput() bool {
+	return os.Getenv("TERM") != "dumb" && term.IsTerminal(os.Stdout.Fd()) && term.EnableVT(os.Stdout.Fd()) == nil
+}
+
+// displayPriceFormat is the price format from the display config
+func displayPriceFormat() *output.PriceFormat {
+	return &output.PriceFormat{
+		MinorUnit:  cfg.Display.Unit == config.UnitMinor,
+		Decimals:   cfg.Display.Decimals,
+		Components: cfg.Display.Components,
+	}
+}
+
+// defaultHomeID returns the configured home ID, or the first home's ID
+func defaultHomeID(ctx context.Context, client *api.Client) string {
+	if cfg.HomeID != "" {
+		return cfg.HomeID
+	}
+
+	homes, err := client.GetHomes(ctx)
+	if err != nil {
+		exitWithError("Failed to fetch homes: %v", err)
+	}
+	if len(homes) == 0 {
+		exitWithError("No homes found")
+	}
+	return homes[0].ID
+}
+
+// useHomeTimezone sets homeLoc to the timezone of the home with homeID, or
+// of the first home when homeID is empty, and shows times in it unless --tz
+// is set. Without homes the system's timezone is kept.
+func useHomeTimezone(ctx context.Context, client *api.Client, homeID string) {
+	homes, err := client.GetHomes(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to fetch homes, using the system timezone: %v\n", err)
+		return
+	}
+	setHomeTimezone(homes, homeID)
+}
+
+// setHomeTimezone is useHomeTimezone for homes that are already fetched
+func setHomeTimezone(homes []models.HomeResponse, homeID string) {
+	for i := range homes {
+		if homeID == "" || homes[i].ID == homeID {
+			homeLoc = homeLocation(&homes[i].Home)
+			if tzFlag == "" {
+				displayLoc = homeLoc
+				formatter = newFormatter()
+			}
+			return
+		}
+	}
+}
+
+// homeLocation returns the home's timezone, or the system's when the API
+// doesn't know it or it isn't in the timezone database
+func homeLocation(home *models.Home) *time.Location {
+	if home.TimeZone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(home.TimeZone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// displayConverter returns a converter to the display currency, or nil
+// when none is set
+func displayConverter(ctx context.Context) *currency.Converter {
+	if cfg.Currency.Display == "" {
+		return nil
+	}
+
+	rates, err := currency.NewProvider(cfg.Currency.Rates, config.CacheDir()).Rates(ctx)
+	if err != nil {
+		exitWithError("Failed to load exchange rates: %v", err)
+	}
+	converter, err := currency.NewConverter(rates, cfg.Currency.Display)
+	if err != nil {
+		exitWithError("%v", err)
+	}
+	return converter
 }
 
 // exitWithError prints an error and exits
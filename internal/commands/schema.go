@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/api"
+	"github.com/kristofferrisa/powerctl-cli/internal/schema"
+)
+
+var (
+	schemaDumpDir  string
+	schemaDumpName string
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Work with the Tibber GraphQL schema",
+}
+
+var schemaDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Save the Tibber GraphQL schema as JSON and SDL",
+	Long: `Run an introspection query against the Tibber API and save the result as
+<name>.json (the raw introspection data) and <name>.graphql (SDL).
+
+The JSON file is the snapshot the Go models are generated from. To pick up
+schema changes, dump into internal/schema and run go generate:
+
+  powerctl schema dump --dir internal/schema
+  go generate ./...`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cfg.Validate(); err != nil {
+			exitWithError("%v", err)
+		}
+
+		client := api.NewClient(cfg.Token)
+		resp, err := client.Do(context.Background(), schema.IntrospectionQuery, nil)
+		if err != nil {
+			exitWithError("Introspection failed: %v", err)
+		}
+		if len(resp.Errors) > 0 {
+			exitWithError("Introspection failed: %s", resp.Errors[0].Message)
+		}
+
+		s, err := schema.Parse(resp.Data)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+
+		var data bytes.Buffer
+		if err := json.Indent(&data, resp.Data, "", "  "); err != nil {
+			exitWithError("Failed to format schema: %v", err)
+		}
+		data.WriteString("\n")
+
+		if err := os.MkdirAll(schemaDumpDir, 0755); err != nil {
+			exitWithError("Failed to create %s: %v", schemaDumpDir, err)
+		}
+
+		jsonPath := filepath.Join(schemaDumpDir, schemaDumpName+".json")
+		sdlPath := filepath.Join(schemaDumpDir, schemaDumpName+".graphql")
+		if err := os.WriteFile(jsonPath, data.Bytes(), 0644); err != nil {
+			exitWithError("Failed to write %s: %v", jsonPath, err)
+		}
+		if err := os.WriteFile(sdlPath, []byte(s.SDL()), 0644); err != nil {
+			exitWithError("Failed to write %s: %v", sdlPath, err)
+		}
+
+		fmt.Printf("Wrote %d types to %s and %s\n", len(s.Types), jsonPath, sdlPath)
+	},
+}
+
+func init() {
+	schemaDumpCmd.Flags().StringVar(&schemaDumpDir, "dir", ".", "directory to write the schema files to")
+	schemaDumpCmd.Flags().StringVar(&schemaDumpName, "name", "tibber", "base name of the schema files")
+	schemaCmd.AddCommand(schemaDumpCmd)
+	rootCmd.AddCommand(schemaCmd)
+}
@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/api"
+	"github.com/kristofferrisa/powerctl-cli/internal/config"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+	"github.com/kristofferrisa/powerctl-cli/internal/notifier"
+)
+
+var (
+	watchInterval time.Duration
+	watchHomeID   string
+)
+
+const (
+	// watchMinReconnect and watchMaxReconnect bound the live stream
+	// reconnect backoff (Tibber allows 20 connections per hour)
+	watchMinReconnect = 10 * time.Second
+	watchMaxReconnect = 5 * time.Minute
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Send webhook notifications when rules fire",
+	Long: `Run until interrupted, evaluating notification rules and POSTing events
+to the webhooks configured under notify in the config file.
+
+Rules are driven by polling prices and, for power_above and stream_lost
+rules, by the live stream:
+
+  price_level      the current price level changes to the given level
+  tomorrow_prices  tomorrow's prices are published
+  power_above      power stays above N watts for M minutes
+  stream_lost      the live stream disconnects
+
+Deliveries run in the background and are retried with backoff; events that
+still cannot be delivered, or that arrive while 100 are already waiting,
+are appended to a dead-letter log in the state directory. On interrupt,
+waiting events get up to 10 seconds to be delivered.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cfg.Validate(); err != nil {
+			exitWithError("%v", err)
+		}
+
+		if watchInterval <= 0 {
+			exitWithError("--interval must be positive")
+		}
+		if len(cfg.Notify.Rules) == 0 {
+			exitWithError("No notification rules configured. Add notify.rules to %s", config.DefaultConfigPath())
+		}
+		if len(cfg.Notify.Webhooks) == 0 {
+			fmt.Fprintln(os.Stderr, "Warning: no webhooks configured, events are only printed")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigChan
+			cancel()
+		}()
+
+		client := api.NewClient(cfg.Token)
+		homeID := watchHomeID
+		if homeID == "" {
+			homeID = defaultHomeID(ctx, client)
+		}
+		useHomeTimezone(ctx, client, homeID)
+
+		engine := notifier.NewEngine(cfg.Notify.Rules, homeID)
+		n := notifier.New(cfg.Notify.Webhooks, notifier.DefaultDeadLetterPath())
+		// Deliveries retry for a while, so they run off this loop and the
+		// producers, including the live stream, are never held up
+		queue := notifier.NewQueue(ctx, n, notifier.DefaultQueueSize, func(err error) {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		})
+		defer queue.Close()
+		events := make(chan models.Event)
+
+		go watchPrices(ctx, client, homeID, engine, events)
+		if engine.NeedsStream() {
+			go watchStream(ctx, homeID, engine, events)
+		}
+
+		fmt.Fprintf(os.Stderr, "Watching home %s (%d rules, %d webhooks)...\n",
+			homeID, len(cfg.Notify.Rules), len(cfg.Notify.Webhooks))
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-events:
+				fmt.Println(formatter.FormatEvent(&event))
+				queue.Enqueue(event)
+			}
+		}
+	},
+}
+
+// watchPrices polls prices every watchInterval and emits price rule events
+func watchPrices(ctx context.Context, client *api.Client, homeID string, engine *notifier.Engine, events chan<- models.Event) {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		prices, err := client.GetPrices(ctx, homeID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch prices: %v\n", err)
+		} else {
+			for _, event := range engine.CheckPrices(prices, time.Now()) {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchStream follows the live stream, reconnecting with backoff, and emits
+// power and stream_lost events
+func watchStream(ctx context.Context, homeID string, engine *notifier.Engine, events chan<- models.Event) {
+	liveClient := api.NewLiveClient(cfg.Token, homeID)
+	backoff := watchMinReconnect
+
+	emit := func(list []models.Event) {
+		for _, event := range list {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		}
+	}
+
+	for {
+		err := liveClient.Subscribe(ctx, func(m *models.LiveMeasurement) error {
+			backoff = watchMinReconnect
+			emit(engine.CheckPower(m))
+			return nil
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = fmt.Errorf("stream completed")
+		}
+
+		emit(engine.StreamLost(err, time.Now()))
+		fmt.Fprintf(os.Stderr, "Warning: live stream lost (%v), reconnecting in %s\n", err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, watchMaxReconnect)
+	}
+}
+
+func init() {
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Minute, "price polling interval")
+	watchCmd.Flags().StringVar(&watchHomeID, "home-id", "", "home to watch (default: configured or first home)")
+	rootCmd.AddCommand(watchCmd)
+}
@@ -1,22 +1,475 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/i18n"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+const (
+	// AppName is the directory name used under the XDG base directories
+	AppName = "powerctl"
+
+	// CurrentConfigVersion is the config schema version written by this build
+	CurrentConfigVersion = 1
 )
 
 // Config holds the application configuration
 type Config struct {
-	Token  string `mapstructure:"token"`
-	HomeID string `mapstructure:"home_id"`
-	Format string `mapstructure:"format"`
+	ConfigVersion int    `mapstructure:"config_version"`
+	Token         string `mapstructure:"token"`
+	HomeID        string `mapstructure:"home_id"`
+	Format        string `mapstructure:"format"`
+	// Lang is the language of human-readable output, one of i18n.Langs
+	Lang string `mapstructure:"lang"`
+
+	// CapacitySteps is the grid company's capacity tariff table (effekttrinn)
+	CapacitySteps []CapacityStep `mapstructure:"capacity_steps"`
+
+	// Tariff is the grid tariff and fees added to spot prices
+	Tariff TariffConfig `mapstructure:"tariff"`
+
+	// Subsidy holds the state electricity subsidy rules
+	Subsidy SubsidyConfig `mapstructure:"subsidy"`
+
+	// Currency selects a display currency and exchange rate source
+	Currency CurrencyConfig `mapstructure:"currency"`
+
+	// Display controls how prices per kWh are printed
+	Display DisplayConfig `mapstructure:"display"`
+
+	// Alerts configures live stream warnings
+	Alerts AlertConfig `mapstructure:"alerts"`
+
+	// Notify configures webhook notifications sent by `powerctl watch`
+	Notify NotifyConfig `mapstructure:"notify"`
+
+	// Path is the config file that was read, empty if none was found
+	Path string `mapstructure:"-"`
+	// TokenSource records where Token came from (TokenSourceEnv or TokenSourceFile)
+	TokenSource string `mapstructure:"-"`
+	// Notices are human-readable messages produced while loading,
+	// e.g. when a legacy config file was migrated
+	Notices []string `mapstructure:"-"`
+}
+
+// CapacityStep is one step of a capacity tariff. A step applies when the
+// monthly peak average is below LimitKW; LimitKW 0 means unbounded.
+type CapacityStep struct {
+	LimitKW float64 `mapstructure:"limit_kw" yaml:"limit_kw"`
+	Price   float64 `mapstructure:"price" yaml:"price"`
+}
+
+// DefaultCapacitySteps is a typical Norwegian step table (monthly fee per
+// step). Grid companies differ, so users should configure their own.
+var DefaultCapacitySteps = []CapacityStep{
+	{LimitKW: 2, Price: 130},
+	{LimitKW: 5, Price: 190},
+	{LimitKW: 10, Price: 280},
+	{LimitKW: 15, Price: 580},
+	{LimitKW: 20, Price: 770},
+	{LimitKW: 25, Price: 960},
+	{LimitKW: 50, Price: 1840},
+	{LimitKW: 0, Price: 2800},
+}
+
+// TariffConfig describes the grid company's energy charge (nettleie) and
+// government fees, which come on top of the spot price. Per-kWh amounts and
+// MonthlyFee exclude VAT; VAT is a percentage applied to all of them.
+type TariffConfig struct {
+	VAT        float64 `mapstructure:"vat"`
+	MonthlyFee float64 `mapstructure:"monthly_fee"`
+	// Fees is charged per kWh at all times, e.g. consumption tax and Enova fee
+	Fees float64 `mapstructure:"fees"`
+	// Holidays are YYYY-MM-DD dates billed by holiday and weekend bands
+	Holidays []string       `mapstructure:"holidays"`
+	Seasons  []TariffSeason `mapstructure:"seasons"`
+}
+
+// Configured reports whether the tariff adds anything to spot prices
+func (t TariffConfig) Configured() bool {
+	return len(t.Seasons) > 0 || t.Fees != 0 || t.MonthlyFee != 0
+}
+
+// TariffSeason holds the time-of-use bands for some months of the year.
+// The first season listing a month applies; no months means all year.
+type TariffSeason struct {
+	Name   string       `mapstructure:"name"`
+	Months []int        `mapstructure:"months"`
+	Bands  []TariffBand `mapstructure:"bands"`
+}
+
+// Tariff band day kinds. Weekend bands also cover holidays.
+const (
+	TariffDaysAll     = "all"
+	TariffDaysWeekday = "weekday"
+	TariffDaysWeekend = "weekend"
+	TariffDaysHoliday = "holiday"
+)
+
+// TariffBand is an energy charge per kWh for a time of day. From and To
+// are HH:MM local time and default to the whole day; a To before From
+// wraps past midnight. The first matching band of a season applies.
+type TariffBand struct {
+	Name  string  `mapstructure:"name"`
+	Days  string  `mapstructure:"days"`
+	From  string  `mapstructure:"from"`
+	To    string  `mapstructure:"to"`
+	Price float64 `mapstructure:"price"`
+}
+
+// ParseClock parses an HH:MM time of day into minutes after midnight.
+// "24:00" is accepted as the end of the day.
+func ParseClock(s string) (int, error) {
+	if s == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, use HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// validateTariff checks VAT, holiday dates, season months and band fields
+func validateTariff(t TariffConfig) error {
+	if t.VAT < 0 || t.VAT > 100 {
+		return fmt.Errorf("vat must be a percentage between 0 and 100")
+	}
+	for _, day := range t.Holidays {
+		if _, err := time.Parse("2006-01-02", day); err != nil {
+			return fmt.Errorf("invalid holiday %q, use YYYY-MM-DD", day)
+		}
+	}
+	for _, season := range t.Seasons {
+		for _, m := range season.Months {
+			if m < 1 || m > 12 {
+				return fmt.Errorf("season %q: invalid month %d", season.Name, m)
+			}
+		}
+		for _, band := range season.Bands {
+			switch band.Days {
+			case "", TariffDaysAll, TariffDaysWeekday, TariffDaysWeekend, TariffDaysHoliday:
+			default:
+				return fmt.Errorf("band %q: invalid days %q", band.Name, band.Days)
+			}
+			for _, clock := range []string{band.From, band.To} {
+				if clock == "" {
+					continue
+				}
+				if _, err := ParseClock(clock); err != nil {
+					return fmt.Errorf("band %q: %w", band.Name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SubsidyConfig lists the rules of the Norwegian electricity subsidy
+// (strømstøtte, later Norgespris). Each rule applies from its From date
+// until the next rule takes effect, so past months use the rules of the
+// time. No rules means no subsidy.
+type SubsidyConfig struct {
+	Rules []SubsidyRule `mapstructure:"rules"`
+}
+
+// Subsidy schemes
+const (
+	// SubsidyMonthlyAverage covers Coverage percent of the month's average
+	// spot price above Threshold, the same amount for every hour
+	SubsidyMonthlyAverage = "monthly_average"
+	// SubsidyHourly covers Coverage percent of each hour's spot price above
+	// Threshold
+	SubsidyHourly = "hourly"
+	// SubsidyFixedPrice settles every hour to the fixed Price, so the
+	// subsidy is negative when the spot price is below it
+	SubsidyFixedPrice = "fixed_price"
+)
+
+// SubsidySchemes are the valid subsidy schemes
+var SubsidySchemes = []string{SubsidyMonthlyAverage, SubsidyHourly, SubsidyFixedPrice}
+
+// SubsidyRule is one version of the subsidy rules. Threshold and Price are
+// per kWh excluding VAT; VAT is the percentage added to the subsidy.
+type SubsidyRule struct {
+	From      string  `mapstructure:"from"`
+	Scheme    string  `mapstructure:"scheme"`
+	Threshold float64 `mapstructure:"threshold"`
+	Coverage  float64 `mapstructure:"coverage"`
+	Price     float64 `mapstructure:"price"`
+	VAT       float64 `mapstructure:"vat"`
+}
+
+// validateSubsidy checks that rules have known schemes, sensible
+// parameters and ascending From dates
+func validateSubsidy(s SubsidyConfig) error {
+	var last time.Time
+	for i, rule := range s.Rules {
+		from, err := time.Parse("2006-01-02", rule.From)
+		if err != nil {
+			return fmt.Errorf("rule %d: invalid from %q, use YYYY-MM-DD", i+1, rule.From)
+		}
+		if i > 0 && !from.After(last) {
+			return fmt.Errorf("rule %d: from dates must be ascending", i+1)
+		}
+		last = from
+
+		switch rule.Scheme {
+		case SubsidyMonthlyAverage, SubsidyHourly:
+			if rule.Coverage <= 0 || rule.Coverage > 100 {
+				return fmt.Errorf("rule %d: coverage must be a percentage between 0 and 100", i+1)
+			}
+		case SubsidyFixedPrice:
+			if rule.Price <= 0 {
+				return fmt.Errorf("rule %d: %s requires price", i+1, rule.Scheme)
+			}
+		default:
+			return fmt.Errorf("rule %d: unknown scheme %q", i+1, rule.Scheme)
+		}
+		if rule.VAT < 0 || rule.VAT > 100 {
+			return fmt.Errorf("rule %d: vat must be a percentage between 0 and 100", i+1)
+		}
+	}
+	return nil
+}
+
+// RatesECB selects the European Central Bank's daily reference rates
+const RatesECB = "ecb"
+
+// CurrencyConfig converts prices and costs for display. An empty Display
+// keeps the home's currency. Rates is RatesECB or the path of a rates file.
+type CurrencyConfig struct {
+	Display string `mapstructure:"display"`
+	Rates   string `mapstructure:"rates"`
+}
+
+// DefaultCurrency fetches ECB rates and converts nothing until a display
+// currency is set
+var DefaultCurrency = CurrencyConfig{Rates: RatesECB}
+
+// ValidateCurrencyCode checks for a three-letter ISO 4217 style code
+func ValidateCurrencyCode(code string) error {
+	if len(code) != 3 {
+		return fmt.Errorf("invalid currency %q, use a three-letter code such as EUR", code)
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("invalid currency %q, use a three-letter code such as EUR", code)
+		}
+	}
+	return nil
+}
+
+// Price display units
+const (
+	UnitMajor = "major" // NOK, SEK, EUR
+	UnitMinor = "minor" // øre, öre, cent
+)
+
+// Color modes
+const (
+	ColorAuto   = "auto"   // color on a terminal, unless NO_COLOR or TERM=dumb
+	ColorAlways = "always" // color even when piped
+	ColorNever  = "never"
+)
+
+// ColorModes are the valid color modes
+var ColorModes = []string{ColorAuto, ColorAlways, ColorNever}
+
+// DisplayConfig controls how the pretty and markdown formats print prices
+// per kWh. Components lists models.PriceTotal, PriceEnergy or PriceTax; the
+// first is the main value shown in bars and summaries.
+//
+// Color is one of ColorModes; ASCII replaces emoji and box-drawing glyphs.
+type DisplayConfig struct {
+	Unit       string   `mapstructure:"unit"`
+	Decimals   int      `mapstructure:"decimals"`
+	Components []string `mapstructure:"components"`
+	Color      string   `mapstructure:"color"`
+	ASCII      bool     `mapstructure:"ascii"`
+}
+
+// DefaultDisplay prints total prices in the major unit with two decimals,
+// in color on terminals
+var DefaultDisplay = DisplayConfig{
+	Unit:       UnitMajor,
+	Decimals:   2,
+	Components: []string{models.PriceTotal},
+	Color:      ColorAuto,
+}
+
+// ValidateColorMode checks for one of ColorModes
+func ValidateColorMode(mode string) error {
+	if !slices.Contains(ColorModes, mode) {
+		return fmt.Errorf("invalid color mode %q, valid values: %s", mode, strings.Join(ColorModes, ", "))
+	}
+	return nil
+}
+
+// maxDecimals caps the printed precision of prices
+const maxDecimals = 6
+
+func validateDisplay(d DisplayConfig) error {
+	if d.Unit != UnitMajor && d.Unit != UnitMinor {
+		return fmt.Errorf("unit must be %s or %s, got %q", UnitMajor, UnitMinor, d.Unit)
+	}
+	if d.Decimals < 0 || d.Decimals > maxDecimals {
+		return fmt.Errorf("decimals must be between 0 and %d, got %d", maxDecimals, d.Decimals)
+	}
+	if len(d.Components) == 0 {
+		return fmt.Errorf("at least one component is required")
+	}
+	for _, c := range d.Components {
+		valid := false
+		for _, known := range models.PriceComponents {
+			valid = valid || c == known
+		}
+		if !valid {
+			return fmt.Errorf("invalid component %q, valid values: %s", c, strings.Join(models.PriceComponents, ", "))
+		}
+	}
+	return ValidateColorMode(d.Color)
+}
+
+// AlertConfig holds thresholds for live stream warnings
+type AlertConfig struct {
+	// FusePercent is the share of the main fuse rating a phase may carry
+	// for longer than FuseSeconds before an overload is reported
+	FusePercent float64 `mapstructure:"fuse_percent"`
+	FuseSeconds int     `mapstructure:"fuse_seconds"`
+	// ImbalanceAmps is the largest allowed spread between phase currents
+	ImbalanceAmps float64 `mapstructure:"imbalance_amps"`
+	// NominalVoltage and VoltageTolerance (percent) bound phase voltages
+	NominalVoltage   float64 `mapstructure:"nominal_voltage"`
+	VoltageTolerance float64 `mapstructure:"voltage_tolerance"`
+}
+
+// DefaultAlerts are the thresholds used when the config file sets none
+var DefaultAlerts = AlertConfig{
+	FusePercent:      80,
+	FuseSeconds:      30,
+	ImbalanceAmps:    10,
+	NominalVoltage:   230,
+	VoltageTolerance: 10,
+}
+
+// NotifyConfig lists webhook targets and the rules that trigger them
+type NotifyConfig struct {
+	Webhooks []WebhookConfig `mapstructure:"webhooks"`
+	Rules    []RuleConfig    `mapstructure:"rules"`
+}
+
+// WebhookConfig is a webhook target. With Secret set, payloads are signed
+// with HMAC-SHA256. Events limits which rule types are sent (empty: all).
+type WebhookConfig struct {
+	URL    string   `mapstructure:"url"`
+	Secret string   `mapstructure:"secret"`
+	Events []string `mapstructure:"events"`
+}
+
+// Notification rule types
+const (
+	RulePriceLevel     = "price_level"
+	RuleTomorrowPrices = "tomorrow_prices"
+	RulePowerAbove     = "power_above"
+	RuleStreamLost     = "stream_lost"
+)
+
+// RuleConfig is a notification rule. Level applies to price_level, Watts
+// and Minutes to power_above.
+type RuleConfig struct {
+	Type    string  `mapstructure:"type"`
+	Level   string  `mapstructure:"level"`
+	Watts   float64 `mapstructure:"watts"`
+	Minutes int     `mapstructure:"minutes"`
+}
+
+// Validate checks a rule's type and required parameters
+func (r RuleConfig) Validate() error {
+	switch r.Type {
+	case RulePriceLevel:
+		if r.Level == "" {
+			return fmt.Errorf("%s rule requires level", r.Type)
+		}
+		if _, err := models.ParsePriceLevel(r.Level); err != nil {
+			return fmt.Errorf("%s rule: %w", r.Type, err)
+		}
+	case RulePowerAbove:
+		if r.Watts <= 0 {
+			return fmt.Errorf("%s rule requires watts", r.Type)
+		}
+	case RuleTomorrowPrices, RuleStreamLost:
+	default:
+		return fmt.Errorf("unknown rule type %q", r.Type)
+	}
+	return nil
+}
+
+// Token sources reported in Config.TokenSource
+const (
+	TokenSourceEnv  = "env"
+	TokenSourceFile = "file"
+)
+
+// schemaMigrations upgrade the top-level mapping of a config file one
+// version at a time. Entry i migrates a file from version i to version i+1.
+// They edit YAML nodes so comments and key order survive a rewrite.
+var schemaMigrations = []func(root *yaml.Node) error{
+	// v0 -> v1: unversioned files only gain the config_version key
+	func(root *yaml.Node) error { return nil },
+}
+
+// xdgDir resolves an XDG base directory, falling back to a path under $HOME
+func xdgDir(env string, fallback ...string) string {
+	if dir := os.Getenv(env); dir != "" && filepath.IsAbs(dir) {
+		return filepath.Join(dir, AppName)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(append(append([]string{home}, fallback...), AppName)...)
+}
+
+// ConfigDir returns the config directory ($XDG_CONFIG_HOME/powerctl)
+func ConfigDir() string {
+	return xdgDir("XDG_CONFIG_HOME", ".config")
+}
+
+// CacheDir returns the cache directory ($XDG_CACHE_HOME/powerctl)
+func CacheDir() string {
+	return xdgDir("XDG_CACHE_HOME", ".cache")
+}
+
+// StateDir returns the state directory ($XDG_STATE_HOME/powerctl)
+func StateDir() string {
+	return xdgDir("XDG_STATE_HOME", ".local", "state")
 }
 
 // DefaultConfigPath returns the default config file path
 func DefaultConfigPath() string {
+	dir := ConfigDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "config.yaml")
+}
+
+// LegacyConfigPath returns the pre-XDG config file path (~/.tibber/config.yaml)
+func LegacyConfigPath() string {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return ""
@@ -24,35 +477,87 @@ func DefaultConfigPath() string {
 	return filepath.Join(home, ".tibber", "config.yaml")
 }
 
-// Load reads configuration from environment and config file
-// Priority: env vars > config file > defaults
-func Load(configPath string) (*Config, error) {
+// Defaults returns the configuration without a config file: the defaults
+// with the token and home ID from the environment
+func Defaults() *Config {
 	cfg := &Config{
-		Format: "pretty", // default: beautiful CLI output
+		ConfigVersion: CurrentConfigVersion,
+		Format:        "pretty", // default: beautiful CLI output
+		Lang:          i18n.English,
+		CapacitySteps: DefaultCapacitySteps,
+		Alerts:        DefaultAlerts,
+		Currency:      DefaultCurrency,
+		Display:       DefaultDisplay,
 	}
+	// Decoding reuses slices, so don't let the file overwrite the default's
+	cfg.Display.Components = slices.Clone(DefaultDisplay.Components)
 
 	// Check environment variable first (highest priority)
 	if token := os.Getenv("TIBBER_TOKEN"); token != "" {
 		cfg.Token = token
+		cfg.TokenSource = TokenSourceEnv
 	}
 
 	if homeID := os.Getenv("TIBBER_HOME_ID"); homeID != "" {
 		cfg.HomeID = homeID
 	}
 
+	return cfg
+}
+
+// Load reads configuration from environment and config file
+// Priority: env vars > config file > defaults
+func Load(configPath string) (*Config, error) {
+	cfg := Defaults()
+
 	// Try to load config file
 	if configPath == "" {
 		configPath = DefaultConfigPath()
+
+		if notice, err := migrateLegacyConfig(configPath); err != nil {
+			return nil, err
+		} else if notice != "" {
+			cfg.Notices = append(cfg.Notices, notice)
+		}
 	}
 
 	if configPath != "" {
-		viper.SetConfigFile(configPath)
-		viper.SetConfigType("yaml")
+		doc, err := readNode(configPath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		// Ignore file not found - config file is optional
+		if err == nil {
+			migrated, err := migrateSchema(configPath, doc)
+			if err != nil {
+				return nil, err
+			}
+			// Saving the upgrade is best effort; a read-only file is
+			// migrated in memory on every run instead
+			if migrated {
+				if err := writeNode(configPath, doc); err != nil {
+					cfg.Notices = append(cfg.Notices, fmt.Sprintf("Could not save config %s upgraded to version %d: %v", configPath, CurrentConfigVersion, err))
+				}
+			}
+
+			raw, err := yaml.Marshal(doc)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode config: %w", err)
+			}
+
+			viper.SetConfigType("yaml")
+			if err := viper.ReadConfig(bytes.NewReader(raw)); err != nil {
+				return nil, fmt.Errorf("failed to parse config %s: %w", configPath, err)
+			}
+
+			cfg.Path = configPath
 
-		if err := viper.ReadInConfig(); err == nil {
 			// Only override if not set by env var
 			if cfg.Token == "" {
 				cfg.Token = viper.GetString("token")
+				if cfg.Token != "" {
+					cfg.TokenSource = TokenSourceFile
+				}
 			}
 			if cfg.HomeID == "" {
 				cfg.HomeID = viper.GetString("home_id")
@@ -60,13 +565,301 @@ func Load(configPath string) (*Config, error) {
 			if format := viper.GetString("format"); format != "" {
 				cfg.Format = format
 			}
+			if lang := viper.GetString("lang"); lang != "" {
+				parsed, err := i18n.Parse(lang)
+				if err != nil {
+					return nil, fmt.Errorf("invalid lang in %s: %w", configPath, err)
+				}
+				cfg.Lang = parsed
+			}
+			if viper.IsSet("capacity_steps") {
+				var steps []CapacityStep
+				if err := viper.UnmarshalKey("capacity_steps", &steps); err != nil {
+					return nil, fmt.Errorf("invalid capacity_steps in %s: %w", configPath, err)
+				}
+				if err := validateCapacitySteps(steps); err != nil {
+					return nil, fmt.Errorf("invalid capacity_steps in %s: %w", configPath, err)
+				}
+				cfg.CapacitySteps = steps
+			}
+			if err := viper.UnmarshalKey("tariff", &cfg.Tariff, viper.DecodeHook(dateToString)); err != nil {
+				return nil, fmt.Errorf("invalid tariff in %s: %w", configPath, err)
+			}
+			if err := validateTariff(cfg.Tariff); err != nil {
+				return nil, fmt.Errorf("invalid tariff in %s: %w", configPath, err)
+			}
+			if err := viper.UnmarshalKey("subsidy", &cfg.Subsidy, viper.DecodeHook(dateToString)); err != nil {
+				return nil, fmt.Errorf("invalid subsidy in %s: %w", configPath, err)
+			}
+			if err := validateSubsidy(cfg.Subsidy); err != nil {
+				return nil, fmt.Errorf("invalid subsidy in %s: %w", configPath, err)
+			}
+			// Keys missing from the file keep their defaults
+			if err := viper.UnmarshalKey("currency", &cfg.Currency); err != nil {
+				return nil, fmt.Errorf("invalid currency in %s: %w", configPath, err)
+			}
+			cfg.Currency.Display = strings.ToUpper(cfg.Currency.Display)
+			if cfg.Currency.Display != "" {
+				if err := ValidateCurrencyCode(cfg.Currency.Display); err != nil {
+					return nil, fmt.Errorf("invalid currency in %s: %w", configPath, err)
+				}
+			}
+			// Keys missing from the file keep their defaults
+			if err := viper.UnmarshalKey("display", &cfg.Display); err != nil {
+				return nil, fmt.Errorf("invalid display in %s: %w", configPath, err)
+			}
+			cfg.Display.Unit = strings.ToLower(cfg.Display.Unit)
+			cfg.Display.Color = strings.ToLower(cfg.Display.Color)
+			for i, c := range cfg.Display.Components {
+				cfg.Display.Components[i] = strings.ToLower(c)
+			}
+			if err := validateDisplay(cfg.Display); err != nil {
+				return nil, fmt.Errorf("invalid display in %s: %w", configPath, err)
+			}
+			if err := viper.UnmarshalKey("notify", &cfg.Notify); err != nil {
+				return nil, fmt.Errorf("invalid notify in %s: %w", configPath, err)
+			}
+			for _, rule := range cfg.Notify.Rules {
+				if err := rule.Validate(); err != nil {
+					return nil, fmt.Errorf("invalid notify rule in %s: %w", configPath, err)
+				}
+			}
+			// Keys missing from the file keep their defaults
+			if err := viper.UnmarshalKey("alerts", &cfg.Alerts); err != nil {
+				return nil, fmt.Errorf("invalid alerts in %s: %w", configPath, err)
+			}
 		}
-		// Ignore file not found - config file is optional
 	}
 
 	return cfg, nil
 }
 
+// dateToString turns unquoted YAML dates, which decode as time.Time, back
+// into YYYY-MM-DD strings
+func dateToString(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if t, ok := data.(time.Time); ok && to.Kind() == reflect.String {
+		return t.Format("2006-01-02"), nil
+	}
+	return data, nil
+}
+
+// validateCapacitySteps checks that limits are ascending and that only the
+// last step is unbounded
+func validateCapacitySteps(steps []CapacityStep) error {
+	if len(steps) == 0 {
+		return fmt.Errorf("at least one step is required")
+	}
+	for i, step := range steps {
+		if step.LimitKW == 0 {
+			if i != len(steps)-1 {
+				return fmt.Errorf("only the last step may omit limit_kw")
+			}
+			continue
+		}
+		if i > 0 && step.LimitKW <= steps[i-1].LimitKW {
+			return fmt.Errorf("limit_kw must be ascending (step %d)", i+1)
+		}
+	}
+	return nil
+}
+
+// migrateLegacyConfig copies ~/.tibber/config.yaml to the XDG location
+// when no config exists there yet. It returns a notice when a copy was made.
+func migrateLegacyConfig(target string) (string, error) {
+	legacy := LegacyConfigPath()
+	if target == "" || legacy == "" || target == legacy {
+		return "", nil
+	}
+	if _, err := os.Stat(target); err == nil || !os.IsNotExist(err) {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(legacy)
+	if err != nil {
+		return "", nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(target, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to migrate config: %w", err)
+	}
+
+	return fmt.Sprintf("Migrated config from %s to %s (the old file can be removed)", legacy, target), nil
+}
+
+// migrateSchema upgrades doc to CurrentConfigVersion in place and reports
+// whether anything changed. Files from a newer build are rejected.
+func migrateSchema(path string, doc *yaml.Node) (bool, error) {
+	root := doc.Content[0]
+	version := 0
+	if v := mappingValue(root, "config_version"); v != nil {
+		if err := v.Decode(&version); err != nil {
+			return false, fmt.Errorf("invalid config_version in %s: %s", path, v.Value)
+		}
+	}
+
+	if version > CurrentConfigVersion {
+		return false, fmt.Errorf("config %s has version %d, but this build only supports up to %d; please upgrade powerctl",
+			path, version, CurrentConfigVersion)
+	}
+	if version == CurrentConfigVersion {
+		return false, nil
+	}
+
+	for ; version < CurrentConfigVersion; version++ {
+		if err := schemaMigrations[version](root); err != nil {
+			return false, fmt.Errorf("failed to migrate config from version %d: %w", version, err)
+		}
+	}
+	setMappingValue(root, "config_version", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(CurrentConfigVersion)})
+	return true, nil
+}
+
+// readNode reads a config file as a YAML document whose content is a
+// mapping; an empty file gives an empty mapping
+func readNode(path string) (*yaml.Node, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("failed to parse config %s: not a mapping of keys", path)
+	}
+	return &doc, nil
+}
+
+// writeNode writes a YAML document with owner-only permissions, keeping its
+// comments and key order
+func writeNode(path string, doc *yaml.Node) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue replaces the value of key in a mapping node, or adds the
+// key at the end
+func setMappingValue(m *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// LoadUnvalidated is the fallback when Load fails: the defaults and
+// environment plus the token and home ID from the file at configPath (or
+// the default path), read without checking anything else. A file that
+// isn't readable YAML gives just the defaults.
+func LoadUnvalidated(configPath string) *Config {
+	cfg := Defaults()
+	if configPath == "" {
+		configPath = DefaultConfigPath()
+	}
+
+	doc, err := readNode(configPath)
+	if err != nil {
+		return cfg
+	}
+	cfg.Path = configPath
+
+	root := doc.Content[0]
+	if token := mappingValue(root, "token"); cfg.Token == "" && token != nil && token.Kind == yaml.ScalarNode && token.Value != "" {
+		cfg.Token = token.Value
+		cfg.TokenSource = TokenSourceFile
+	}
+	if homeID := mappingValue(root, "home_id"); cfg.HomeID == "" && homeID != nil && homeID.Kind == yaml.ScalarNode {
+		cfg.HomeID = homeID.Value
+	}
+	return cfg
+}
+
+// Setting is a top-level config key and its value
+type Setting struct {
+	Key   string
+	Value string
+}
+
+// Settings reads the top-level settings of the config file at path in
+// file order. Nested values are given in YAML flow style.
+func Settings(path string) ([]Setting, error) {
+	doc, err := readNode(path)
+	if err != nil {
+		return nil, err
+	}
+
+	root := doc.Content[0]
+	settings := make([]Setting, 0, len(root.Content)/2)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		value := root.Content[i+1]
+		if value.Kind == yaml.ScalarNode {
+			settings = append(settings, Setting{Key: root.Content[i].Value, Value: value.Value})
+			continue
+		}
+		flow := *value
+		flow.Style = yaml.FlowStyle
+		raw, err := yaml.Marshal(&flow)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", root.Content[i].Value, err)
+		}
+		settings = append(settings, Setting{Key: root.Content[i].Value, Value: strings.TrimSpace(string(raw))})
+	}
+	return settings, nil
+}
+
+// Set writes string settings to the config file at path with owner-only
+// permissions, keeping its comments and key order. Existing keys are
+// updated in place and new ones added at the end; a new file is stamped
+// with the current config_version.
+func Set(path string, settings ...Setting) error {
+	doc, err := readNode(path)
+	if os.IsNotExist(err) {
+		doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+		setMappingValue(doc.Content[0], "config_version", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(CurrentConfigVersion)})
+	} else if err != nil {
+		return err
+	}
+
+	root := doc.Content[0]
+	for _, s := range settings {
+		// Update a scalar in place so its comments stay with it
+		if old := mappingValue(root, s.Key); old != nil && old.Kind == yaml.ScalarNode {
+			old.Tag, old.Value, old.Style = "!!str", s.Value, 0
+			continue
+		}
+		setMappingValue(root, s.Key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s.Value})
+	}
+	return writeNode(path, doc)
+}
+
 // Validate checks if required configuration is present
 func (c *Config) Validate() error {
 	if c.Token == "" {
@@ -77,10 +870,9 @@ func (c *Config) Validate() error {
 
 // EnsureConfigDir creates the config directory if it doesn't exist
 func EnsureConfigDir() error {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return err
+	dir := ConfigDir()
+	if dir == "" {
+		return fmt.Errorf("could not determine config directory")
 	}
-	configDir := filepath.Join(home, ".tibber")
-	return os.MkdirAll(configDir, 0700)
+	return os.MkdirAll(dir, 0700)
 }
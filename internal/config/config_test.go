@@ -1,8 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -129,3 +132,423 @@ func TestDefaultConfigPath(t *testing.T) {
 		t.Errorf("DefaultConfigPath() basename = %q, want config.yaml", filepath.Base(path))
 	}
 }
+
+// setHome points the user home directory at dir for the duration of a test
+func setHome(t *testing.T, dir string) {
+	t.Setenv("HOME", dir)
+	t.Setenv("USERPROFILE", dir)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_CACHE_HOME", "")
+	t.Setenv("XDG_STATE_HOME", "")
+}
+
+func TestDefaultConfigPath_XDGConfigHome(t *testing.T) {
+	tmpDir := t.TempDir()
+	setHome(t, tmpDir)
+	xdg := filepath.Join(tmpDir, "xdg")
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	want := filepath.Join(xdg, "powerctl", "config.yaml")
+	if got := DefaultConfigPath(); got != want {
+		t.Errorf("DefaultConfigPath() = %q, want %q", got, want)
+	}
+}
+
+func TestBaseDirs_Fallbacks(t *testing.T) {
+	tmpDir := t.TempDir()
+	setHome(t, tmpDir)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"config", ConfigDir(), filepath.Join(tmpDir, ".config", "powerctl")},
+		{"cache", CacheDir(), filepath.Join(tmpDir, ".cache", "powerctl")},
+		{"state", StateDir(), filepath.Join(tmpDir, ".local", "state", "powerctl")},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s dir = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestLoad_MigratesLegacyConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	setHome(t, tmpDir)
+	t.Setenv("TIBBER_TOKEN", "")
+	t.Setenv("TIBBER_HOME_ID", "")
+
+	legacyDir := filepath.Join(tmpDir, ".tibber")
+	if err := os.MkdirAll(legacyDir, 0700); err != nil {
+		t.Fatalf("Failed to create legacy dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(legacyDir, "config.yaml"), []byte("token: legacy-token\n"), 0600); err != nil {
+		t.Fatalf("Failed to write legacy config: %v", err)
+	}
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Token != "legacy-token" {
+		t.Errorf("Token = %q, want %q", cfg.Token, "legacy-token")
+	}
+	if len(cfg.Notices) != 1 {
+		t.Errorf("len(Notices) = %d, want 1", len(cfg.Notices))
+	}
+	if _, err := os.Stat(DefaultConfigPath()); err != nil {
+		t.Errorf("migrated config not found at %s: %v", DefaultConfigPath(), err)
+	}
+
+	// Second load must not migrate again
+	cfg, err = Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Notices) != 0 {
+		t.Errorf("len(Notices) = %d on second load, want 0", len(cfg.Notices))
+	}
+}
+
+func TestLoad_StampsConfigVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	if err := os.WriteFile(configPath, []byte("# powerctl\ntoken: t # mine\nformat: json\n"), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	if _, err := Load(configPath); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	// The rewrite keeps comments and key order
+	raw, _ := os.ReadFile(configPath)
+	if want := "# powerctl\ntoken: t # mine\nformat: json\nconfig_version: 1\n"; string(raw) != want {
+		t.Errorf("rewritten config = %q, want %q", raw, want)
+	}
+}
+
+func TestSet_KeepsCommentsAndOrder(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	original := "# powerctl\nconfig_version: 1\ntoken: t # mine\nformat: pretty # default\nalerts:\n  fuse_percent: 90\n"
+	if err := os.WriteFile(configPath, []byte(original), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	if err := Set(configPath, Setting{Key: "format", Value: "json"}, Setting{Key: "home_id", Value: "1234"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	raw, _ := os.ReadFile(configPath)
+	want := "# powerctl\nconfig_version: 1\ntoken: t # mine\nformat: json # default\nalerts:\n  fuse_percent: 90\nhome_id: \"1234\"\n"
+	if string(raw) != want {
+		t.Errorf("config after Set() = %q, want %q", raw, want)
+	}
+
+	settings, err := Settings(configPath)
+	if err != nil {
+		t.Fatalf("Settings() error = %v", err)
+	}
+	got := fmt.Sprint(settings)
+	if want := "[{config_version 1} {token t} {format json} {alerts {fuse_percent: 90}} {home_id 1234}]"; got != want {
+		t.Errorf("Settings() = %s, want %s", got, want)
+	}
+}
+
+func TestSet_CreatesVersionedFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	if err := Set(configPath, Setting{Key: "token", Value: "t"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	raw, _ := os.ReadFile(configPath)
+	if want := "config_version: 1\ntoken: t\n"; string(raw) != want {
+		t.Errorf("new config = %q, want %q", raw, want)
+	}
+	if info, err := os.Stat(configPath); err == nil && runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("permissions = %04o, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestLoad_MigratesReadOnlyConfigInMemory(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can write read-only files")
+	}
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	if err := os.WriteFile(configPath, []byte("token: t\n"), 0400); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Token != "t" || cfg.ConfigVersion != CurrentConfigVersion {
+		t.Errorf("Token = %q, ConfigVersion = %d", cfg.Token, cfg.ConfigVersion)
+	}
+	if len(cfg.Notices) != 1 || !strings.Contains(cfg.Notices[0], "Could not save") {
+		t.Errorf("Notices = %q, want one about the failed save", cfg.Notices)
+	}
+}
+
+func TestLoad_RejectsNewerConfigVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	if err := os.WriteFile(configPath, []byte("config_version: 999\n"), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	if _, err := Load(configPath); err == nil {
+		t.Error("Load() should reject a config from a newer version")
+	}
+}
+
+func TestLoadUnvalidated_KeepsToken(t *testing.T) {
+	t.Setenv("TIBBER_TOKEN", "")
+	t.Setenv("TIBBER_HOME_ID", "")
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	if err := os.WriteFile(configPath, []byte("config_version: 999\ntoken: file-token\nhome_id: home-1\n"), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	if _, err := Load(configPath); err == nil {
+		t.Fatal("Load() should reject a config from a newer version")
+	}
+
+	cfg := LoadUnvalidated(configPath)
+	if cfg.Token != "file-token" || cfg.TokenSource != TokenSourceFile || cfg.HomeID != "home-1" || cfg.Path != configPath {
+		t.Errorf("LoadUnvalidated() = token %q (%s), home %q, path %q", cfg.Token, cfg.TokenSource, cfg.HomeID, cfg.Path)
+	}
+
+	if cfg := LoadUnvalidated(filepath.Join(t.TempDir(), "missing.yaml")); cfg.Token != "" || cfg.Path != "" {
+		t.Errorf("LoadUnvalidated(missing) = token %q, path %q, want the defaults", cfg.Token, cfg.Path)
+	}
+}
+
+func TestLoad_AlertsKeepDefaultsForMissingKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `alerts:
+  fuse_percent: 90
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Alerts.FusePercent != 90 {
+		t.Errorf("Alerts.FusePercent = %v, want 90", cfg.Alerts.FusePercent)
+	}
+	if cfg.Alerts.FuseSeconds != DefaultAlerts.FuseSeconds {
+		t.Errorf("Alerts.FuseSeconds = %v, want default %v", cfg.Alerts.FuseSeconds, DefaultAlerts.FuseSeconds)
+	}
+}
+
+func TestLoad_Tariff(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `tariff:
+  vat: 25
+  monthly_fee: 200
+  fees: 0.0713
+  holidays: [2025-12-25]
+  seasons:
+    - name: winter
+      months: [1, 2, 3]
+      bands:
+        - name: day
+          days: weekday
+          from: "06:00"
+          to: "22:00"
+          price: 0.36
+        - name: night
+          price: 0.28
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tariff := cfg.Tariff
+	if !tariff.Configured() || tariff.VAT != 25 || tariff.MonthlyFee != 200 || len(tariff.Holidays) != 1 {
+		t.Errorf("Tariff = %+v", tariff)
+	}
+	if len(tariff.Seasons) != 1 || len(tariff.Seasons[0].Bands) != 2 || tariff.Seasons[0].Bands[0].From != "06:00" {
+		t.Errorf("Tariff.Seasons = %+v", tariff.Seasons)
+	}
+}
+
+func TestLoad_InvalidTariff(t *testing.T) {
+	tests := map[string]string{
+		"vat":     "tariff:\n  vat: 250\n",
+		"holiday": "tariff:\n  holidays: [\"25.12.2025\"]\n",
+		"month":   "tariff:\n  seasons:\n    - months: [13]\n",
+		"days":    "tariff:\n  seasons:\n    - bands:\n        - days: weekdays\n",
+		"clock":   "tariff:\n  seasons:\n    - bands:\n        - from: \"6am\"\n",
+	}
+
+	for name, content := range tests {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write test config: %v", err)
+		}
+		if _, err := Load(configPath); err == nil {
+			t.Errorf("Load() should reject an invalid tariff (%s)", name)
+		}
+	}
+}
+
+func TestLoad_Subsidy(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `subsidy:
+  rules:
+    - { from: 2022-09-01, scheme: monthly_average, threshold: 0.70, coverage: 90, vat: 25 }
+    - { from: 2025-10-01, scheme: fixed_price, price: 0.40, vat: 25 }
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	rules := cfg.Subsidy.Rules
+	if len(rules) != 2 || rules[0].From != "2022-09-01" || rules[0].Coverage != 90 || rules[1].Scheme != SubsidyFixedPrice {
+		t.Errorf("Subsidy.Rules = %+v", rules)
+	}
+}
+
+func TestLoad_InvalidSubsidy(t *testing.T) {
+	tests := map[string]string{
+		"from":     "subsidy:\n  rules:\n    - { from: soon, scheme: hourly, coverage: 90 }\n",
+		"order":    "subsidy:\n  rules:\n    - { from: 2024-01-01, scheme: hourly, coverage: 90 }\n    - { from: 2023-01-01, scheme: hourly, coverage: 90 }\n",
+		"scheme":   "subsidy:\n  rules:\n    - { from: 2024-01-01, scheme: daily, coverage: 90 }\n",
+		"coverage": "subsidy:\n  rules:\n    - { from: 2024-01-01, scheme: hourly }\n",
+		"price":    "subsidy:\n  rules:\n    - { from: 2024-01-01, scheme: fixed_price }\n",
+	}
+
+	for name, content := range tests {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write test config: %v", err)
+		}
+		if _, err := Load(configPath); err == nil {
+			t.Errorf("Load() should reject invalid subsidy rules (%s)", name)
+		}
+	}
+}
+
+func TestLoad_Currency(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("currency:\n  display: eur\n"), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Currency.Display != "EUR" || cfg.Currency.Rates != RatesECB {
+		t.Errorf("Currency = %+v, want display EUR and default rates", cfg.Currency)
+	}
+
+	if err := os.WriteFile(configPath, []byte("currency:\n  display: euro\n"), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	if _, err := Load(configPath); err == nil {
+		t.Error("Load() should reject an invalid display currency")
+	}
+}
+
+func TestLoad_Lang(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("lang: nb_NO\n"), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Lang != "nb" {
+		t.Errorf("Lang = %q, want nb", cfg.Lang)
+	}
+
+	if err := os.WriteFile(configPath, []byte("lang: fi\n"), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	if _, err := Load(configPath); err == nil {
+		t.Error("Load() should reject an unsupported language")
+	}
+}
+
+func TestLoad_Display(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("display:\n  unit: Minor\n  components: [energy, tax]\n  ascii: true\n"), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Display.Unit != UnitMinor || cfg.Display.Decimals != 2 || cfg.Display.Color != ColorAuto || !cfg.Display.ASCII {
+		t.Errorf("Display = %+v, want minor unit, default decimals and color, and ASCII", cfg.Display)
+	}
+	if len(cfg.Display.Components) != 2 || cfg.Display.Components[0] != "energy" {
+		t.Errorf("Components = %v, want [energy tax]", cfg.Display.Components)
+	}
+	if DefaultDisplay.Components[0] != "total" {
+		t.Errorf("Load() modified the default components: %v", DefaultDisplay.Components)
+	}
+
+	for name, yaml := range map[string]string{
+		"unit":      "display:\n  unit: kroner\n",
+		"decimals":  "display:\n  decimals: 9\n",
+		"component": "display:\n  components: [grid]\n",
+		"empty":     "display:\n  components: []\n",
+		"color":     "display:\n  color: sometimes\n",
+	} {
+		if err := os.WriteFile(configPath, []byte(yaml), 0600); err != nil {
+			t.Fatalf("Failed to write test config: %v", err)
+		}
+		if _, err := Load(configPath); err == nil {
+			t.Errorf("Load() should reject invalid display settings (%s)", name)
+		}
+	}
+}
+
+func TestDefaults_Environment(t *testing.T) {
+	t.Setenv("TIBBER_TOKEN", "env-token")
+	t.Setenv("TIBBER_HOME_ID", "env-home")
+
+	cfg := Defaults()
+	if cfg.Token != "env-token" || cfg.TokenSource != TokenSourceEnv || cfg.HomeID != "env-home" {
+		t.Errorf("Defaults() = token %q (%s), home %q", cfg.Token, cfg.TokenSource, cfg.HomeID)
+	}
+	if cfg.Path != "" || cfg.Format != "pretty" {
+		t.Errorf("Defaults() = path %q, format %q, want no path and pretty", cfg.Path, cfg.Format)
+	}
+}
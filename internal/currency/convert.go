@@ -0,0 +1,141 @@
+package currency
+
+import (
+	"fmt"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+// Converter converts the amounts in models to one currency
+type Converter struct {
+	To    string
+	rates *Rates
+}
+
+// NewConverter returns a converter to the currency to
+func NewConverter(rates *Rates, to string) (*Converter, error) {
+	if _, err := rates.perBase(to); err != nil {
+		return nil, err
+	}
+	return &Converter{To: to, rates: rates}, nil
+}
+
+// conversion describes converting from to c.To; nil when no conversion is
+// needed
+func (c *Converter) conversion(from string) (*models.Conversion, error) {
+	if from == "" || from == c.To {
+		return nil, nil
+	}
+	rate, err := c.rates.Rate(from, c.To)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Conversion{From: from, To: c.To, Rate: rate, Date: c.rates.Date, Source: c.rates.Source}, nil
+}
+
+// Prices converts prices in place. All prices must share a currency.
+func (c *Converter) Prices(prices []models.Price) (*models.Conversion, error) {
+	if len(prices) == 0 {
+		return nil, nil
+	}
+	conv, err := c.conversion(prices[0].Currency)
+	if conv == nil || err != nil {
+		return nil, err
+	}
+
+	for i := range prices {
+		if err := convertPrice(&prices[i], conv); err != nil {
+			return nil, err
+		}
+	}
+	return conv, nil
+}
+
+// PriceInfo converts current, today's and tomorrow's prices in place
+func (c *Converter) PriceInfo(info *models.PriceInfo) error {
+	var currency string
+	switch {
+	case info.Current != nil:
+		currency = info.Current.Currency
+	case len(info.Today) > 0:
+		currency = info.Today[0].Currency
+	case len(info.Tomorrow) > 0:
+		currency = info.Tomorrow[0].Currency
+	}
+	conv, err := c.conversion(currency)
+	if conv == nil || err != nil {
+		return err
+	}
+
+	if info.Current != nil {
+		if err := convertPrice(info.Current, conv); err != nil {
+			return err
+		}
+	}
+	for _, prices := range [][]models.Price{info.Today, info.Tomorrow} {
+		for i := range prices {
+			if err := convertPrice(&prices[i], conv); err != nil {
+				return err
+			}
+		}
+	}
+	info.Conversion = conv
+	return nil
+}
+
+// CostReport converts a cost report in place
+func (c *Converter) CostReport(r *models.CostReport) error {
+	conv, err := c.conversion(r.Currency)
+	if conv == nil || err != nil {
+		return err
+	}
+
+	for _, amount := range []*float64{
+		&r.Cost, &r.GridCost, &r.FixedFee, &r.Total, &r.Subsidy,
+		&r.AveragePrice, &r.SpotAverage, &r.TimingSavings,
+	} {
+		*amount *= conv.Rate
+	}
+	for i := range r.TopHours {
+		r.TopHours[i].Price *= conv.Rate
+		r.TopHours[i].Cost *= conv.Rate
+	}
+	for i := range r.Levels {
+		r.Levels[i].Cost *= conv.Rate
+	}
+	r.Currency = c.To
+	r.Conversion = conv
+	return nil
+}
+
+// LiveMeasurement converts a measurement's accumulated cost and reward in
+// place
+func (c *Converter) LiveMeasurement(m *models.LiveMeasurement) error {
+	conv, err := c.conversion(m.Currency)
+	if conv == nil || err != nil {
+		return err
+	}
+
+	m.AccumulatedCost *= conv.Rate
+	m.AccumulatedReward *= conv.Rate
+	m.Currency = c.To
+	m.Conversion = conv
+	return nil
+}
+
+func convertPrice(p *models.Price, conv *models.Conversion) error {
+	if p.Currency != conv.From {
+		return fmt.Errorf("cannot convert %s price with a %s rate", p.Currency, conv.From)
+	}
+	for _, amount := range []*float64{&p.Total, &p.Energy, &p.Tax, &p.Grid, &p.Fees, &p.Subsidy} {
+		*amount *= conv.Rate
+	}
+	// Copies of a price share Effective, so replace it rather than scale
+	// it in place
+	if p.Effective != nil {
+		effective := *p.Effective * conv.Rate
+		p.Effective = &effective
+	}
+	p.Currency = conv.To
+	return nil
+}
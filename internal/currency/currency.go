@@ -0,0 +1,203 @@
+// Package currency converts prices and costs between currencies using
+// exchange rates from a pluggable provider.
+package currency
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rates are exchange rates against a base currency: 1 Base = Rates[X] X
+type Rates struct {
+	Base  string             `yaml:"base"`
+	Date  string             `yaml:"date"`
+	Rates map[string]float64 `yaml:"rates"`
+
+	// Source names where the rates came from, e.g. "ECB"
+	Source string `yaml:"source"`
+}
+
+// Rate returns how many units of to one unit of from buys
+func (r *Rates) Rate(from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return 1, nil
+	}
+
+	fromRate, err := r.perBase(from)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := r.perBase(to)
+	if err != nil {
+		return 0, err
+	}
+	return toRate / fromRate, nil
+}
+
+func (r *Rates) perBase(code string) (float64, error) {
+	if code == r.Base {
+		return 1, nil
+	}
+	if rate, ok := r.Rates[code]; ok && rate > 0 {
+		return rate, nil
+	}
+	return 0, fmt.Errorf("no exchange rate for %s", code)
+}
+
+// Provider supplies exchange rates
+type Provider interface {
+	Rates(ctx context.Context) (*Rates, error)
+}
+
+// FileProvider reads rates from a YAML file with base, date and rates keys
+type FileProvider struct {
+	Path string
+}
+
+// Rates reads and validates the rates file
+func (p *FileProvider) Rates(ctx context.Context) (*Rates, error) {
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rates file: %w", err)
+	}
+
+	var rates Rates
+	if err := yaml.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("failed to parse rates file %s: %w", p.Path, err)
+	}
+	if rates.Base == "" || len(rates.Rates) == 0 {
+		return nil, fmt.Errorf("rates file %s needs base and rates", p.Path)
+	}
+	rates.Base = strings.ToUpper(rates.Base)
+	if rates.Source == "" {
+		rates.Source = filepath.Base(p.Path)
+	}
+	return &rates, nil
+}
+
+// ECBURL is the European Central Bank's daily euro reference rates feed
+const ECBURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// ECBProvider fetches the ECB's euro reference rates. Responses are cached
+// in CachePath for MaxAge; a stale cache is used when the fetch fails.
+type ECBProvider struct {
+	URL        string
+	CachePath  string
+	MaxAge     time.Duration
+	HTTPClient *http.Client
+}
+
+// NewECBProvider returns a provider caching the feed in cacheDir for 12 hours
+func NewECBProvider(cacheDir string) *ECBProvider {
+	return &ECBProvider{
+		URL:        ECBURL,
+		CachePath:  filepath.Join(cacheDir, "ecb-rates.xml"),
+		MaxAge:     12 * time.Hour,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Rates returns cached rates when fresh, fetching them otherwise
+func (p *ECBProvider) Rates(ctx context.Context) (*Rates, error) {
+	cached, cacheErr := os.ReadFile(p.CachePath)
+	if cacheErr == nil {
+		if info, err := os.Stat(p.CachePath); err == nil && time.Since(info.ModTime()) < p.MaxAge {
+			return ParseECB(cached)
+		}
+	}
+
+	data, err := p.fetch(ctx)
+	if err != nil {
+		if cacheErr == nil {
+			return ParseECB(cached)
+		}
+		return nil, err
+	}
+
+	rates, err := ParseECB(data)
+	if err != nil {
+		return nil, err
+	}
+	if p.CachePath != "" {
+		// The cache is an optimization; failing to write it is not an error
+		if os.MkdirAll(filepath.Dir(p.CachePath), 0700) == nil {
+			_ = os.WriteFile(p.CachePath, data, 0600)
+		}
+	}
+	return rates, nil
+}
+
+func (p *ECBProvider) fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch exchange rates: HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// ecbEnvelope is the layout of the ECB feed: nested Cube elements holding
+// the date and one rate per currency
+type ecbEnvelope struct {
+	Cube struct {
+		Cube struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string  `xml:"currency,attr"`
+				Rate     float64 `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+// ParseECB parses the ECB daily reference rates XML
+func ParseECB(data []byte) (*Rates, error) {
+	var env ecbEnvelope
+	if err := xml.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("failed to parse ECB rates: %w", err)
+	}
+
+	day := env.Cube.Cube
+	if len(day.Rates) == 0 {
+		return nil, fmt.Errorf("ECB rates contain no currencies")
+	}
+
+	rates := &Rates{Base: "EUR", Date: day.Time, Source: "ECB", Rates: make(map[string]float64, len(day.Rates))}
+	for _, r := range day.Rates {
+		rates.Rates[r.Currency] = r.Rate
+	}
+	return rates, nil
+}
+
+// NewProvider returns the provider for a config rates setting: "ecb" or
+// the path of a rates file, which may start with ~/
+func NewProvider(source, cacheDir string) Provider {
+	if source == "" || strings.EqualFold(source, "ecb") {
+		return NewECBProvider(cacheDir)
+	}
+	if rest, ok := strings.CutPrefix(source, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			source = filepath.Join(home, rest)
+		}
+	}
+	return &FileProvider{Path: source}
+}
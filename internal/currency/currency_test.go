@@ -0,0 +1,181 @@
+package currency
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+func loadFixture(t *testing.T) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/eurofxref-daily.xml")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	return data
+}
+
+func TestParseECB(t *testing.T) {
+	rates, err := ParseECB(loadFixture(t))
+	if err != nil {
+		t.Fatalf("ParseECB() error = %v", err)
+	}
+
+	if rates.Base != "EUR" || rates.Date != "2025-10-17" || rates.Source != "ECB" {
+		t.Errorf("ParseECB() = %+v", rates)
+	}
+	if rates.Rates["NOK"] != 11.5 || len(rates.Rates) != 4 {
+		t.Errorf("ParseECB() rates = %v", rates.Rates)
+	}
+
+	if _, err := ParseECB([]byte("<html></html>")); err == nil {
+		t.Error("ParseECB() should reject a document without rates")
+	}
+}
+
+func TestRates_Rate(t *testing.T) {
+	rates, _ := ParseECB(loadFixture(t))
+
+	tests := []struct {
+		from, to string
+		want     float64
+	}{
+		{"EUR", "NOK", 11.5},
+		{"NOK", "EUR", 1 / 11.5},
+		{"NOK", "SEK", 11.0 / 11.5},
+		{"nok", "NOK", 1},
+	}
+	for _, tt := range tests {
+		got, err := rates.Rate(tt.from, tt.to)
+		if err != nil || math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("Rate(%s, %s) = %v, %v, want %v", tt.from, tt.to, got, err, tt.want)
+		}
+	}
+
+	if _, err := rates.Rate("NOK", "XYZ"); err == nil {
+		t.Error("Rate() should fail for an unknown currency")
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	rates, err := (&FileProvider{Path: "testdata/rates.yaml"}).Rates(context.Background())
+	if err != nil {
+		t.Fatalf("Rates() error = %v", err)
+	}
+	if rates.Base != "NOK" || rates.Rates["EUR"] != 0.08 || rates.Source != "rates.yaml" {
+		t.Errorf("Rates() = %+v", rates)
+	}
+
+	if _, err := (&FileProvider{Path: "testdata/missing.yaml"}).Rates(context.Background()); err == nil {
+		t.Error("Rates() should fail for a missing file")
+	}
+}
+
+func TestECBProvider_Cache(t *testing.T) {
+	fixture := loadFixture(t)
+	requests := 0
+	fail := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(fixture)
+	}))
+	defer server.Close()
+
+	p := NewECBProvider(t.TempDir())
+	p.URL = server.URL
+
+	for i := 0; i < 2; i++ {
+		if _, err := p.Rates(context.Background()); err != nil {
+			t.Fatalf("Rates() error = %v", err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1 (second call served from cache)", requests)
+	}
+
+	// A stale cache is refreshed, and still used when the refresh fails
+	stale := time.Now().Add(-24 * time.Hour)
+	if err := os.Chtimes(p.CachePath, stale, stale); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+	fail = true
+	rates, err := p.Rates(context.Background())
+	if err != nil || rates.Rates["NOK"] != 11.5 {
+		t.Errorf("Rates() with failing fetch = %v, %v, want stale cache", rates, err)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want a refresh attempt", requests)
+	}
+
+	p.CachePath = filepath.Join(t.TempDir(), "none.xml")
+	if _, err := p.Rates(context.Background()); err == nil {
+		t.Error("Rates() should fail without a cache when the fetch fails")
+	}
+}
+
+func TestConverter(t *testing.T) {
+	rates, _ := ParseECB(loadFixture(t))
+	c, err := NewConverter(rates, "EUR")
+	if err != nil {
+		t.Fatalf("NewConverter() error = %v", err)
+	}
+
+	info := &models.PriceInfo{
+		Current: &models.Price{Total: 2.3, Energy: 1.84, Tax: 0.46, Currency: "NOK"},
+		Today:   []models.Price{{Total: 1.15, Currency: "NOK"}},
+	}
+	if err := c.PriceInfo(info); err != nil {
+		t.Fatalf("PriceInfo() error = %v", err)
+	}
+	if math.Abs(info.Current.Total-0.2) > 1e-9 || info.Current.Currency != "EUR" || math.Abs(info.Today[0].Total-0.1) > 1e-9 {
+		t.Errorf("PriceInfo() = %+v, %+v", info.Current, info.Today)
+	}
+	if conv := info.Conversion; conv == nil || conv.From != "NOK" || math.Abs(conv.Original(0.2)-2.3) > 1e-9 {
+		t.Errorf("PriceInfo() conversion = %+v", conv)
+	}
+
+	report := &models.CostReport{Currency: "NOK", Cost: 115, Total: 230, TopHours: []models.CostHour{{Cost: 11.5}}}
+	if err := c.CostReport(report); err != nil {
+		t.Fatalf("CostReport() error = %v", err)
+	}
+	if math.Abs(report.Total-20) > 1e-9 || math.Abs(report.TopHours[0].Cost-1) > 1e-9 || report.Currency != "EUR" {
+		t.Errorf("CostReport() = %+v", report)
+	}
+
+	m := &models.LiveMeasurement{AccumulatedCost: 23, Currency: "NOK"}
+	if err := c.LiveMeasurement(m); err != nil || math.Abs(m.AccumulatedCost-2) > 1e-9 || m.Conversion == nil {
+		t.Errorf("LiveMeasurement() = %+v, %v", m, err)
+	}
+
+	same := []models.Price{{Total: 1, Currency: "EUR"}}
+	if conv, err := c.Prices(same); conv != nil || err != nil || same[0].Total != 1 {
+		t.Errorf("Prices() in the target currency = %v, %v, want no conversion", conv, err)
+	}
+
+	if _, err := NewConverter(rates, "XYZ"); err == nil {
+		t.Error("NewConverter() should reject an unknown currency")
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	if _, ok := NewProvider("ecb", t.TempDir()).(*ECBProvider); !ok {
+		t.Error("NewProvider(ecb) should return an ECB provider")
+	}
+
+	home, _ := os.UserHomeDir()
+	p, ok := NewProvider("~/rates.yaml", "").(*FileProvider)
+	if !ok || p.Path != filepath.Join(home, "rates.yaml") {
+		t.Errorf("NewProvider(~/rates.yaml) = %+v, want a file provider under $HOME", p)
+	}
+}
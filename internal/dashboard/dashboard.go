@@ -0,0 +1,325 @@
+// Package dashboard implements the full-screen `powerctl dashboard` view:
+// live power, prices, the cheapest upcoming window and today's cost for
+// one home at a time.
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/i18n"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+	"github.com/kristofferrisa/powerctl-cli/internal/output"
+	"github.com/kristofferrisa/powerctl-cli/internal/stats"
+)
+
+// LiveSource streams live measurements for one home. *api.LiveClient
+// implements it.
+type LiveSource interface {
+	Subscribe(ctx context.Context, handler func(*models.LiveMeasurement) error) error
+}
+
+// PriceSource fetches a home's current, today's and tomorrow's prices
+type PriceSource interface {
+	Prices(ctx context.Context, homeID string) (*models.PriceInfo, error)
+}
+
+// Home is a home the dashboard can switch to
+type Home struct {
+	ID   string
+	Name string
+	// Location is the timezone the home's times are shown in; nil is the
+	// system's
+	Location *time.Location
+}
+
+// Terminal is where the dashboard reads keys and draws. Size is polled on
+// every redraw; Resize, if set, triggers an immediate one.
+type Terminal struct {
+	In     io.Reader
+	Out    io.Writer
+	Size   func() (width, height int)
+	Resize <-chan os.Signal
+}
+
+const (
+	// priceRefresh is the minimum interval between price refetches
+	priceRefresh = 15 * time.Minute
+
+	// tick redraws the clock and catches size changes without a signal
+	tick = time.Second
+)
+
+// reconnectDelay is the pause before resubscribing after the stream ends
+var reconnectDelay = 5 * time.Second
+
+// Dashboard holds the options and the state of the view. All state is
+// owned by the goroutine running Run.
+type Dashboard struct {
+	// WindowHours is the length of the cheapest window, in slots
+	WindowHours int
+	// SparkMinutes is the history shown in the power sparkline
+	SparkMinutes int
+	// Format controls how prices per kWh are printed; nil uses the default
+	Format *output.PriceFormat
+	// Style controls colors and glyphs; New turns colors on
+	Style output.Style
+	// Locale translates labels and formats numbers and dates; nil is
+	// English
+	Locale *i18n.Locale
+	// Now returns the current time; tests replace it
+	Now func() time.Time
+
+	homes  []Home
+	live   func(homeID string) LiveSource
+	prices PriceSource
+
+	current   int
+	latest    *models.LiveMeasurement
+	window    *stats.Window
+	info      *models.PriceInfo
+	lastFetch time.Time
+	status    string
+}
+
+// New creates a dashboard for homes, showing the first. live returns the
+// stream for a home ID.
+func New(homes []Home, live func(homeID string) LiveSource, prices PriceSource) *Dashboard {
+	return &Dashboard{
+		WindowHours:  3,
+		SparkMinutes: 15,
+		Style:        output.Style{Color: true},
+		Now:          time.Now,
+		homes:        homes,
+		live:         live,
+		prices:       prices,
+	}
+}
+
+// Show selects the home with index i before Run starts
+func (d *Dashboard) Show(i int) {
+	if i >= 0 && i < len(d.homes) {
+		d.current = i
+	}
+}
+
+// Key actions
+const (
+	keyQuit = iota + 1
+	keyNext
+	keyPrevious
+	keyRefresh
+)
+
+// measurement and priceResult carry the home generation they belong to so
+// results for a home that is no longer shown are dropped
+type measurement struct {
+	gen int
+	m   *models.LiveMeasurement
+	err error
+}
+
+type priceResult struct {
+	gen  int
+	info *models.PriceInfo
+	err  error
+}
+
+// Run draws the dashboard on t until ctx is done or q is pressed
+func (d *Dashboard) Run(ctx context.Context, t Terminal) error {
+	if len(d.homes) == 0 {
+		return fmt.Errorf("no homes to show")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	measurements := make(chan measurement)
+	priceResults := make(chan priceResult)
+	keys := make(chan int)
+	go readKeys(ctx, t.In, keys)
+
+	fmt.Fprint(t.Out, enterScreen)
+	defer fmt.Fprint(t.Out, leaveScreen)
+
+	gen := 0
+	var stopLive context.CancelFunc
+	start := func() {
+		if stopLive != nil {
+			stopLive()
+		}
+		gen++
+		d.latest, d.info, d.lastFetch, d.status = nil, nil, time.Time{}, d.Locale.T("Connecting…")
+		d.window = stats.NewWindow(time.Duration(d.SparkMinutes) * time.Minute)
+
+		var liveCtx context.Context
+		liveCtx, stopLive = context.WithCancel(ctx)
+		go d.subscribe(liveCtx, gen, d.homes[d.current].ID, measurements)
+		d.fetchPrices(ctx, gen, priceResults)
+	}
+	start()
+	defer func() { stopLive() }()
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	last := ""
+	for {
+		if d.needsPrices() {
+			d.fetchPrices(ctx, gen, priceResults)
+		}
+
+		width, height := t.Size()
+		if frame := d.Render(width, height); frame != last {
+			fmt.Fprint(t.Out, frame)
+			last = frame
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-t.Resize:
+			last = ""
+		case k := <-keys:
+			switch k {
+			case keyQuit:
+				return nil
+			case keyNext:
+				d.current = (d.current + 1) % len(d.homes)
+				start()
+			case keyPrevious:
+				d.current = (d.current + len(d.homes) - 1) % len(d.homes)
+				start()
+			case keyRefresh:
+				d.lastFetch = time.Time{}
+				d.fetchPrices(ctx, gen, priceResults)
+			}
+		case r := <-measurements:
+			if r.gen != gen {
+				continue
+			}
+			if r.err != nil {
+				d.status = d.Locale.Sprintf("Stream error: %v", r.err)
+				continue
+			}
+			d.latest = r.m
+			d.window.Add(r.m)
+			d.status = ""
+		case r := <-priceResults:
+			if r.gen != gen {
+				continue
+			}
+			if r.err != nil {
+				d.status = d.Locale.Sprintf("Failed to fetch prices: %v", r.err)
+				continue
+			}
+			d.info = r.info
+		}
+	}
+}
+
+// subscribe streams measurements for homeID into out, reconnecting after
+// errors until ctx is done
+func (d *Dashboard) subscribe(ctx context.Context, gen int, homeID string, out chan<- measurement) {
+	for {
+		err := d.live(homeID).Subscribe(ctx, func(m *models.LiveMeasurement) error {
+			select {
+			case out <- measurement{gen: gen, m: m}:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = fmt.Errorf("stream ended, reconnecting")
+		}
+		select {
+		case out <- measurement{gen: gen, err: err}:
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case <-time.After(reconnectDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// needsPrices reports whether prices are due for a refetch: when they no
+// longer cover now, or tomorrow's are missing
+func (d *Dashboard) needsPrices() bool {
+	now := d.Now()
+	if now.Sub(d.lastFetch) < priceRefresh {
+		return false
+	}
+	return d.info == nil || d.info.At(now) == nil || len(d.info.Tomorrow) == 0
+}
+
+// fetchPrices starts fetching prices for the current home
+func (d *Dashboard) fetchPrices(ctx context.Context, gen int, out chan<- priceResult) {
+	d.lastFetch = d.Now()
+	homeID := d.homes[d.current].ID
+	go func() {
+		info, err := d.prices.Prices(ctx, homeID)
+		select {
+		case out <- priceResult{gen: gen, info: info, err: err}:
+		case <-ctx.Done():
+		}
+	}()
+}
+
+// readKeys decodes key presses from in until it fails or ctx is done
+func readKeys(ctx context.Context, in io.Reader, keys chan<- int) {
+	buf := make([]byte, 16)
+	for {
+		n, err := in.Read(buf)
+		for _, k := range decodeKeys(buf[:n]) {
+			select {
+			case keys <- k:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// decodeKeys maps the bytes of one read to key actions. Arrow keys arrive
+// as ESC [ C and ESC [ D.
+func decodeKeys(b []byte) []int {
+	var keys []int
+	for i := 0; i < len(b); i++ {
+		switch b[i] {
+		case 'q', 'Q', 3: // 3 is Ctrl+C when it isn't turned into a signal
+			keys = append(keys, keyQuit)
+		case 'n', 'N', '\t', 'l':
+			keys = append(keys, keyNext)
+		case 'p', 'P', 'h':
+			keys = append(keys, keyPrevious)
+		case 'r', 'R':
+			keys = append(keys, keyRefresh)
+		case 0x1b:
+			if i+2 < len(b) && b[i+1] == '[' {
+				switch b[i+2] {
+				case 'C':
+					keys = append(keys, keyNext)
+				case 'D':
+					keys = append(keys, keyPrevious)
+				}
+				i += 2
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,291 @@
+package dashboard
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/i18n"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+	"github.com/kristofferrisa/powerctl-cli/internal/stats"
+)
+
+var testNow = time.Date(2025, 1, 1, 10, 30, 0, 0, time.Local)
+
+// fakeLive sends its measurements, then blocks until the subscription is
+// cancelled
+type fakeLive struct {
+	measurements []*models.LiveMeasurement
+}
+
+func (l *fakeLive) Subscribe(ctx context.Context, handler func(*models.LiveMeasurement) error) error {
+	for _, m := range l.measurements {
+		if err := handler(m); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// fakePrices returns the same prices for every home and records the calls
+type fakePrices struct {
+	mu    sync.Mutex
+	homes []string
+	info  *models.PriceInfo
+}
+
+func (p *fakePrices) Prices(ctx context.Context, homeID string) (*models.PriceInfo, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.homes = append(p.homes, homeID)
+	return p.info, nil
+}
+
+func (p *fakePrices) calls() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string{}, p.homes...)
+}
+
+// screen collects everything the dashboard draws
+type screen struct {
+	mu  sync.Mutex
+	buf strings.Builder
+}
+
+func (s *screen) Write(b []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(b)
+}
+
+func (s *screen) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()
+}
+
+func (s *screen) waitFor(t *testing.T, want string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(s.String(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %q on screen", want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// testPrices returns hourly prices for testNow's day, cheapest 14:00–17:00
+func testPrices() *models.PriceInfo {
+	day := time.Date(testNow.Year(), testNow.Month(), testNow.Day(), 0, 0, 0, 0, time.Local)
+	info := &models.PriceInfo{}
+	for h := 0; h < 24; h++ {
+		total := 1.0 + float64(h%12)/10
+		level := models.PriceLevelNormal
+		if h >= 14 && h < 17 {
+			total, level = 0.2, models.PriceLevelVeryCheap
+		}
+		info.Today = append(info.Today, models.Price{
+			StartsAt: day.Add(time.Duration(h) * time.Hour),
+			Total:    total,
+			Currency: "NOK",
+			Level:    level,
+		})
+	}
+	return info
+}
+
+func measurementAt(power float64) *models.LiveMeasurement {
+	return &models.LiveMeasurement{
+		Timestamp:              testNow,
+		Power:                  power,
+		AccumulatedConsumption: 12.5,
+		AccumulatedCost:        15.25,
+		Currency:               "NOK",
+	}
+}
+
+func TestDashboard_Run(t *testing.T) {
+	homes := []Home{{ID: "home-1", Name: "Home"}, {ID: "home-2", Name: "Cabin"}}
+	live := map[string]*fakeLive{
+		"home-1": {measurements: []*models.LiveMeasurement{measurementAt(1500)}},
+		"home-2": {measurements: []*models.LiveMeasurement{measurementAt(4200)}},
+	}
+	prices := &fakePrices{info: testPrices()}
+
+	d := New(homes, func(id string) LiveSource { return live[id] }, prices)
+	d.Now = func() time.Time { return testNow }
+
+	keys, typed := io.Pipe()
+	out := &screen{}
+	done := make(chan error, 1)
+	go func() {
+		done <- d.Run(context.Background(), Terminal{
+			In:   keys,
+			Out:  out,
+			Size: func() (int, int) { return 100, 40 },
+		})
+	}()
+
+	out.waitFor(t, "1500 W")
+	out.waitFor(t, "14:00–17:00")
+	typed.Write([]byte("n"))
+	out.waitFor(t, "4200 W")
+	out.waitFor(t, "Cabin")
+	typed.Write([]byte("q"))
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not return after q")
+	}
+
+	if calls := prices.calls(); len(calls) < 2 || calls[0] != "home-1" || calls[len(calls)-1] != "home-2" {
+		t.Errorf("prices fetched for %v, want home-1 then home-2", calls)
+	}
+	screen := out.String()
+	if !strings.HasPrefix(screen, enterScreen) || !strings.HasSuffix(screen, leaveScreen) {
+		t.Error("Run() should switch to the alternate screen and back")
+	}
+	if strings.Contains(screen, "\033[2J") {
+		t.Error("Run() should redraw in place, not clear the screen")
+	}
+}
+
+func TestDashboard_RunStopsWithContext(t *testing.T) {
+	d := New([]Home{{ID: "home-1"}}, func(string) LiveSource { return &fakeLive{} }, &fakePrices{info: testPrices()})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- d.Run(ctx, Terminal{In: strings.NewReader(""), Out: io.Discard, Size: func() (int, int) { return 80, 24 }})
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not return after cancel")
+	}
+}
+
+func testDashboard() *Dashboard {
+	d := New([]Home{{ID: "home-1", Name: "Home"}}, nil, nil)
+	d.Now = func() time.Time { return testNow }
+	d.window = stats.NewWindow(15 * time.Minute)
+	d.latest = measurementAt(2500)
+	d.window.Add(d.latest)
+	d.info = testPrices()
+	return d
+}
+
+func TestDashboard_Render(t *testing.T) {
+	d := testDashboard()
+
+	for _, size := range [][2]int{{120, 40}, {60, 30}} {
+		width, height := size[0], size[1]
+		frame := d.Render(width, height)
+		plain := ansiPattern.ReplaceAllString(frame, "")
+
+		for _, want := range []string{"2500 W", "15.25 NOK", "14:00–17:00", "avg 0.20 NOK/kWh", "in 3h 30m", "now 2.00", "▲"} {
+			if !strings.Contains(plain, want) {
+				t.Errorf("Render(%d, %d) missing %q:\n%s", width, height, want, plain)
+			}
+		}
+
+		lines := strings.Split(strings.TrimPrefix(frame, cursorHome), "\r\n")
+		if len(lines) > height {
+			t.Errorf("Render(%d, %d) drew %d lines", width, height, len(lines))
+		}
+		for _, line := range lines {
+			if w := visibleWidth(line); w >= width {
+				t.Errorf("Render(%d, %d) line is %d columns wide: %q", width, height, w, line)
+			}
+		}
+	}
+
+	if frame := d.Render(30, 8); !strings.Contains(frame, "Terminal too small") {
+		t.Errorf("Render() on a tiny terminal = %q", frame)
+	}
+}
+
+func TestDashboard_RenderLocalized(t *testing.T) {
+	d := testDashboard()
+	d.Locale = i18n.New(i18n.Norwegian)
+
+	plain := ansiPattern.ReplaceAllString(d.Render(120, 40), "")
+	for _, want := range []string{"Sanntidsforbruk", "Kostnad", "Billigst 3t", "Effekt", "15,25 NOK", "i dag", "snitt 0,20 NOK/kWh", "om 3h 30m", "nå 2,00", "r oppdater priser"} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("Render() in Norwegian missing %q:\n%s", want, plain)
+		}
+	}
+	for _, english := range []string{"Live power", "Cheapest", "today", "avg", "quit"} {
+		if strings.Contains(plain, english) {
+			t.Errorf("Render() in Norwegian has %q:\n%s", english, plain)
+		}
+	}
+
+	if got := d.dayLabel(testNow.AddDate(0, 0, 2), testNow); got != "fre 03. jan" {
+		t.Errorf("dayLabel() = %q, want %q", got, "fre 03. jan")
+	}
+}
+
+func TestDashboard_RenderWaiting(t *testing.T) {
+	d := New([]Home{{ID: "home-1", Name: "Home"}}, nil, nil)
+	frame := d.Render(100, 30)
+	if !strings.Contains(frame, "Waiting for live data") || !strings.Contains(frame, "Waiting for prices") {
+		t.Errorf("Render() before any data:\n%s", frame)
+	}
+}
+
+func TestDecodeKeys(t *testing.T) {
+	got := decodeKeys([]byte("n\x1b[Dpq\x1b[Cr\x03x"))
+	want := []int{keyNext, keyPrevious, keyPrevious, keyQuit, keyNext, keyRefresh, keyQuit}
+	if len(got) != len(want) {
+		t.Fatalf("decodeKeys() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("decodeKeys()[%d] = %d, want %d", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	s := "\033[1mbold\033[0m text"
+	if got := truncate(s, 6); visibleWidth(got) != 6 || !strings.HasPrefix(got, "\033[1mbold") {
+		t.Errorf("truncate() = %q", got)
+	}
+	if got := truncate(s, 20); got != s {
+		t.Errorf("truncate() of a short string = %q, want it unchanged", got)
+	}
+}
+
+func TestDashboard_RenderStyle(t *testing.T) {
+	d := testDashboard()
+	d.Style.Color, d.Style.ASCII = false, true
+
+	frame := strings.TrimSuffix(strings.TrimPrefix(d.Render(100, 30), cursorHome), clearBelow)
+	for _, line := range strings.Split(frame, "\r\n") {
+		line = strings.TrimSuffix(line, clearLine)
+		if strings.Contains(line, "\033[") {
+			t.Errorf("Render() without color drew %q", line)
+		}
+		for _, r := range line {
+			if r > 127 {
+				t.Errorf("Render() in ASCII drew %q in %q", r, line)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,390 @@
+package dashboard
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+	"github.com/kristofferrisa/powerctl-cli/internal/output"
+	"github.com/kristofferrisa/powerctl-cli/internal/pricing"
+)
+
+// Escape sequences for the alternate screen. Each frame is drawn over the
+// previous one instead of clearing the screen, which avoids flicker.
+const (
+	enterScreen = "\033[?1049h\033[?25l"
+	leaveScreen = "\033[?25h\033[?1049l"
+	cursorHome  = "\033[H"
+	clearLine   = "\033[K"
+	clearBelow  = "\033[J"
+)
+
+const (
+	// minWidth and minHeight are the smallest usable terminal
+	minWidth  = 40
+	minHeight = 12
+
+	// sideBySideWidth is the width from which the live and cost panes
+	// share a row
+	sideBySideWidth = 80
+
+	// maxChartRows caps the height of the price chart
+	maxChartRows = 10
+)
+
+// barTicks are the partial blocks used for the top of a chart bar
+var barTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Render draws one frame for a terminal of width × height, ready to be
+// written over the previous frame
+func (d *Dashboard) Render(width, height int) string {
+	var sb strings.Builder
+	sb.WriteString(cursorHome)
+	for i, line := range d.lines(width, height) {
+		if i > 0 {
+			sb.WriteString("\r\n")
+		}
+		sb.WriteString(truncate(d.Style.Apply(line), width-1))
+		sb.WriteString(clearLine)
+	}
+	sb.WriteString(clearBelow)
+	return sb.String()
+}
+
+func (d *Dashboard) lines(width, height int) []string {
+	if width < minWidth || height < minHeight {
+		return []string{d.Locale.Sprintf("Terminal too small (%d×%d), need at least %d×%d", width, height, minWidth, minHeight)}
+	}
+
+	// Leave the last column free so no line wraps
+	width--
+	now := d.Now()
+	l := d.Locale
+	lines := []string{d.header(width, now), ""}
+
+	if width >= sideBySideWidth {
+		half := width / 2
+		live, cost := d.livePane(half-4), d.costPane()
+		for len(live) < len(cost) {
+			live = append(live, "")
+		}
+		for len(cost) < len(live) {
+			cost = append(cost, "")
+		}
+		left, right := box(l.T("Live power"), live, half), box(l.T("Cost"), cost, width-half)
+		for i := range left {
+			lines = append(lines, left[i]+right[i])
+		}
+	} else {
+		lines = append(lines, box(l.T("Live power"), d.livePane(width-4), width)...)
+		lines = append(lines, box(l.T("Cost"), d.costPane(), width)...)
+	}
+	lines = append(lines, box(l.Sprintf("Cheapest %dh", d.WindowHours), d.cheapestPane(now), width)...)
+
+	// The chart gets the rows left after its box, summary, marker and axis
+	footer := d.footer()
+	rows := min(maxChartRows, height-len(lines)-len(footer)-5)
+	lines = append(lines, box(l.T("Prices"), d.pricePane(width-4, rows, now), width)...)
+
+	return append(lines, footer...)
+}
+
+func (d *Dashboard) format() *output.PriceFormat {
+	return d.Format.WithLocale(d.Locale)
+}
+
+func (d *Dashboard) header(width int, now time.Time) string {
+	left := fmt.Sprintf("%s%spowerctl dashboard%s  %s", output.Bold, output.Cyan, output.Reset, d.homes[d.current].Name)
+	if len(d.homes) > 1 {
+		left += fmt.Sprintf(" %s(%d/%d)%s", output.Dim, d.current+1, len(d.homes), output.Reset)
+	}
+	right := d.local(now).Format("15:04:05")
+	gap := max(1, width-visibleWidth(left)-visibleWidth(right))
+	return left + strings.Repeat(" ", gap) + right
+}
+
+func (d *Dashboard) footer() []string {
+	l := d.Locale
+	help := []string{l.T("r refresh prices"), l.T("q quit")}
+	if len(d.homes) > 1 {
+		help = append([]string{l.T("←/→ switch home")}, help...)
+	}
+	lines := []string{output.Dim + strings.Join(help, " · ") + output.Reset}
+	if d.status != "" {
+		lines = append([]string{output.Yellow + d.status + output.Reset}, lines...)
+	}
+	return lines
+}
+
+func (d *Dashboard) livePane(width int) []string {
+	l := d.Locale
+	m := d.latest
+	if m == nil {
+		return []string{output.Dim + l.T("Waiting for live data…") + output.Reset}
+	}
+
+	color := output.BrightGreen
+	if m.Power > 5000 {
+		color = output.BrightRed
+	} else if m.Power > 2000 {
+		color = output.BrightYellow
+	}
+
+	labels := d.labels("Power", "Average", "Solar")
+	lines := []string{
+		fmt.Sprintf("%s  %s%s%.0f W%s", labels[0], output.Bold, color, m.Power, output.Reset),
+		fmt.Sprintf("%s  %.0f / %.0f / %.0f W %s(1/5/15 min)%s", labels[1],
+			d.window.Average(time.Minute), d.window.Average(5*time.Minute), d.window.Average(15*time.Minute), output.Dim, output.Reset),
+	}
+	if m.PowerProduction > 0 {
+		lines = append(lines, fmt.Sprintf("%s  %.0f W", labels[2], m.PowerProduction))
+	}
+
+	label := " " + l.Sprintf("last %d min", d.SparkMinutes)
+	values := d.window.Sparkline(time.Duration(d.SparkMinutes)*time.Minute, max(1, width-utf8.RuneCountInString(label)))
+	if line := output.Sparkline(values); line != "" {
+		lines = append(lines, output.BrightCyan+line+output.Reset+output.Dim+label+output.Reset)
+	}
+	return lines
+}
+
+func (d *Dashboard) costPane() []string {
+	l := d.Locale
+	m := d.latest
+	if m == nil {
+		return []string{output.Dim + l.T("Waiting for live data…") + output.Reset}
+	}
+
+	var price *models.Price
+	if d.info != nil {
+		price = d.info.At(m.Timestamp)
+	}
+	s := d.window.Stats(m, price, d.SparkMinutes)
+
+	labels := d.labels("Today", "This hour", "Projected")
+	lines := []string{
+		fmt.Sprintf("%s  %s kWh  %s%s %s%s", labels[0], l.Float("%.2f", m.AccumulatedConsumption), output.Bold, l.Float("%.2f", m.AccumulatedCost), m.Currency, output.Reset),
+		fmt.Sprintf("%s  %s", labels[1], l.Sprintf("%s kWh so far, %s kWh projected", l.Float("%.2f", s.HourEnergy), l.Float("%.2f", s.ProjectedHourEnergy))),
+	}
+	if price != nil {
+		lines = append(lines, fmt.Sprintf("%s  %s %s %s %s", labels[2], l.Float("%.2f", s.ProjectedHourCost), m.Currency, l.T("at"), d.format().Price(price.Payable(), price.Currency)))
+	}
+	return lines
+}
+
+func (d *Dashboard) cheapestPane(now time.Time) []string {
+	l := d.Locale
+	if d.info == nil {
+		return []string{output.Dim + l.T("Waiting for prices…") + output.Reset}
+	}
+
+	w := pricing.CheapestWindow(d.slots(), now, d.WindowHours)
+	if w == nil {
+		return []string{output.Dim + l.Sprintf("Less than %dh of prices left; tomorrow's arrive around 13:00", d.WindowHours) + output.Reset}
+	}
+
+	when := l.T("now")
+	if w.Start.After(now) {
+		when = l.Sprintf("in %s", formatWait(w.Start.Sub(now)))
+	}
+	return []string{fmt.Sprintf("%s%s–%s%s %s  %s %s  %s%s%s",
+		output.Bold, d.local(w.Start).Format("15:04"), d.local(w.End).Format("15:04"), output.Reset, d.dayLabel(w.Start, now),
+		l.T("avg"), d.format().Price(w.Average, w.Prices[0].Currency), output.Dim, when, output.Reset)}
+}
+
+// pricePane draws today's and tomorrow's prices as a bar chart rows high,
+// with a summary above and now and the cheapest window marked below
+func (d *Dashboard) pricePane(width, rows int, now time.Time) []string {
+	l := d.Locale
+	if d.info == nil {
+		return []string{output.Dim + l.T("Waiting for prices…") + output.Reset}
+	}
+	slots := d.slots()
+	if len(slots) == 0 {
+		return []string{output.Dim + l.T("No prices available") + output.Reset}
+	}
+
+	current := -1
+	for i := range slots {
+		end := slots[i].StartsAt.Add(time.Hour)
+		if i+1 < len(slots) {
+			end = slots[i+1].StartsAt
+		}
+		if !now.Before(slots[i].StartsAt) && now.Before(end) {
+			current = i
+		}
+	}
+
+	// Keep some history in view when everything doesn't fit
+	if len(slots) > width {
+		first := max(0, min(current-width/4, len(slots)-width))
+		current -= first
+		slots = slots[first : first+width]
+	}
+
+	pf := d.format()
+	lo, hi := slots[0].Payable(), slots[0].Payable()
+	var cheapest, dearest int
+	for i, p := range slots {
+		if p.Payable() < lo {
+			lo, cheapest = p.Payable(), i
+		}
+		if p.Payable() > hi {
+			hi, dearest = p.Payable(), i
+		}
+	}
+	summary := fmt.Sprintf("%s %s %s  %s %s %s  %s",
+		l.T("min"), pf.Number(lo), d.local(slots[cheapest].StartsAt).Format("15:04"),
+		l.T("max"), pf.Number(hi), d.local(slots[dearest].StartsAt).Format("15:04"), pf.Unit(slots[0].Currency)+"/kWh")
+	if current >= 0 {
+		summary = fmt.Sprintf("%s %s%s%s  ", l.T("now"), output.Bold, pf.Number(slots[current].Payable()), output.Reset) + summary
+	}
+	lines := []string{summary}
+	if rows < 2 {
+		return lines
+	}
+
+	// Bars start at zero; negative prices are drawn empty
+	scale := hi
+	if scale <= 0 {
+		scale = 1
+	}
+	for r := rows - 1; r >= 0; r-- {
+		var sb strings.Builder
+		for _, p := range slots {
+			eighths := int(max(0, p.Payable()) / scale * float64(rows*8))
+			cell := eighths - r*8
+			switch {
+			case cell <= 0:
+				sb.WriteByte(' ')
+			default:
+				sb.WriteString(output.PriceColor(p.Level) + string(barTicks[min(cell, 8)-1]) + output.Reset)
+			}
+		}
+		lines = append(lines, sb.String())
+	}
+
+	window := pricing.CheapestWindow(slots, now, d.WindowHours)
+	marks := []rune(strings.Repeat(" ", len(slots)))
+	if window != nil {
+		for i := range slots {
+			if !slots[i].StartsAt.Before(window.Start) && slots[i].StartsAt.Before(window.End) {
+				marks[i] = '─'
+			}
+		}
+	}
+	if current >= 0 {
+		marks[current] = '▲'
+	}
+	lines = append(lines, output.BrightYellow+string(marks)+output.Reset)
+
+	axis := []rune(strings.Repeat(" ", len(slots)))
+	for i, p := range slots {
+		t := d.local(p.StartsAt)
+		if t.Minute() == 0 && t.Hour()%6 == 0 && i+1 < len(axis) {
+			copy(axis[i:], []rune(t.Format("15")))
+		}
+	}
+	lines = append(lines, output.Dim+string(axis)+output.Reset)
+
+	return lines
+}
+
+// slots returns today's and tomorrow's prices in order
+func (d *Dashboard) slots() []models.Price {
+	return append(append([]models.Price{}, d.info.Today...), d.info.Tomorrow...)
+}
+
+// local returns t in the shown home's timezone
+func (d *Dashboard) local(t time.Time) time.Time {
+	if loc := d.homes[d.current].Location; loc != nil {
+		return t.In(loc)
+	}
+	return t.Local()
+}
+
+// dayLabel names the day of t relative to now
+func (d *Dashboard) dayLabel(t, now time.Time) string {
+	t, now = d.local(t), d.local(now)
+	switch t.Format("2006-01-02") {
+	case now.Format("2006-01-02"):
+		return d.Locale.T("today")
+	case now.AddDate(0, 0, 1).Format("2006-01-02"):
+		return d.Locale.T("tomorrow")
+	}
+	return d.Locale.Date(t, "Mon 02 Jan")
+}
+
+// labels translates a pane's row labels and pads them to the same width
+func (d *Dashboard) labels(names ...string) []string {
+	labels := make([]string, len(names))
+	width := 0
+	for i, name := range names {
+		labels[i] = d.Locale.T(name)
+		width = max(width, utf8.RuneCountInString(labels[i]))
+	}
+	for i := range labels {
+		labels[i] += strings.Repeat(" ", width-utf8.RuneCountInString(labels[i]))
+	}
+	return labels
+}
+
+// formatWait formats a duration as e.g. "2h 15m"
+func formatWait(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// box frames lines in a border width columns wide, with title in the top
+// edge
+func box(title string, lines []string, width int) []string {
+	inner := width - 4
+	top := "┌─ " + title + " " + strings.Repeat("─", max(0, width-5-utf8.RuneCountInString(title))) + "┐"
+	out := []string{output.Dim + top + output.Reset}
+	for _, line := range lines {
+		out = append(out, output.Dim+"│"+output.Reset+" "+padRight(line, inner)+" "+output.Dim+"│"+output.Reset)
+	}
+	return append(out, output.Dim+"└"+strings.Repeat("─", max(0, width-2))+"┘"+output.Reset)
+}
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// visibleWidth counts the columns s takes up, ignoring escape sequences
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(ansiPattern.ReplaceAllString(s, ""))
+}
+
+// truncate cuts s to width visible columns, keeping escape sequences
+func truncate(s string, width int) string {
+	if visibleWidth(s) <= width {
+		return s
+	}
+
+	var sb strings.Builder
+	n := 0
+	for len(s) > 0 && n < width {
+		if loc := ansiPattern.FindStringIndex(s); loc != nil && loc[0] == 0 {
+			sb.WriteString(s[:loc[1]])
+			s = s[loc[1]:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		sb.WriteRune(r)
+		s = s[size:]
+		n++
+	}
+	sb.WriteString(output.Reset)
+	return sb.String()
+}
+
+// padRight truncates or pads s with spaces to width visible columns
+func padRight(s string, width int) string {
+	s = truncate(s, width)
+	return s + strings.Repeat(" ", max(0, width-visibleWidth(s)))
+}
@@ -0,0 +1,663 @@
+package i18n
+
+// catalogs holds the translations of each language, keyed by the English
+// message. Formats keep the English verbs in the same order.
+var catalogs = map[string]map[string]string{
+	Norwegian: {
+		// Homes
+		"Home":          "Hjem",
+		"Tibber Homes":  "Tibber-hjem",
+		"Address":       "Adresse",
+		"Details":       "Detaljer",
+		"Size":          "Størrelse",
+		"Type":          "Type",
+		"Residents":     "Beboere",
+		"Main Fuse":     "Sikring",
+		"Status:":       "Status:",
+		"Connected":     "Tilkoblet",
+		"Not connected": "Ikke tilkoblet",
+		"Pulse Enabled": "Pulse aktivert",
+		"Property":      "Egenskap",
+		"Value":         "Verdi",
+		"Yes":           "Ja",
+		"No":            "Nei",
+		"Apartment":     "Leilighet",
+		"Rowhouse":      "Rekkehus",
+		"House":         "Enebolig",
+		"Cottage":       "Hytte",
+
+		// Prices
+		"Electricity Prices": "Strømpriser",
+		"Current Price":      "Nåværende pris",
+		"NOW":                "NÅ",
+		"Today":              "I dag",
+		"Tomorrow":           "I morgen",
+		"Today & Tomorrow":   "I dag og i morgen",
+		"Not yet available (published ~13:00, see 'powerctl prices wait-tomorrow')":                       "Ikke tilgjengelig ennå (publiseres ~13:00, se 'powerctl prices wait-tomorrow')",
+		"Tomorrow's prices are not yet available (published ~13:00, see 'powerctl prices wait-tomorrow')": "Morgendagens priser er ikke tilgjengelige ennå (publiseres ~13:00, se 'powerctl prices wait-tomorrow')",
+		"Tomorrow's prices not yet available (published around 13:00)":                                    "Morgendagens priser er ikke tilgjengelige ennå (publiseres rundt 13:00)",
+		"Components":             "Komponenter",
+		"Effective price":        "Effektiv pris",
+		"Time":                   "Tid",
+		"Price":                  "Pris",
+		"Level":                  "Nivå",
+		"Total":                  "Totalt",
+		"Energy":                 "Energi",
+		"Tax":                    "Avgift",
+		"total":                  "totalt",
+		"energy":                 "energi",
+		"tax":                    "avgift",
+		"spot":                   "spot",
+		"grid":                   "nettleie",
+		"fees":                   "gebyrer",
+		"subsidy":                "strømstøtte",
+		"fixed price settlement": "fastprisavregning",
+		"now":                    "nå",
+		"cheapest":               "billigst",
+		"most expensive":         "dyrest",
+
+		// Price levels
+		"Very Cheap":     "Svært billig",
+		"Cheap":          "Billig",
+		"Normal":         "Normal",
+		"Expensive":      "Dyr",
+		"Very Expensive": "Svært dyr",
+		"VERY_CHEAP":     "SVÆRT BILLIG",
+		"CHEAP":          "BILLIG",
+		"NORMAL":         "NORMAL",
+		"EXPENSIVE":      "DYR",
+		"VERY_EXPENSIVE": "SVÆRT DYR",
+		"No price":       "Ingen pris",
+
+		// Price breakdown
+		"Price Breakdown":     "Prisfordeling",
+		"No prices available": "Ingen priser tilgjengelig",
+		"grid & fees":         "nettleie og gebyrer",
+		"Grid & fees":         "Nettleie og gebyrer",
+		"Prices in %s/kWh.":   "Priser i %s/kWh.",
+		"Tax share":           "Avgiftsandel",
+		"Daily summary":       "Daglig oppsummering",
+		"Daily Summary":       "Daglig oppsummering",
+		"Date":                "Dato",
+		"avg":                 "snitt",
+
+		// Price range
+		"Prices":                  "Priser",
+		"No prices in this range": "Ingen priser i dette tidsrommet",
+		"Average":                 "Snitt",
+		"Lowest":                  "Lavest",
+		"Highest":                 "Høyest",
+		"Min":                     "Min",
+		"Max":                     "Maks",
+		"Metric":                  "Måltall",
+		"hourly":                  "timepriser",
+		"daily":                   "døgnpriser",
+		"%d hourly prices":        "%d timepriser",
+		"%d daily prices":         "%d døgnpriser",
+
+		// Price rating
+		"Price Rating":                          "Prisvurdering",
+		"monthly":                               "månedlig",
+		"This hour":                             "Denne timen",
+		"This month":                            "Denne måneden",
+		"vs. trailing average":                  "mot glidende snitt",
+		"Recent average":                        "Snitt siste periode",
+		"High above %+.0f%%, low below %+.0f%%": "Høy over %+.0f%%, lav under %+.0f%%",
+		"Thresholds: high above %+.0f%%, low below %+.0f%%": "Terskler: høy over %+.0f%%, lav under %+.0f%%",
+		"No entries": "Ingen oppføringer",
+		"Period":     "Periode",
+		"Difference": "Differanse",
+		"Low":        "Lav",
+		"High":       "Høy",
+		"LOW":        "LAV",
+		"HIGH":       "HØY",
+
+		// Live power
+		"Live Power":                      "Sanntidsforbruk",
+		"Production:":                     "Produksjon:",
+		"Production":                      "Produksjon",
+		"Power":                           "Effekt",
+		"Consumed:":                       "Forbrukt:",
+		"Cost:":                           "Kostnad:",
+		"Cost":                            "Kostnad",
+		"Grid":                            "Strømnett",
+		"Voltage:":                        "Spenning:",
+		"Voltage":                         "Spenning",
+		"Current:":                        "Strøm:",
+		"Current":                         "Strøm",
+		"Updated":                         "Oppdatert",
+		"Trend":                           "Trend",
+		"Avg:":                            "Snitt:",
+		"Peak:":                           "Topp:",
+		"Peak":                            "Topp",
+		"Min:":                            "Min:",
+		"at":                              "kl.",
+		"last %d min":                     "siste %d min",
+		"Last %d min":                     "Siste %d min",
+		"So far:":                         "Hittil:",
+		"Projected:":                      "Anslått:",
+		"Projected cost":                  "Anslått kostnad",
+		"%s kWh so far, %s kWh projected": "%s kWh hittil, %s kWh anslått",
+
+		// Capacity peaks
+		"Capacity peaks":                         "Effekttopper",
+		"Capacity Peaks":                         "Effekttopper",
+		"No hourly data yet this month":          "Ingen timedata ennå denne måneden",
+		"Hour":                                   "Time",
+		"Average:":                               "Snitt:",
+		"step %d":                                "trinn %d",
+		"%s/month":                               "%s/måned",
+		"no upper limit":                         "ingen øvre grense",
+		"below %s kW":                            "under %s kW",
+		"This hour:":                             "Denne timen:",
+		"projected":                              "anslått",
+		"This hour (projected)":                  "Denne timen (anslått)",
+		"New peak ahead: average %s kW, step %d": "Ny effekttopp i vente: snitt %s kW, trinn %d",
+		"This hour is projected to set a new peak (average %s kW, step %d)": "Denne timen ser ut til å gi en ny effekttopp (snitt %s kW, trinn %d)",
+
+		// Cost report
+		"Cost report":                       "Kostnadsrapport",
+		"Cost Report":                       "Kostnadsrapport",
+		"No consumption this month":         "Intet forbruk denne måneden",
+		"%d hours":                          "%d timer",
+		"incl. %s %s grid tariff and fees":  "inkl. %s %s nettleie og gebyrer",
+		"after %s %s estimated subsidy":     "etter %s %s beregnet strømstøtte",
+		"Fee":                               "Gebyr",
+		"fixed monthly":                     "fast per måned",
+		"Paid":                              "Betalt",
+		"volume-weighted":                   "volumvektet",
+		"Spot":                              "Spot",
+		"monthly average":                   "månedssnitt",
+		"Timing":                            "Tidsvalg",
+		"Most expensive hours":              "Dyreste timer",
+		"Most Expensive Hours":              "Dyreste timer",
+		"By price level":                    "Etter prisnivå",
+		"By Price Level":                    "Etter prisnivå",
+		"Hours":                             "Timer",
+		"Share":                             "Andel",
+		"Grid tariff and fees (in cost)":    "Nettleie og gebyrer (i kostnad)",
+		"Estimated subsidy (deducted)":      "Beregnet strømstøtte (trukket fra)",
+		"Fixed monthly fee":                 "Fast månedsgebyr",
+		"Average price paid":                "Snittpris betalt",
+		"Spot average":                      "Spotsnitt",
+		"%s%%, saved %s %s":                 "%s%%, spart %s %s",
+		"%s%%, cost %s %s extra":            "%s%%, kostet %s %s ekstra",
+		"Converted from %s at 1 %s = %s %s": "Omregnet fra %s med 1 %s = %s %s",
+
+		// Dashboard
+		"Live power":   "Sanntidsforbruk",
+		"Cheapest %dh": "Billigst %dt",
+		"Terminal too small (%d×%d), need at least %d×%d": "Terminalen er for liten (%d×%d), trenger minst %d×%d",
+		"←/→ switch home":                                 "←/→ bytt hjem",
+		"r refresh prices":                                "r oppdater priser",
+		"q quit":                                          "q avslutt",
+		"Waiting for live data…":                          "Venter på sanntidsdata…",
+		"Waiting for prices…":                             "Venter på priser…",
+		"Solar":                                           "Sol",
+		"Projected":                                       "Anslått",
+		"Less than %dh of prices left; tomorrow's arrive around 13:00": "Mindre enn %dt med priser igjen; morgendagens kommer rundt 13:00",
+		"in %s":                      "om %s",
+		"min":                        "min",
+		"max":                        "maks",
+		"Connecting…":                "Kobler til…",
+		"Stream error: %v":           "Feil i sanntidsstrømmen: %v",
+		"Failed to fetch prices: %v": "Kunne ikke hente priser: %v",
+		"today":                      "i dag",
+		"tomorrow":                   "i morgen",
+
+		// Alerts, notifications and diagnostics
+		"Alerts":                         "Varsler",
+		"Alert":                          "Varsel",
+		"State":                          "Tilstand",
+		"Detail":                         "Detaljer",
+		"Push Notification":              "Push-varsel",
+		"Push notification sent":         "Push-varsel sendt",
+		"Push notification was not sent": "Push-varselet ble ikke sendt",
+		"to %s device(s)":                "til %s enhet(er)",
+		"Successful":                     "Vellykket",
+		"Devices":                        "Enheter",
+		"Diagnostics":                    "Diagnostikk",
+		"Check":                          "Sjekk",
+		"Status":                         "Status",
+		"Some checks failed":             "Noen sjekker feilet",
+		"All checks passed":              "Alle sjekker bestått",
+	},
+	Swedish: {
+		// Homes
+		"Home":          "Hem",
+		"Tibber Homes":  "Tibber-hem",
+		"Address":       "Adress",
+		"Details":       "Detaljer",
+		"Size":          "Storlek",
+		"Type":          "Typ",
+		"Residents":     "Boende",
+		"Main Fuse":     "Säkring",
+		"Status:":       "Status:",
+		"Connected":     "Ansluten",
+		"Not connected": "Inte ansluten",
+		"Pulse Enabled": "Pulse aktiverad",
+		"Property":      "Egenskap",
+		"Value":         "Värde",
+		"Yes":           "Ja",
+		"No":            "Nej",
+		"Apartment":     "Lägenhet",
+		"Rowhouse":      "Radhus",
+		"House":         "Villa",
+		"Cottage":       "Fritidshus",
+
+		// Prices
+		"Electricity Prices": "Elpriser",
+		"Current Price":      "Aktuellt pris",
+		"NOW":                "NU",
+		"Today":              "Idag",
+		"Tomorrow":           "Imorgon",
+		"Today & Tomorrow":   "Idag och imorgon",
+		"Not yet available (published ~13:00, see 'powerctl prices wait-tomorrow')":                       "Ännu inte tillgängliga (publiceras ~13:00, se 'powerctl prices wait-tomorrow')",
+		"Tomorrow's prices are not yet available (published ~13:00, see 'powerctl prices wait-tomorrow')": "Morgondagens priser är ännu inte tillgängliga (publiceras ~13:00, se 'powerctl prices wait-tomorrow')",
+		"Tomorrow's prices not yet available (published around 13:00)":                                    "Morgondagens priser är ännu inte tillgängliga (publiceras runt 13:00)",
+		"Components":             "Komponenter",
+		"Effective price":        "Effektivt pris",
+		"Time":                   "Tid",
+		"Price":                  "Pris",
+		"Level":                  "Nivå",
+		"Total":                  "Totalt",
+		"Energy":                 "Energi",
+		"Tax":                    "Skatt",
+		"total":                  "totalt",
+		"energy":                 "energi",
+		"tax":                    "skatt",
+		"spot":                   "spot",
+		"grid":                   "nätavgift",
+		"fees":                   "avgifter",
+		"subsidy":                "elstöd",
+		"fixed price settlement": "fastprisavräkning",
+		"now":                    "nu",
+		"cheapest":               "billigast",
+		"most expensive":         "dyrast",
+
+		// Price levels
+		"Very Cheap":     "Mycket billigt",
+		"Cheap":          "Billigt",
+		"Normal":         "Normalt",
+		"Expensive":      "Dyrt",
+		"Very Expensive": "Mycket dyrt",
+		"VERY_CHEAP":     "MYCKET BILLIGT",
+		"CHEAP":          "BILLIGT",
+		"NORMAL":         "NORMALT",
+		"EXPENSIVE":      "DYRT",
+		"VERY_EXPENSIVE": "MYCKET DYRT",
+		"No price":       "Inget pris",
+
+		// Price breakdown
+		"Price Breakdown":     "Prisuppdelning",
+		"No prices available": "Inga priser tillgängliga",
+		"grid & fees":         "nät och avgifter",
+		"Grid & fees":         "Nät och avgifter",
+		"Prices in %s/kWh.":   "Priser i %s/kWh.",
+		"Tax share":           "Skatteandel",
+		"Daily summary":       "Daglig sammanfattning",
+		"Daily Summary":       "Daglig sammanfattning",
+		"Date":                "Datum",
+		"avg":                 "snitt",
+
+		// Price range
+		"Prices":                  "Priser",
+		"No prices in this range": "Inga priser i detta intervall",
+		"Average":                 "Snitt",
+		"Lowest":                  "Lägst",
+		"Highest":                 "Högst",
+		"Min":                     "Min",
+		"Max":                     "Max",
+		"Metric":                  "Mått",
+		"hourly":                  "timpriser",
+		"daily":                   "dygnspriser",
+		"%d hourly prices":        "%d timpriser",
+		"%d daily prices":         "%d dygnspriser",
+
+		// Price rating
+		"Price Rating":                          "Prisbedömning",
+		"monthly":                               "månadsvis",
+		"This hour":                             "Denna timme",
+		"This month":                            "Denna månad",
+		"vs. trailing average":                  "mot glidande snitt",
+		"Recent average":                        "Snitt senaste perioden",
+		"High above %+.0f%%, low below %+.0f%%": "Högt över %+.0f%%, lågt under %+.0f%%",
+		"Thresholds: high above %+.0f%%, low below %+.0f%%": "Gränser: högt över %+.0f%%, lågt under %+.0f%%",
+		"No entries": "Inga poster",
+		"Period":     "Period",
+		"Difference": "Skillnad",
+		"Low":        "Lågt",
+		"High":       "Högt",
+		"LOW":        "LÅGT",
+		"HIGH":       "HÖGT",
+
+		// Live power
+		"Live Power":                      "Effekt i realtid",
+		"Production:":                     "Produktion:",
+		"Production":                      "Produktion",
+		"Power":                           "Effekt",
+		"Consumed:":                       "Förbrukat:",
+		"Cost:":                           "Kostnad:",
+		"Cost":                            "Kostnad",
+		"Grid":                            "Elnät",
+		"Voltage:":                        "Spänning:",
+		"Voltage":                         "Spänning",
+		"Current:":                        "Ström:",
+		"Current":                         "Ström",
+		"Updated":                         "Uppdaterad",
+		"Trend":                           "Trend",
+		"Avg:":                            "Snitt:",
+		"Peak:":                           "Topp:",
+		"Peak":                            "Topp",
+		"Min:":                            "Min:",
+		"at":                              "kl.",
+		"last %d min":                     "senaste %d min",
+		"Last %d min":                     "Senaste %d min",
+		"So far:":                         "Hittills:",
+		"Projected:":                      "Beräknat:",
+		"Projected cost":                  "Beräknad kostnad",
+		"%s kWh so far, %s kWh projected": "%s kWh hittills, %s kWh beräknat",
+
+		// Capacity peaks
+		"Capacity peaks":                         "Effekttoppar",
+		"Capacity Peaks":                         "Effekttoppar",
+		"No hourly data yet this month":          "Inga timdata ännu denna månad",
+		"Hour":                                   "Timme",
+		"Average:":                               "Snitt:",
+		"step %d":                                "steg %d",
+		"%s/month":                               "%s/månad",
+		"no upper limit":                         "ingen övre gräns",
+		"below %s kW":                            "under %s kW",
+		"This hour:":                             "Denna timme:",
+		"projected":                              "beräknat",
+		"This hour (projected)":                  "Denna timme (beräknat)",
+		"New peak ahead: average %s kW, step %d": "Ny effekttopp väntas: snitt %s kW, steg %d",
+		"This hour is projected to set a new peak (average %s kW, step %d)": "Denna timme väntas ge en ny effekttopp (snitt %s kW, steg %d)",
+
+		// Cost report
+		"Cost report":                       "Kostnadsrapport",
+		"Cost Report":                       "Kostnadsrapport",
+		"No consumption this month":         "Ingen förbrukning denna månad",
+		"%d hours":                          "%d timmar",
+		"incl. %s %s grid tariff and fees":  "inkl. %s %s nätavgift och avgifter",
+		"after %s %s estimated subsidy":     "efter %s %s beräknat elstöd",
+		"Fee":                               "Avgift",
+		"fixed monthly":                     "fast per månad",
+		"Paid":                              "Betalt",
+		"volume-weighted":                   "volymviktat",
+		"Spot":                              "Spot",
+		"monthly average":                   "månadssnitt",
+		"Timing":                            "Tidsval",
+		"Most expensive hours":              "Dyraste timmarna",
+		"Most Expensive Hours":              "Dyraste timmarna",
+		"By price level":                    "Per prisnivå",
+		"By Price Level":                    "Per prisnivå",
+		"Hours":                             "Timmar",
+		"Share":                             "Andel",
+		"Grid tariff and fees (in cost)":    "Nätavgift och avgifter (i kostnad)",
+		"Estimated subsidy (deducted)":      "Beräknat elstöd (avdraget)",
+		"Fixed monthly fee":                 "Fast månadsavgift",
+		"Average price paid":                "Betalt snittpris",
+		"Spot average":                      "Spotsnitt",
+		"%s%%, saved %s %s":                 "%s%%, sparade %s %s",
+		"%s%%, cost %s %s extra":            "%s%%, kostade %s %s extra",
+		"Converted from %s at 1 %s = %s %s": "Omräknat från %s med 1 %s = %s %s",
+
+		// Dashboard
+		"Live power":   "Effekt i realtid",
+		"Cheapest %dh": "Billigast %dh",
+		"Terminal too small (%d×%d), need at least %d×%d": "Terminalen är för liten (%d×%d), behöver minst %d×%d",
+		"←/→ switch home":                                 "←/→ byt hem",
+		"r refresh prices":                                "r uppdatera priser",
+		"q quit":                                          "q avsluta",
+		"Waiting for live data…":                          "Väntar på realtidsdata…",
+		"Waiting for prices…":                             "Väntar på priser…",
+		"Solar":                                           "Sol",
+		"Projected":                                       "Beräknat",
+		"Less than %dh of prices left; tomorrow's arrive around 13:00": "Mindre än %dh priser kvar; morgondagens kommer runt 13:00",
+		"in %s":                      "om %s",
+		"min":                        "min",
+		"max":                        "max",
+		"Connecting…":                "Ansluter…",
+		"Stream error: %v":           "Fel i realtidsströmmen: %v",
+		"Failed to fetch prices: %v": "Kunde inte hämta priser: %v",
+		"today":                      "idag",
+		"tomorrow":                   "imorgon",
+
+		// Alerts, notifications and diagnostics
+		"Alerts":                         "Larm",
+		"Alert":                          "Larm",
+		"State":                          "Tillstånd",
+		"Detail":                         "Detaljer",
+		"Push Notification":              "Pushnotis",
+		"Push notification sent":         "Pushnotis skickad",
+		"Push notification was not sent": "Pushnotisen skickades inte",
+		"to %s device(s)":                "till %s enhet(er)",
+		"Successful":                     "Lyckades",
+		"Devices":                        "Enheter",
+		"Diagnostics":                    "Diagnostik",
+		"Check":                          "Kontroll",
+		"Status":                         "Status",
+		"Some checks failed":             "Några kontroller misslyckades",
+		"All checks passed":              "Alla kontroller godkända",
+	},
+	German: {
+		// Homes
+		"Home":          "Zuhause",
+		"Tibber Homes":  "Tibber-Zuhause",
+		"Address":       "Adresse",
+		"Details":       "Details",
+		"Size":          "Größe",
+		"Type":          "Typ",
+		"Residents":     "Bewohner",
+		"Main Fuse":     "Sicherung",
+		"Status:":       "Status:",
+		"Connected":     "Verbunden",
+		"Not connected": "Nicht verbunden",
+		"Pulse Enabled": "Pulse aktiviert",
+		"Property":      "Eigenschaft",
+		"Value":         "Wert",
+		"Yes":           "Ja",
+		"No":            "Nein",
+		"Apartment":     "Wohnung",
+		"Rowhouse":      "Reihenhaus",
+		"House":         "Haus",
+		"Cottage":       "Ferienhaus",
+
+		// Prices
+		"Electricity Prices": "Strompreise",
+		"Current Price":      "Aktueller Preis",
+		"NOW":                "JETZT",
+		"Today":              "Heute",
+		"Tomorrow":           "Morgen",
+		"Today & Tomorrow":   "Heute und morgen",
+		"Not yet available (published ~13:00, see 'powerctl prices wait-tomorrow')":                       "Noch nicht verfügbar (veröffentlicht ~13:00, siehe 'powerctl prices wait-tomorrow')",
+		"Tomorrow's prices are not yet available (published ~13:00, see 'powerctl prices wait-tomorrow')": "Die Preise für morgen sind noch nicht verfügbar (veröffentlicht ~13:00, siehe 'powerctl prices wait-tomorrow')",
+		"Tomorrow's prices not yet available (published around 13:00)":                                    "Die Preise für morgen sind noch nicht verfügbar (veröffentlicht gegen 13:00)",
+		"Components":             "Bestandteile",
+		"Effective price":        "Effektiver Preis",
+		"Time":                   "Zeit",
+		"Price":                  "Preis",
+		"Level":                  "Stufe",
+		"Total":                  "Gesamt",
+		"Energy":                 "Energie",
+		"Tax":                    "Steuern",
+		"total":                  "gesamt",
+		"energy":                 "Energie",
+		"tax":                    "Steuern",
+		"spot":                   "Spot",
+		"grid":                   "Netzentgelt",
+		"fees":                   "Gebühren",
+		"subsidy":                "Zuschuss",
+		"fixed price settlement": "Festpreisabrechnung",
+		"now":                    "jetzt",
+		"cheapest":               "am günstigsten",
+		"most expensive":         "am teuersten",
+
+		// Price levels
+		"Very Cheap":     "Sehr günstig",
+		"Cheap":          "Günstig",
+		"Normal":         "Normal",
+		"Expensive":      "Teuer",
+		"Very Expensive": "Sehr teuer",
+		"VERY_CHEAP":     "SEHR GÜNSTIG",
+		"CHEAP":          "GÜNSTIG",
+		"NORMAL":         "NORMAL",
+		"EXPENSIVE":      "TEUER",
+		"VERY_EXPENSIVE": "SEHR TEUER",
+		"No price":       "Kein Preis",
+
+		// Price breakdown
+		"Price Breakdown":     "Preiszusammensetzung",
+		"No prices available": "Keine Preise verfügbar",
+		"grid & fees":         "Netz und Gebühren",
+		"Grid & fees":         "Netz und Gebühren",
+		"Prices in %s/kWh.":   "Preise in %s/kWh.",
+		"Tax share":           "Steueranteil",
+		"Daily summary":       "Tageszusammenfassung",
+		"Daily Summary":       "Tageszusammenfassung",
+		"Date":                "Datum",
+		"avg":                 "Ø",
+
+		// Price range
+		"Prices":                  "Preise",
+		"No prices in this range": "Keine Preise in diesem Zeitraum",
+		"Average":                 "Schnitt",
+		"Lowest":                  "Tiefst",
+		"Highest":                 "Höchst",
+		"Min":                     "Min",
+		"Max":                     "Max",
+		"Metric":                  "Kennzahl",
+		"hourly":                  "Stundenpreise",
+		"daily":                   "Tagespreise",
+		"%d hourly prices":        "%d Stundenpreise",
+		"%d daily prices":         "%d Tagespreise",
+
+		// Price rating
+		"Price Rating":                          "Preisbewertung",
+		"monthly":                               "monatlich",
+		"This hour":                             "Diese Stunde",
+		"This month":                            "Dieser Monat",
+		"vs. trailing average":                  "ggü. gleitendem Schnitt",
+		"Recent average":                        "Schnitt des letzten Zeitraums",
+		"High above %+.0f%%, low below %+.0f%%": "Hoch über %+.0f%%, niedrig unter %+.0f%%",
+		"Thresholds: high above %+.0f%%, low below %+.0f%%": "Schwellen: hoch über %+.0f%%, niedrig unter %+.0f%%",
+		"No entries": "Keine Einträge",
+		"Period":     "Zeitraum",
+		"Difference": "Abweichung",
+		"Low":        "Niedrig",
+		"High":       "Hoch",
+		"LOW":        "NIEDRIG",
+		"HIGH":       "HOCH",
+
+		// Live power
+		"Live Power":                      "Live-Leistung",
+		"Production:":                     "Erzeugung:",
+		"Production":                      "Erzeugung",
+		"Power":                           "Leistung",
+		"Consumed:":                       "Verbrauch:",
+		"Cost:":                           "Kosten:",
+		"Cost":                            "Kosten",
+		"Grid":                            "Netz",
+		"Voltage:":                        "Spannung:",
+		"Voltage":                         "Spannung",
+		"Current:":                        "Strom:",
+		"Current":                         "Strom",
+		"Updated":                         "Aktualisiert",
+		"Trend":                           "Trend",
+		"Avg:":                            "Ø:",
+		"Peak:":                           "Spitze:",
+		"Peak":                            "Spitze",
+		"Min:":                            "Min:",
+		"at":                              "um",
+		"last %d min":                     "letzte %d Min.",
+		"Last %d min":                     "Letzte %d Min.",
+		"So far:":                         "Bisher:",
+		"Projected:":                      "Prognose:",
+		"Projected cost":                  "Prognostizierte Kosten",
+		"%s kWh so far, %s kWh projected": "%s kWh bisher, %s kWh prognostiziert",
+
+		// Capacity peaks
+		"Capacity peaks":                         "Leistungsspitzen",
+		"Capacity Peaks":                         "Leistungsspitzen",
+		"No hourly data yet this month":          "Noch keine Stundendaten in diesem Monat",
+		"Hour":                                   "Stunde",
+		"Average:":                               "Schnitt:",
+		"step %d":                                "Stufe %d",
+		"%s/month":                               "%s/Monat",
+		"no upper limit":                         "keine Obergrenze",
+		"below %s kW":                            "unter %s kW",
+		"This hour:":                             "Diese Stunde:",
+		"projected":                              "prognostiziert",
+		"This hour (projected)":                  "Diese Stunde (Prognose)",
+		"New peak ahead: average %s kW, step %d": "Neue Leistungsspitze absehbar: Schnitt %s kW, Stufe %d",
+		"This hour is projected to set a new peak (average %s kW, step %d)": "Diese Stunde setzt voraussichtlich eine neue Leistungsspitze (Schnitt %s kW, Stufe %d)",
+
+		// Cost report
+		"Cost report":                       "Kostenbericht",
+		"Cost Report":                       "Kostenbericht",
+		"No consumption this month":         "Kein Verbrauch in diesem Monat",
+		"%d hours":                          "%d Stunden",
+		"incl. %s %s grid tariff and fees":  "inkl. %s %s Netzentgelt und Gebühren",
+		"after %s %s estimated subsidy":     "nach %s %s geschätztem Zuschuss",
+		"Fee":                               "Gebühr",
+		"fixed monthly":                     "monatlich fix",
+		"Paid":                              "Bezahlt",
+		"volume-weighted":                   "mengengewichtet",
+		"Spot":                              "Spot",
+		"monthly average":                   "Monatsschnitt",
+		"Timing":                            "Zeitwahl",
+		"Most expensive hours":              "Teuerste Stunden",
+		"Most Expensive Hours":              "Teuerste Stunden",
+		"By price level":                    "Nach Preisstufe",
+		"By Price Level":                    "Nach Preisstufe",
+		"Hours":                             "Stunden",
+		"Share":                             "Anteil",
+		"Grid tariff and fees (in cost)":    "Netzentgelt und Gebühren (in Kosten)",
+		"Estimated subsidy (deducted)":      "Geschätzter Zuschuss (abgezogen)",
+		"Fixed monthly fee":                 "Monatliche Grundgebühr",
+		"Average price paid":                "Bezahlter Durchschnittspreis",
+		"Spot average":                      "Spot-Schnitt",
+		"%s%%, saved %s %s":                 "%s%%, %s %s gespart",
+		"%s%%, cost %s %s extra":            "%s%%, %s %s Mehrkosten",
+		"Converted from %s at 1 %s = %s %s": "Umgerechnet aus %s zu 1 %s = %s %s",
+
+		// Dashboard
+		"Live power":   "Live-Leistung",
+		"Cheapest %dh": "Günstigste %dh",
+		"Terminal too small (%d×%d), need at least %d×%d": "Terminal zu klein (%d×%d), mindestens %d×%d nötig",
+		"←/→ switch home":                                 "←/→ Zuhause wechseln",
+		"r refresh prices":                                "r Preise aktualisieren",
+		"q quit":                                          "q beenden",
+		"Waiting for live data…":                          "Warte auf Live-Daten…",
+		"Waiting for prices…":                             "Warte auf Preise…",
+		"Solar":                                           "Solar",
+		"Projected":                                       "Prognose",
+		"Less than %dh of prices left; tomorrow's arrive around 13:00": "Weniger als %dh Preise übrig; die von morgen kommen gegen 13:00",
+		"in %s":                      "in %s",
+		"min":                        "min",
+		"max":                        "max",
+		"Connecting…":                "Verbinde…",
+		"Stream error: %v":           "Fehler im Live-Stream: %v",
+		"Failed to fetch prices: %v": "Preise konnten nicht abgerufen werden: %v",
+		"today":                      "heute",
+		"tomorrow":                   "morgen",
+
+		// Alerts, notifications and diagnostics
+		"Alerts":                         "Warnungen",
+		"Alert":                          "Warnung",
+		"State":                          "Zustand",
+		"Detail":                         "Details",
+		"Push Notification":              "Push-Benachrichtigung",
+		"Push notification sent":         "Push-Benachrichtigung gesendet",
+		"Push notification was not sent": "Push-Benachrichtigung wurde nicht gesendet",
+		"to %s device(s)":                "an %s Gerät(e)",
+		"Successful":                     "Erfolgreich",
+		"Devices":                        "Geräte",
+		"Diagnostics":                    "Diagnose",
+		"Check":                          "Prüfung",
+		"Status":                         "Status",
+		"Some checks failed":             "Einige Prüfungen fehlgeschlagen",
+		"All checks passed":              "Alle Prüfungen bestanden",
+	},
+}
@@ -0,0 +1,35 @@
+package i18n
+
+// dateNames translates the English day and month names of Go time layouts
+var dateNames = map[string]map[string]string{
+	Norwegian: {
+		"Monday": "mandag", "Tuesday": "tirsdag", "Wednesday": "onsdag", "Thursday": "torsdag",
+		"Friday": "fredag", "Saturday": "lørdag", "Sunday": "søndag",
+		"Mon": "man", "Tue": "tir", "Wed": "ons", "Thu": "tor", "Fri": "fre", "Sat": "lør", "Sun": "søn",
+		"January": "januar", "February": "februar", "March": "mars", "April": "april",
+		"May": "mai", "June": "juni", "July": "juli", "August": "august",
+		"September": "september", "October": "oktober", "November": "november", "December": "desember",
+		"Jan": "jan", "Feb": "feb", "Mar": "mar", "Apr": "apr", "Jun": "jun", "Jul": "jul",
+		"Aug": "aug", "Sep": "sep", "Oct": "okt", "Nov": "nov", "Dec": "des",
+	},
+	Swedish: {
+		"Monday": "måndag", "Tuesday": "tisdag", "Wednesday": "onsdag", "Thursday": "torsdag",
+		"Friday": "fredag", "Saturday": "lördag", "Sunday": "söndag",
+		"Mon": "mån", "Tue": "tis", "Wed": "ons", "Thu": "tor", "Fri": "fre", "Sat": "lör", "Sun": "sön",
+		"January": "januari", "February": "februari", "March": "mars", "April": "april",
+		"May": "maj", "June": "juni", "July": "juli", "August": "augusti",
+		"September": "september", "October": "oktober", "November": "november", "December": "december",
+		"Jan": "jan", "Feb": "feb", "Mar": "mar", "Apr": "apr", "Jun": "jun", "Jul": "jul",
+		"Aug": "aug", "Sep": "sep", "Oct": "okt", "Nov": "nov", "Dec": "dec",
+	},
+	German: {
+		"Monday": "Montag", "Tuesday": "Dienstag", "Wednesday": "Mittwoch", "Thursday": "Donnerstag",
+		"Friday": "Freitag", "Saturday": "Samstag", "Sunday": "Sonntag",
+		"Mon": "Mo", "Tue": "Di", "Wed": "Mi", "Thu": "Do", "Fri": "Fr", "Sat": "Sa", "Sun": "So",
+		"January": "Januar", "February": "Februar", "March": "März", "April": "April",
+		"May": "Mai", "June": "Juni", "July": "Juli", "August": "August",
+		"September": "September", "October": "Oktober", "November": "November", "December": "Dezember",
+		"Jan": "Jan", "Feb": "Feb", "Mar": "Mär", "Apr": "Apr", "Jun": "Jun", "Jul": "Jul",
+		"Aug": "Aug", "Sep": "Sep", "Oct": "Okt", "Nov": "Nov", "Dec": "Dez",
+	},
+}
@@ -0,0 +1,130 @@
+// Package i18n translates the labels of human-readable output and formats
+// numbers and dates for a language.
+//
+// Messages are looked up by their English text, so untranslated messages
+// and a nil *Locale fall back to English.
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Supported languages
+const (
+	English   = "en"
+	Norwegian = "nb"
+	Swedish   = "sv"
+	German    = "de"
+)
+
+// Langs are the supported languages
+var Langs = []string{English, Norwegian, Swedish, German}
+
+// aliases maps other common codes to a supported language
+var aliases = map[string]string{
+	"no": Norwegian,
+	"nn": Norwegian,
+	"se": Swedish,
+}
+
+// Parse returns the supported language for a code such as "nb",
+// "nb_NO.UTF-8" or "de-AT"
+func Parse(code string) (string, error) {
+	lang := strings.ToLower(code)
+	if i := strings.IndexAny(lang, "_-."); i >= 0 {
+		lang = lang[:i]
+	}
+	if alias, ok := aliases[lang]; ok {
+		lang = alias
+	}
+	for _, l := range Langs {
+		if lang == l {
+			return l, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported language %q, valid values: %s", code, strings.Join(Langs, ", "))
+}
+
+// Locale translates messages and formats numbers and dates for a language.
+// A nil *Locale is English.
+type Locale struct {
+	lang     string
+	messages map[string]string
+	names    map[string]string
+	layouts  *strings.Replacer
+	comma    bool
+}
+
+// New returns the locale for a supported language; unknown languages get
+// English
+func New(lang string) *Locale {
+	l := &Locale{lang: lang, messages: catalogs[lang], names: dateNames[lang], comma: lang != English}
+	if lang == Norwegian || lang == German {
+		// "02. Jan" is the day of the month in Norwegian and German, and
+		// numeric dates are written dd.mm.yyyy
+		l.layouts = strings.NewReplacer("02 Jan", "02. Jan", "2006-01-02", "02.01.2006")
+	}
+	if l.messages == nil {
+		l.lang, l.comma = English, false
+	}
+	return l
+}
+
+// Lang returns the language code
+func (l *Locale) Lang() string {
+	if l == nil {
+		return English
+	}
+	return l.lang
+}
+
+// T translates msg, or returns it unchanged without a translation
+func (l *Locale) T(msg string) string {
+	if l == nil {
+		return msg
+	}
+	if translated, ok := l.messages[msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Sprintf translates format, then formats it like fmt.Sprintf
+func (l *Locale) Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(l.T(format), args...)
+}
+
+// Float formats v with a fmt verb such as "%.2f" or "%+.1f", using the
+// language's decimal separator
+func (l *Locale) Float(format string, v float64) string {
+	s := fmt.Sprintf(format, v)
+	if l != nil && l.comma {
+		s = strings.Replace(s, ".", ",", 1)
+	}
+	return s
+}
+
+// dateName matches English day and month names in formatted times
+var dateName = regexp.MustCompile(`\b(Monday|Tuesday|Wednesday|Thursday|Friday|Saturday|Sunday|Mon|Tue|Wed|Thu|Fri|Sat|Sun|` +
+	`January|February|March|April|May|June|July|August|September|October|November|December|` +
+	`Jan|Feb|Mar|Apr|Jun|Jul|Aug|Sep|Oct|Nov|Dec)\b`)
+
+// Date formats t with a time layout, with day and month names in the
+// language
+func (l *Locale) Date(t time.Time, layout string) string {
+	if l == nil || l.names == nil {
+		return t.Format(layout)
+	}
+	if l.layouts != nil {
+		layout = l.layouts.Replace(layout)
+	}
+	return dateName.ReplaceAllStringFunc(t.Format(layout), func(name string) string {
+		if translated, ok := l.names[name]; ok {
+			return translated
+		}
+		return name
+	})
+}
@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"en", English},
+		{"NB", Norwegian},
+		{"no", Norwegian},
+		{"nb_NO.UTF-8", Norwegian},
+		{"sv-SE", Swedish},
+		{"de-AT", German},
+	}
+	for _, tt := range tests {
+		if got, err := Parse(tt.code); err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %q, %v, want %q", tt.code, got, err, tt.want)
+		}
+	}
+
+	if _, err := Parse("fi"); err == nil {
+		t.Error("Parse(fi) should fail")
+	}
+}
+
+func TestLocale(t *testing.T) {
+	var nilLocale *Locale
+	if nilLocale.T("Today") != "Today" || nilLocale.Float("%.2f", 1.5) != "1.50" || nilLocale.Lang() != English {
+		t.Error("a nil Locale should be English")
+	}
+
+	nb := New(Norwegian)
+	if got := nb.T("Today"); got != "I dag" {
+		t.Errorf("T(Today) = %q, want I dag", got)
+	}
+	if got := nb.T("untranslated"); got != "untranslated" {
+		t.Errorf("T() = %q, want the message unchanged", got)
+	}
+	if got := nb.Sprintf("step %d", 3); got != "trinn 3" {
+		t.Errorf("Sprintf() = %q, want trinn 3", got)
+	}
+	if got := nb.Float("%+.1f", -12.34); got != "-12,3" {
+		t.Errorf("Float() = %q, want -12,3", got)
+	}
+	if got := New("fi"); got.Lang() != English || got.Float("%.1f", 1.5) != "1.5" {
+		t.Errorf("New(fi) = %q, want English", got.Lang())
+	}
+}
+
+func TestDate(t *testing.T) {
+	d := time.Date(2025, time.March, 3, 14, 0, 0, 0, time.UTC)
+	tests := []struct {
+		lang   string
+		layout string
+		want   string
+	}{
+		{English, "Mon 02 Jan 15:04", "Mon 03 Mar 14:00"},
+		{Norwegian, "Mon 02 Jan 15:04", "man 03. mar 14:00"},
+		{Swedish, "Mon 02 Jan", "mån 03 mar"},
+		{German, "Mon 02 Jan", "Mo 03. Mär"},
+		{German, "January 2006", "März 2025"},
+		{English, "2006-01-02 15:04", "2025-03-03 14:00"},
+		{Norwegian, "2006-01-02 15:04", "03.03.2025 14:00"},
+		{Swedish, "2006-01-02 15:04", "2025-03-03 14:00"},
+		{German, "2006-01-02", "03.03.2025"},
+	}
+	for _, tt := range tests {
+		if got := New(tt.lang).Date(d, tt.layout); got != tt.want {
+			t.Errorf("%s Date(%q) = %q, want %q", tt.lang, tt.layout, got, tt.want)
+		}
+	}
+}
+
+// verbs matches fmt verbs, which translations must keep in order
+var verbs = regexp.MustCompile(`%[-+ #0-9.]*[a-z%]`)
+
+func TestCatalogsAreComplete(t *testing.T) {
+	reference := catalogs[Norwegian]
+	for _, lang := range Langs[1:] {
+		catalog := catalogs[lang]
+		if catalog == nil {
+			t.Fatalf("no catalog for %s", lang)
+		}
+		for msg := range reference {
+			if _, ok := catalog[msg]; !ok {
+				t.Errorf("%s is missing %q", lang, msg)
+			}
+		}
+		for msg, translated := range catalog {
+			if !slices.Equal(verbs.FindAllString(msg, -1), verbs.FindAllString(translated, -1)) {
+				t.Errorf("%s translation %q does not keep the verbs of %q", lang, translated, msg)
+			}
+		}
+		if len(catalog) != len(reference) {
+			t.Errorf("%s has %d messages, %s has %d", lang, len(catalog), Norwegian, len(reference))
+		}
+		if dateNames[lang] == nil {
+			t.Errorf("no day and month names for %s", lang)
+		}
+	}
+}
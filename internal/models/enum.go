@@ -0,0 +1,123 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// enum backs the generated typed enums. Known values have codes 1..n in
+// their declared order, so typed values compare with < and >=; 0 means no
+// value. Unknown values from the API are interned with negative codes so
+// they round-trip unchanged instead of failing or being dropped.
+type enum struct {
+	typeName string
+	names    []string // names[code-1]
+
+	mu      sync.Mutex
+	unknown []string // unknown[-code-1]
+}
+
+func newEnum(typeName string, names ...string) *enum {
+	return &enum{typeName: typeName, names: names}
+}
+
+// labelOverrides replaces labels derived from value names where they read
+// poorly, keyed by "Type.VALUE"
+var labelOverrides = map[string]string{
+	"HeatingSource.AIR2AIR_HEATPUMP":   "Air-to-air heat pump",
+	"HeatingSource.AIR2WATER_HEATPUMP": "Air-to-water heat pump",
+	"HeatingSource.GROUND":             "Ground source heat pump",
+	"HeatingSource.DISTRICT_HEATING":   "District heating",
+	"HeatingSource.ELECTRIC_BOILER":    "Electric boiler",
+	"HomeType.ROWHOUSE":                "Row house",
+}
+
+func (e *enum) known(code int) bool {
+	return code >= 1 && code <= len(e.names)
+}
+
+func (e *enum) name(code int) string {
+	switch {
+	case e.known(code):
+		return e.names[code-1]
+	case code < 0:
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if -code <= len(e.unknown) {
+			return e.unknown[-code-1]
+		}
+	}
+	return ""
+}
+
+// label returns a human-readable name, e.g. "Very Cheap" for VERY_CHEAP
+func (e *enum) label(code int) string {
+	name := e.name(code)
+	switch {
+	case name == "":
+		return ""
+	case !e.known(code):
+		return fmt.Sprintf("Unknown (%s)", name)
+	}
+
+	if label, ok := labelOverrides[e.typeName+"."+name]; ok {
+		return label
+	}
+	words := strings.Split(strings.ToLower(name), "_")
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// code returns the code for name, interning unknown names
+func (e *enum) code(name string) int {
+	if name == "" {
+		return 0
+	}
+	for i, n := range e.names {
+		if n == name {
+			return i + 1
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, n := range e.unknown {
+		if n == name {
+			return -i - 1
+		}
+	}
+	e.unknown = append(e.unknown, name)
+	return -len(e.unknown)
+}
+
+// parse converts user input to a known code, case-insensitively
+func (e *enum) parse(s string) (int, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	for i, n := range e.names {
+		if n == name {
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid %s %q, valid values: %s", e.typeName, s, strings.Join(e.names, ", "))
+}
+
+func (e *enum) marshal(code int) ([]byte, error) {
+	return json.Marshal(e.name(code))
+}
+
+// unmarshal accepts a JSON string or null; anything else is an error
+func (e *enum) unmarshal(data []byte) (int, error) {
+	if string(data) == "null" {
+		return 0, nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return 0, fmt.Errorf("invalid %s %s: must be a string", e.typeName, data)
+	}
+	return e.code(name), nil
+}
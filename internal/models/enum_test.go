@@ -0,0 +1,81 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPriceLevel_Ordering(t *testing.T) {
+	if !(PriceLevelVeryCheap < PriceLevelCheap && PriceLevelCheap < PriceLevelNormal &&
+		PriceLevelNormal < PriceLevelExpensive && PriceLevelExpensive < PriceLevelVeryExpensive) {
+		t.Error("price levels should be ordered from VERY_CHEAP to VERY_EXPENSIVE")
+	}
+}
+
+func TestPriceLevel_JSONRoundTrip(t *testing.T) {
+	var p Price
+	if err := json.Unmarshal([]byte(`{"level": "CHEAP"}`), &p); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if p.Level != PriceLevelCheap || p.Level.Label() != "Cheap" {
+		t.Errorf("Level = %v (%s), want CHEAP", p.Level, p.Level.Label())
+	}
+
+	data, _ := json.Marshal(p.Level)
+	if string(data) != `"CHEAP"` {
+		t.Errorf("Marshal() = %s, want \"CHEAP\"", data)
+	}
+}
+
+func TestPriceLevel_UnknownPreserved(t *testing.T) {
+	var level PriceLevel
+	if err := json.Unmarshal([]byte(`"NEGATIVE"`), &level); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if level.Known() {
+		t.Error("NEGATIVE should not be a known level")
+	}
+	if level >= PriceLevelVeryCheap {
+		t.Error("unknown levels should not pass level filters")
+	}
+	if level.String() != "NEGATIVE" || level.Label() != "Unknown (NEGATIVE)" {
+		t.Errorf("String() = %q, Label() = %q", level.String(), level.Label())
+	}
+
+	data, _ := json.Marshal(level)
+	if string(data) != `"NEGATIVE"` {
+		t.Errorf("Marshal() = %s, want the unknown value back", data)
+	}
+
+	var again PriceLevel
+	json.Unmarshal([]byte(`"NEGATIVE"`), &again)
+	if again != level {
+		t.Errorf("the same unknown value decoded to %d and %d", level, again)
+	}
+}
+
+func TestPriceLevel_UnmarshalValidation(t *testing.T) {
+	var level PriceLevel
+	if err := json.Unmarshal([]byte(`3`), &level); err == nil {
+		t.Error("Unmarshal() should reject non-string levels")
+	}
+	if err := json.Unmarshal([]byte(`null`), &level); err != nil || level != 0 {
+		t.Errorf("null = %d, %v; want zero level", level, err)
+	}
+}
+
+func TestParseEnums(t *testing.T) {
+	if level, err := ParsePriceLevel("very_cheap"); err != nil || level != PriceLevelVeryCheap {
+		t.Errorf("ParsePriceLevel(very_cheap) = %v, %v", level, err)
+	}
+	if _, err := ParsePriceLevel("free"); err == nil {
+		t.Error("ParsePriceLevel(free) should fail")
+	}
+	if source, err := ParseHeatingSource("air2air_heatpump"); err != nil || source.Label() != "Air-to-air heat pump" {
+		t.Errorf("ParseHeatingSource() = %q, %v", source.Label(), err)
+	}
+	if homeType, err := ParseHomeType("House"); err != nil || homeType != HomeTypeHouse {
+		t.Errorf("ParseHomeType(House) = %v, %v", homeType, err)
+	}
+}
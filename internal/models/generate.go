@@ -0,0 +1,6 @@
+package models
+
+// Schema-backed types and enums are generated from the snapshot in
+// internal/schema; refresh it with `powerctl schema dump --dir internal/schema`
+// and regenerate with `go generate ./...`.
+//go:generate go run ../../cmd/schemagen -config schemagen.yaml
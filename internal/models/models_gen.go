@@ -0,0 +1,444 @@
+// Code generated by schemagen from the Tibber schema snapshot. DO NOT EDIT.
+
+package models
+
+import "time"
+
+// PriceLevel is the PriceLevel schema enum. Price level based on trailing price average (3 days for hourly values and 30 days for daily values)
+type PriceLevel int
+
+// PriceLevel values, in ascending order
+const (
+	PriceLevelVeryCheap PriceLevel = iota + 1
+	PriceLevelCheap
+	PriceLevelNormal
+	PriceLevelExpensive
+	PriceLevelVeryExpensive
+)
+
+var priceLevelEnum = newEnum("PriceLevel", "VERY_CHEAP", "CHEAP", "NORMAL", "EXPENSIVE", "VERY_EXPENSIVE")
+
+// PriceLevels are all known PriceLevel values in ascending order
+var PriceLevels = []PriceLevel{PriceLevelVeryCheap, PriceLevelCheap, PriceLevelNormal, PriceLevelExpensive, PriceLevelVeryExpensive}
+
+// PriceLevelNames returns the names of all known PriceLevel values
+func PriceLevelNames() []string { return append([]string{}, priceLevelEnum.names...) }
+
+// ParsePriceLevel parses a PriceLevel name case-insensitively, rejecting unknown values
+func ParsePriceLevel(s string) (PriceLevel, error) {
+	code, err := priceLevelEnum.parse(s)
+	return PriceLevel(code), err
+}
+
+// String returns the schema name of the value, including unknown values
+func (v PriceLevel) String() string { return priceLevelEnum.name(int(v)) }
+
+// Label returns a human-readable name
+func (v PriceLevel) Label() string { return priceLevelEnum.label(int(v)) }
+
+// Known reports whether v is a value from the schema snapshot
+func (v PriceLevel) Known() bool { return priceLevelEnum.known(int(v)) }
+
+// MarshalJSON encodes the schema name
+func (v PriceLevel) MarshalJSON() ([]byte, error) { return priceLevelEnum.marshal(int(v)) }
+
+// UnmarshalJSON decodes a schema name, preserving unknown values
+func (v *PriceLevel) UnmarshalJSON(data []byte) error {
+	code, err := priceLevelEnum.unmarshal(data)
+	*v = PriceLevel(code)
+	return err
+}
+
+// PriceRatingLevel is the PriceRatingLevel schema enum. Price level compared to the recent average
+type PriceRatingLevel int
+
+// PriceRatingLevel values, in ascending order
+const (
+	PriceRatingLevelLow PriceRatingLevel = iota + 1
+	PriceRatingLevelNormal
+	PriceRatingLevelHigh
+)
+
+var priceRatingLevelEnum = newEnum("PriceRatingLevel", "LOW", "NORMAL", "HIGH")
+
+// PriceRatingLevels are all known PriceRatingLevel values in ascending order
+var PriceRatingLevels = []PriceRatingLevel{PriceRatingLevelLow, PriceRatingLevelNormal, PriceRatingLevelHigh}
+
+// PriceRatingLevelNames returns the names of all known PriceRatingLevel values
+func PriceRatingLevelNames() []string { return append([]string{}, priceRatingLevelEnum.names...) }
+
+// ParsePriceRatingLevel parses a PriceRatingLevel name case-insensitively, rejecting unknown values
+func ParsePriceRatingLevel(s string) (PriceRatingLevel, error) {
+	code, err := priceRatingLevelEnum.parse(s)
+	return PriceRatingLevel(code), err
+}
+
+// String returns the schema name of the value, including unknown values
+func (v PriceRatingLevel) String() string { return priceRatingLevelEnum.name(int(v)) }
+
+// Label returns a human-readable name
+func (v PriceRatingLevel) Label() string { return priceRatingLevelEnum.label(int(v)) }
+
+// Known reports whether v is a value from the schema snapshot
+func (v PriceRatingLevel) Known() bool { return priceRatingLevelEnum.known(int(v)) }
+
+// MarshalJSON encodes the schema name
+func (v PriceRatingLevel) MarshalJSON() ([]byte, error) { return priceRatingLevelEnum.marshal(int(v)) }
+
+// UnmarshalJSON decodes a schema name, preserving unknown values
+func (v *PriceRatingLevel) UnmarshalJSON(data []byte) error {
+	code, err := priceRatingLevelEnum.unmarshal(data)
+	*v = PriceRatingLevel(code)
+	return err
+}
+
+// HomeType is the HomeType schema enum
+type HomeType int
+
+// HomeType values, in ascending order
+const (
+	HomeTypeApartment HomeType = iota + 1
+	HomeTypeRowhouse
+	HomeTypeHouse
+	HomeTypeCottage
+)
+
+var homeTypeEnum = newEnum("HomeType", "APARTMENT", "ROWHOUSE", "HOUSE", "COTTAGE")
+
+// HomeTypes are all known HomeType values in ascending order
+var HomeTypes = []HomeType{HomeTypeApartment, HomeTypeRowhouse, HomeTypeHouse, HomeTypeCottage}
+
+// HomeTypeNames returns the names of all known HomeType values
+func HomeTypeNames() []string { return append([]string{}, homeTypeEnum.names...) }
+
+// ParseHomeType parses a HomeType name case-insensitively, rejecting unknown values
+func ParseHomeType(s string) (HomeType, error) {
+	code, err := homeTypeEnum.parse(s)
+	return HomeType(code), err
+}
+
+// String returns the schema name of the value, including unknown values
+func (v HomeType) String() string { return homeTypeEnum.name(int(v)) }
+
+// Label returns a human-readable name
+func (v HomeType) Label() string { return homeTypeEnum.label(int(v)) }
+
+// Known reports whether v is a value from the schema snapshot
+func (v HomeType) Known() bool { return homeTypeEnum.known(int(v)) }
+
+// MarshalJSON encodes the schema name
+func (v HomeType) MarshalJSON() ([]byte, error) { return homeTypeEnum.marshal(int(v)) }
+
+// UnmarshalJSON decodes a schema name, preserving unknown values
+func (v *HomeType) UnmarshalJSON(data []byte) error {
+	code, err := homeTypeEnum.unmarshal(data)
+	*v = HomeType(code)
+	return err
+}
+
+// HeatingSource is the HeatingSource schema enum
+type HeatingSource int
+
+// HeatingSource values, in ascending order
+const (
+	HeatingSourceAir2airHeatpump HeatingSource = iota + 1
+	HeatingSourceElectricity
+	HeatingSourceGround
+	HeatingSourceDistrictHeating
+	HeatingSourceElectricBoiler
+	HeatingSourceAir2waterHeatpump
+	HeatingSourceOther
+)
+
+var heatingSourceEnum = newEnum("HeatingSource", "AIR2AIR_HEATPUMP", "ELECTRICITY", "GROUND", "DISTRICT_HEATING", "ELECTRIC_BOILER", "AIR2WATER_HEATPUMP", "OTHER")
+
+// HeatingSources are all known HeatingSource values in ascending order
+var HeatingSources = []HeatingSource{HeatingSourceAir2airHeatpump, HeatingSourceElectricity, HeatingSourceGround, HeatingSourceDistrictHeating, HeatingSourceElectricBoiler, HeatingSourceAir2waterHeatpump, HeatingSourceOther}
+
+// HeatingSourceNames returns the names of all known HeatingSource values
+func HeatingSourceNames() []string { return append([]string{}, heatingSourceEnum.names...) }
+
+// ParseHeatingSource parses a HeatingSource name case-insensitively, rejecting unknown values
+func ParseHeatingSource(s string) (HeatingSource, error) {
+	code, err := heatingSourceEnum.parse(s)
+	return HeatingSource(code), err
+}
+
+// String returns the schema name of the value, including unknown values
+func (v HeatingSource) String() string { return heatingSourceEnum.name(int(v)) }
+
+// Label returns a human-readable name
+func (v HeatingSource) Label() string { return heatingSourceEnum.label(int(v)) }
+
+// Known reports whether v is a value from the schema snapshot
+func (v HeatingSource) Known() bool { return heatingSourceEnum.known(int(v)) }
+
+// MarshalJSON encodes the schema name
+func (v HeatingSource) MarshalJSON() ([]byte, error) { return heatingSourceEnum.marshal(int(v)) }
+
+// UnmarshalJSON decodes a schema name, preserving unknown values
+func (v *HeatingSource) UnmarshalJSON(data []byte) error {
+	code, err := heatingSourceEnum.unmarshal(data)
+	*v = HeatingSource(code)
+	return err
+}
+
+// EnergyResolution values
+const (
+	EnergyResolutionHourly  = "HOURLY"
+	EnergyResolutionDaily   = "DAILY"
+	EnergyResolutionWeekly  = "WEEKLY"
+	EnergyResolutionMonthly = "MONTHLY"
+	EnergyResolutionAnnual  = "ANNUAL"
+)
+
+// EnergyResolutions are all EnergyResolution values in schema order
+var EnergyResolutions = []string{EnergyResolutionHourly, EnergyResolutionDaily, EnergyResolutionWeekly, EnergyResolutionMonthly, EnergyResolutionAnnual}
+
+// PriceResolution values
+const (
+	PriceResolutionHourly = "HOURLY"
+	PriceResolutionDaily  = "DAILY"
+)
+
+// PriceResolutions are all PriceResolution values in schema order
+var PriceResolutions = []string{PriceResolutionHourly, PriceResolutionDaily}
+
+// AppScreen values
+const (
+	AppScreenHome            = "HOME"
+	AppScreenReports         = "REPORTS"
+	AppScreenConsumption     = "CONSUMPTION"
+	AppScreenComparison      = "COMPARISON"
+	AppScreenDisaggregation  = "DISAGGREGATION"
+	AppScreenHomeProfile     = "HOME_PROFILE"
+	AppScreenCustomerProfile = "CUSTOMER_PROFILE"
+	AppScreenMeterReading    = "METER_READING"
+	AppScreenNotifications   = "NOTIFICATIONS"
+	AppScreenInvoices        = "INVOICES"
+)
+
+// AppScreens are all AppScreen values in schema order
+var AppScreens = []string{AppScreenHome, AppScreenReports, AppScreenConsumption, AppScreenComparison, AppScreenDisaggregation, AppScreenHomeProfile, AppScreenCustomerProfile, AppScreenMeterReading, AppScreenNotifications, AppScreenInvoices}
+
+// Home represents a Tibber home/residence
+type Home struct {
+	ID string `json:"id"`
+	// The nickname given to the home by the user
+	AppNickname string `json:"appNickname"`
+	// The size of the home in square meters
+	Size int `json:"size"`
+	// The type of home.
+	Type HomeType `json:"type"`
+	// The number of people living in the home
+	NumberOfResidents int `json:"numberOfResidents"`
+	// The primary form of heating in the household
+	PrimaryHeatingSource HeatingSource `json:"primaryHeatingSource"`
+	// Whether the home has a ventilation system
+	HasVentilationSystem bool `json:"hasVentilationSystem"`
+	// The main fuse size
+	MainFuseSize int `json:"mainFuseSize"`
+	// The time zone the home resides in
+	TimeZone string   `json:"timeZone"`
+	Address  Address  `json:"address"`
+	Features Features `json:"features"`
+}
+
+// Address represents a physical address
+type Address struct {
+	Address1   string `json:"address1"`
+	Address2   string `json:"address2"`
+	Address3   string `json:"address3"`
+	PostalCode string `json:"postalCode"`
+	City       string `json:"city"`
+	Country    string `json:"country"`
+	Latitude   string `json:"latitude"`
+	Longitude  string `json:"longitude"`
+}
+
+// Features represents home features
+type Features struct {
+	// Whether Tibber server-side real-time data is available
+	RealTimeConsumptionEnabled bool `json:"realTimeConsumptionEnabled"`
+}
+
+// Subscription contains price info
+type Subscription struct {
+	// The current status of the subscription
+	Status string `json:"status,omitempty"`
+	// Price information related to the subscription
+	PriceInfo *PriceInfo `json:"priceInfo"`
+}
+
+// PriceInfo contains current and upcoming prices
+type PriceInfo struct {
+	// The energy price right now
+	Current *Price `json:"current"`
+	// The hourly prices of the current day
+	Today []Price `json:"today"`
+	// The hourly prices of the upcoming day
+	Tomorrow []Price `json:"tomorrow"`
+	// Set when prices were converted to another currency
+	Conversion *Conversion `json:"conversion,omitempty"`
+}
+
+// Price represents an electricity price point
+type Price struct {
+	// The total price (energy + taxes)
+	Total float64 `json:"total"`
+	// Nordpool spot price
+	Energy float64 `json:"energy"`
+	// The tax part of the price (guarantee of origin certificate, energy tax (Sweden only) and VAT)
+	Tax float64 `json:"tax"`
+	// The start time of the price
+	StartsAt time.Time `json:"startsAt"`
+	// The price level compared to recent price values
+	Level PriceLevel `json:"level"`
+	// The price currency
+	Currency string `json:"currency"`
+	// Grid energy charge incl. VAT, added to the effective price by the configured tariff
+	Grid float64 `json:"grid,omitempty"`
+	// Government fees incl. VAT, added to the effective price by the configured tariff
+	Fees float64 `json:"fees,omitempty"`
+	// Estimated state subsidy incl. VAT, subtracted from the effective price
+	Subsidy float64 `json:"subsidy,omitempty"`
+	// Total plus the grid charge and fees, less the subsidy; nil unless a tariff or subsidy applies
+	Effective *float64 `json:"effective,omitempty"`
+}
+
+// PriceRating compares prices with their trailing averages over three periods
+type PriceRating struct {
+	// The different 'high'/'low' price breakpoints (market dependent)
+	ThresholdPercentages *PriceRatingThresholds `json:"thresholdPercentages"`
+	// The hourly prices of today, the previous 7 days, and tomorrow
+	Hourly *PriceRatingPeriod `json:"hourly"`
+	// The daily prices of today and the previous 30 days
+	Daily *PriceRatingPeriod `json:"daily"`
+	// The monthly prices of this month and the previous 31 months
+	Monthly *PriceRatingPeriod `json:"monthly"`
+}
+
+// PriceRatingThresholds holds the differences (in percent) at which prices count as high or low
+type PriceRatingThresholds struct {
+	// The percentage difference when the price is considered to be 'high' (market dependent)
+	High float64 `json:"high"`
+	// The percentage difference when the price is considered to be 'low' (market dependent)
+	Low float64 `json:"low"`
+}
+
+// PriceRatingPeriod holds the price rating entries of one period
+type PriceRatingPeriod struct {
+	// Lowest Nordpool spot price over the time period
+	MinEnergy float64 `json:"minEnergy"`
+	// Highest Nordpool spot price over the time period
+	MaxEnergy float64 `json:"maxEnergy"`
+	// Lowest total price (incl. tax) over the time period
+	MinTotal float64 `json:"minTotal"`
+	// Highest total price (incl. tax) over the time period
+	MaxTotal float64 `json:"maxTotal"`
+	// The price currency
+	Currency string `json:"currency"`
+	// The individual price entries aggregated by hourly/daily/monthly values
+	Entries []PriceRatingEntry `json:"entries"`
+}
+
+// PriceRatingEntry is an aggregated price compared to its trailing average
+type PriceRatingEntry struct {
+	// The start time of the price
+	Time time.Time `json:"time"`
+	// Nordpool spot price
+	Energy float64 `json:"energy"`
+	// The total price (incl. tax)
+	Total float64 `json:"total"`
+	// The tax part of the price (guarantee of origin certificate, energy tax (Sweden only) and VAT)
+	Tax float64 `json:"tax"`
+	// The percentage difference compared to the trailing price average (1 day for 'hourly', 30 days for 'daily' and 32 months for 'monthly')
+	Difference float64 `json:"difference"`
+	// The price level compared to recent price values (calculated using 'difference' and 'priceRating.thresholdPercentages')
+	Level PriceRatingLevel `json:"level"`
+}
+
+// Consumption is the energy used and its cost over one period
+type Consumption struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// Total cost of the consumption
+	Cost float64 `json:"cost"`
+	// The cost per kWh
+	UnitPrice float64 `json:"unitPrice"`
+	// The VAT part of the unit price
+	UnitPriceVAT float64 `json:"unitPriceVAT"`
+	// kWh consumed
+	Consumption     float64 `json:"consumption"`
+	ConsumptionUnit string  `json:"consumptionUnit"`
+	// The cost currency
+	Currency string `json:"currency"`
+}
+
+// LiveMeasurement represents real-time power data from Pulse
+type LiveMeasurement struct {
+	// When usage occurred
+	Timestamp time.Time `json:"timestamp"`
+	// Consumption at the moment (Watt)
+	Power float64 `json:"power"`
+	// Net production (A-) at the moment (Watt)
+	PowerProduction float64 `json:"powerProduction"`
+	// kWh consumed since midnight
+	AccumulatedConsumption float64 `json:"accumulatedConsumption"`
+	// kWh consumed since since last hour shift
+	AccumulatedConsumptionLastHour float64 `json:"accumulatedConsumptionLastHour"`
+	// net kWh produced since midnight
+	AccumulatedProduction float64 `json:"accumulatedProduction"`
+	// Accumulated cost since midnight; requires active Tibber power deal; includes VAT (where applicable)
+	AccumulatedCost float64 `json:"accumulatedCost"`
+	// Accumulated reward since midnight; requires active Tibber power deal
+	AccumulatedReward float64 `json:"accumulatedReward"`
+	// Min consumption since midnight (Watt)
+	MinPower float64 `json:"minPower"`
+	// Peak consumption since midnight  (Watt)
+	MaxPower float64 `json:"maxPower"`
+	// Average consumption since midnight (Watt)
+	AveragePower float64 `json:"averagePower"`
+	// Voltage on phase 1
+	VoltagePhase1 float64 `json:"voltagePhase1"`
+	// Voltage on phase 2
+	VoltagePhase2 float64 `json:"voltagePhase2"`
+	// Voltage on phase 3
+	VoltagePhase3 float64 `json:"voltagePhase3"`
+	// Current on L1
+	CurrentL1 float64 `json:"currentL1"`
+	// Current on L2
+	CurrentL2 float64 `json:"currentL2"`
+	// Current on L3
+	CurrentL3 float64 `json:"currentL3"`
+	// Currency of displayed cost; requires active Tibber power deal
+	Currency string `json:"currency"`
+	// Set when costs were converted to another currency
+	Conversion *Conversion `json:"conversion,omitempty"`
+}
+
+// UpdateHomeInput is the input to the updateHome mutation. Nil fields are left unchanged.
+type UpdateHomeInput struct {
+	HomeID               string         `json:"homeId"`
+	AppNickname          *string        `json:"appNickname,omitempty"`
+	Size                 *int           `json:"size,omitempty"`
+	Type                 *HomeType      `json:"type,omitempty"`
+	NumberOfResidents    *int           `json:"numberOfResidents,omitempty"`
+	PrimaryHeatingSource *HeatingSource `json:"primaryHeatingSource,omitempty"`
+	HasVentilationSystem *bool          `json:"hasVentilationSystem,omitempty"`
+	// The main fuse size
+	MainFuseSize *int `json:"mainFuseSize,omitempty"`
+}
+
+// PushNotificationInput is the input to the sendPushNotification mutation
+type PushNotificationInput struct {
+	Title        string `json:"title,omitempty"`
+	Message      string `json:"message"`
+	ScreenToOpen string `json:"screenToOpen,omitempty"`
+}
+
+// PushNotificationResult is the result of the sendPushNotification mutation
+type PushNotificationResult struct {
+	Successful              bool `json:"successful"`
+	PushedToNumberOfDevices int  `json:"pushedToNumberOfDevices"`
+}
@@ -1,74 +1,370 @@
 package models
 
-import "time"
-
-// Home represents a Tibber home/residence
-type Home struct {
-	ID                   string   `json:"id"`
-	AppNickname          string   `json:"appNickname"`
-	Size                 int      `json:"size"`
-	Type                 string   `json:"type"`
-	NumberOfResidents    int      `json:"numberOfResidents"`
-	PrimaryHeatingSource string   `json:"primaryHeatingSource"`
-	HasVentilationSystem bool     `json:"hasVentilationSystem"`
-	MainFuseSize         int      `json:"mainFuseSize"`
-	Address              Address  `json:"address"`
-	Features             Features `json:"features"`
-}
-
-// Address represents a physical address
-type Address struct {
-	Address1   string `json:"address1"`
-	Address2   string `json:"address2"`
-	Address3   string `json:"address3"`
-	PostalCode string `json:"postalCode"`
-	City       string `json:"city"`
-	Country    string `json:"country"`
-	Latitude   string `json:"latitude"`
-	Longitude  string `json:"longitude"`
-}
-
-// Features represents home features
-type Features struct {
-	RealTimeConsumptionEnabled bool `json:"realTimeConsumptionEnabled"`
-}
-
-// Price represents an electricity price point
-type Price struct {
-	Total    float64   `json:"total"`
-	Energy   float64   `json:"energy"`
-	Tax      float64   `json:"tax"`
-	StartsAt time.Time `json:"startsAt"`
-	Level    string    `json:"level"`
-	Currency string    `json:"currency"`
-}
-
-// PriceInfo contains current and upcoming prices
-type PriceInfo struct {
-	Current  *Price  `json:"current"`
-	Today    []Price `json:"today"`
-	Tomorrow []Price `json:"tomorrow"`
-}
-
-// LiveMeasurement represents real-time power data from Pulse
-type LiveMeasurement struct {
-	Timestamp              time.Time `json:"timestamp"`
-	Power                  float64   `json:"power"`
-	PowerProduction        float64   `json:"powerProduction"`
-	AccumulatedConsumption float64   `json:"accumulatedConsumption"`
-	AccumulatedProduction  float64   `json:"accumulatedProduction"`
-	AccumulatedCost        float64   `json:"accumulatedCost"`
-	AccumulatedReward      float64   `json:"accumulatedReward"`
-	MinPower               float64   `json:"minPower"`
-	MaxPower               float64   `json:"maxPower"`
-	AveragePower           float64   `json:"averagePower"`
-	VoltagePhase1          float64   `json:"voltagePhase1"`
-	VoltagePhase2          float64   `json:"voltagePhase2"`
-	VoltagePhase3          float64   `json:"voltagePhase3"`
-	CurrentL1              float64   `json:"currentL1"`
-	CurrentL2              float64   `json:"currentL2"`
-	CurrentL3              float64   `json:"currentL3"`
-	Currency               string    `json:"currency"`
+import (
+	"fmt"
+	"time"
+)
+
+// At returns the price slot covering t, or nil if t is outside today and
+// tomorrow. A slot lasts until the next one starts; the last lasts an hour.
+func (pi *PriceInfo) At(t time.Time) *Price {
+	slots := append(append([]Price{}, pi.Today...), pi.Tomorrow...)
+	for i := range slots {
+		end := slots[i].StartsAt.Add(time.Hour)
+		if i+1 < len(slots) {
+			end = slots[i+1].StartsAt
+		}
+		if !t.Before(slots[i].StartsAt) && t.Before(end) {
+			return &slots[i]
+		}
+	}
+	return nil
+}
+
+// Conversion records a currency conversion, 1 From = Rate To
+type Conversion struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Rate   float64 `json:"rate"`
+	Date   string  `json:"date,omitempty"`
+	Source string  `json:"source,omitempty"`
+}
+
+// Original converts a converted amount back to the From currency
+func (c *Conversion) Original(amount float64) float64 {
+	return amount / c.Rate
+}
+
+// Price components that can be displayed
+const (
+	PriceTotal  = "total"
+	PriceEnergy = "energy"
+	PriceTax    = "tax"
+)
+
+// PriceComponents are the displayable price components
+var PriceComponents = []string{PriceTotal, PriceEnergy, PriceTax}
+
+// Component returns the value of a price component, or Payable for an
+// unknown name
+func (p *Price) Component(name string) float64 {
+	switch name {
+	case PriceEnergy:
+		return p.Energy
+	case PriceTax:
+		return p.Tax
+	default:
+		return p.Payable()
+	}
+}
+
+// Adjusted reports whether a tariff or subsidy applies to the price
+func (p *Price) Adjusted() bool {
+	return p.Grid != 0 || p.Fees != 0 || p.Subsidy != 0
+}
+
+// SetEffective sets Effective from Total, the grid charge, fees and
+// subsidy, leaving Total as Tibber reported it
+func (p *Price) SetEffective() {
+	effective := p.Total + p.Grid + p.Fees - p.Subsidy
+	p.Effective = &effective
+}
+
+// Payable is the price actually paid per kWh: Effective when a tariff or
+// subsidy applies, otherwise Total
+func (p *Price) Payable() float64 {
+	if p.Effective != nil {
+		return *p.Effective
+	}
+	return p.Total
+}
+
+// Other is the part of the payable price that is neither energy nor tax:
+// the grid tariff and fees less any subsidy
+func (p *Price) Other() float64 {
+	return p.Grid + p.Fees - p.Subsidy
+}
+
+// Component returns the value of a price component, or Total for an
+// unknown name
+func (e *PriceRatingEntry) Component(name string) float64 {
+	switch name {
+	case PriceEnergy:
+		return e.Energy
+	case PriceTax:
+		return e.Tax
+	default:
+		return e.Total
+	}
+}
+
+// PriceRangeReport summarizes historical prices over a date range
+type PriceRangeReport struct {
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	Resolution string    `json:"resolution"`
+	Currency   string    `json:"currency"`
+	Count      int       `json:"count"`
+
+	// Average, Min and Max are total prices per kWh over the whole range
+	Average float64 `json:"average"`
+	Min     *Price  `json:"min,omitempty"`
+	Max     *Price  `json:"max,omitempty"`
+
+	Days []PriceDay `json:"days"`
+
+	Conversion *Conversion `json:"conversion,omitempty"`
+}
+
+// PriceDay aggregates the prices of one day
+type PriceDay struct {
+	Date    string  `json:"date"`
+	Count   int     `json:"count"`
+	Average float64 `json:"average"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+}
+
+// PriceBreakdown splits today's and tomorrow's prices into energy and tax
+type PriceBreakdown struct {
+	Currency string         `json:"currency"`
+	Today    []Price        `json:"today"`
+	Tomorrow []Price        `json:"tomorrow"`
+	Days     []BreakdownDay `json:"days"`
+
+	Conversion *Conversion `json:"conversion,omitempty"`
+}
+
+// BreakdownDay averages the price components of one day. Other is the grid
+// tariff and fees less any subsidy, zero unless they are configured.
+type BreakdownDay struct {
+	Date    string  `json:"date"`
+	Count   int     `json:"count"`
+	Average float64 `json:"average"`
+	Energy  float64 `json:"energy"`
+	Tax     float64 `json:"tax"`
+	Other   float64 `json:"other"`
+
+	// TaxShare is the percentage of Average that is tax
+	TaxShare float64 `json:"taxShare"`
+}
+
+// CostReport reconciles a month's consumption and cost with spot prices
+type CostReport struct {
+	Month    string  `json:"month"`
+	Currency string  `json:"currency"`
+	Hours    int     `json:"hours"`
+	Energy   float64 `json:"energy"`
+	Cost     float64 `json:"cost"`
+
+	// GridCost is the part of Cost from the tariff's per-kWh charges.
+	// FixedFee is the tariff's monthly fee and Total is Cost plus FixedFee.
+	GridCost float64 `json:"gridCost,omitempty"`
+	FixedFee float64 `json:"fixedFee,omitempty"`
+	Total    float64 `json:"total"`
+
+	// Subsidy is the estimated state subsidy already deducted from Cost
+	Subsidy float64 `json:"subsidy,omitempty"`
+
+	// AveragePrice is the volume-weighted price paid (Cost / Energy);
+	// SpotAverage is the plain mean of the month's hourly prices
+	AveragePrice float64 `json:"averagePrice"`
+	SpotAverage  float64 `json:"spotAverage"`
+
+	// TimingPercent is how much AveragePrice differs from SpotAverage
+	// (negative is better) and TimingSavings what that saved in Currency
+	TimingPercent float64 `json:"timingPercent"`
+	TimingSavings float64 `json:"timingSavings"`
+
+	TopHours []CostHour       `json:"topHours"`
+	Levels   []LevelBreakdown `json:"levels"`
+
+	Conversion *Conversion `json:"conversion,omitempty"`
+}
+
+// CostHour is one hour of consumption and its cost
+type CostHour struct {
+	Start  time.Time  `json:"start"`
+	Energy float64    `json:"energy"`
+	Price  float64    `json:"price"`
+	Cost   float64    `json:"cost"`
+	Level  PriceLevel `json:"level"`
+}
+
+// LevelBreakdown totals consumption and cost for one price level
+type LevelBreakdown struct {
+	Level  PriceLevel `json:"level"`
+	Hours  int        `json:"hours"`
+	Energy float64    `json:"energy"`
+	Cost   float64    `json:"cost"`
+
+	// Share is the percentage of the month's energy used at this level
+	Share float64 `json:"share"`
+}
+
+// Price rating periods
+const (
+	RatingHourly  = "hourly"
+	RatingDaily   = "daily"
+	RatingMonthly = "monthly"
+)
+
+// RatingPeriods are the valid price rating periods
+var RatingPeriods = []string{RatingHourly, RatingDaily, RatingMonthly}
+
+// PriceRatingReport compares the current hour, day or month with the
+// average of the entries before it
+type PriceRatingReport struct {
+	Period     string                `json:"period"`
+	Currency   string                `json:"currency"`
+	Thresholds PriceRatingThresholds `json:"thresholdPercentages"`
+
+	// Current is the entry covering now; Average is the mean total of the
+	// entries before it
+	Current *PriceRatingEntry  `json:"current,omitempty"`
+	Average float64            `json:"average"`
+	Entries []PriceRatingEntry `json:"entries"`
+}
+
+// Report builds the comparison for period (RatingDaily etc.) at now
+func (r *PriceRating) Report(period string, now time.Time) (*PriceRatingReport, error) {
+	var p *PriceRatingPeriod
+	switch period {
+	case RatingHourly:
+		p = r.Hourly
+	case RatingDaily:
+		p = r.Daily
+	case RatingMonthly:
+		p = r.Monthly
+	default:
+		return nil, fmt.Errorf("invalid period %q", period)
+	}
+	if p == nil {
+		return nil, fmt.Errorf("no %s price rating available", period)
+	}
+
+	report := &PriceRatingReport{Period: period, Currency: p.Currency, Entries: p.Entries}
+	if r.ThresholdPercentages != nil {
+		report.Thresholds = *r.ThresholdPercentages
+	}
+
+	// Entries are oldest first; hourly ones include tomorrow once published
+	current := -1
+	for i, e := range p.Entries {
+		if !e.Time.After(now) {
+			current = i
+		}
+	}
+	if current < 0 {
+		return report, nil
+	}
+
+	report.Current = &p.Entries[current]
+	if current > 0 {
+		sum := 0.0
+		for _, e := range p.Entries[:current] {
+			sum += e.Total
+		}
+		report.Average = sum / float64(current)
+	}
+	return report, nil
+}
+
+// PowerSample is a power reading at a point in time
+type PowerSample struct {
+	Power     float64   `json:"power"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// LiveStats summarizes recent live measurements. Type is always "stats" so
+// JSON consumers can tell them from measurements and alerts.
+type LiveStats struct {
+	Type    string  `json:"type"`
+	Samples int     `json:"samples"`
+	Avg1m   float64 `json:"avg1m"`
+	Avg5m   float64 `json:"avg5m"`
+	Avg15m  float64 `json:"avg15m"`
+
+	// Peak and Min are the extremes seen since the stream started
+	Peak PowerSample `json:"peak"`
+	Min  PowerSample `json:"min"`
+
+	// Sparkline holds bucketed average power, oldest first
+	Sparkline    []float64 `json:"sparkline"`
+	SparkMinutes int       `json:"sparkMinutes"`
+
+	// Current-hour energy so far and projected at the current rate
+	HourEnergy          float64 `json:"hourEnergy"`
+	ProjectedHourEnergy float64 `json:"projectedHourEnergy"`
+	ProjectedHourCost   float64 `json:"projectedHourCost,omitempty"`
+	Price               *Price  `json:"price,omitempty"`
+
+	Conversion *Conversion `json:"conversion,omitempty"`
+}
+
+// Peak is an hourly average power peak in kW
+type Peak struct {
+	Start time.Time `json:"start"`
+	Power float64   `json:"power"`
+}
+
+// PeakReport summarizes capacity tariff (effekttariff) peaks for a month.
+// Type is always "peaks" so JSON consumers of the live stream can tell it
+// from measurements and stats.
+type PeakReport struct {
+	Type     string  `json:"type"`
+	Month    string  `json:"month"`
+	Peaks    []Peak  `json:"peaks"`
+	Average  float64 `json:"average"`
+	Currency string  `json:"currency,omitempty"`
+
+	// Step is the 1-based capacity step for Average; StepLimit 0 is unbounded
+	Step      int     `json:"step"`
+	StepLimit float64 `json:"stepLimit"`
+	StepPrice float64 `json:"stepPrice"`
+
+	// Running is the projected average of the current hour, if known.
+	// NewPeak is set when it would enter the monthly top peaks.
+	Running       *Peak   `json:"running,omitempty"`
+	NewPeak       bool    `json:"newPeak"`
+	ProjectedStep int     `json:"projectedStep,omitempty"`
+	ProjectedAvg  float64 `json:"projectedAverage,omitempty"`
+}
+
+// Alert kinds raised from the live stream
+const (
+	AlertFuseOverload   = "fuse_overload"
+	AlertPhaseImbalance = "phase_imbalance"
+	AlertVoltageLow     = "voltage_low"
+	AlertVoltageHigh    = "voltage_high"
+)
+
+// Alert states
+const (
+	AlertRaised  = "raised"
+	AlertCleared = "cleared"
+)
+
+// Alert is a warning raised or cleared by the live stream monitor. Type is
+// always "alert" so JSON consumers can tell alerts from measurements.
+type Alert struct {
+	Type      string    `json:"type"`
+	Kind      string    `json:"kind"`
+	State     string    `json:"state"`
+	Phase     int       `json:"phase,omitempty"`
+	Value     float64   `json:"value"`
+	Limit     float64   `json:"limit"`
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// Event is a notification fired by a `watch` rule and sent to webhooks
+type Event struct {
+	Type      string                 `json:"type"`
+	HomeID    string                 `json:"homeId,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+	Message   string                 `json:"message"`
+	Data      map[string]interface{} `json:"data,omitempty"`
 }
 
 // Viewer is the root GraphQL response type
@@ -82,7 +378,37 @@ type HomeResponse struct {
 	CurrentSubscription *Subscription `json:"currentSubscription"`
 }
 
-// Subscription contains price info
-type Subscription struct {
-	PriceInfo *PriceInfo `json:"priceInfo"`
+// Diagnostic check statuses
+const (
+	CheckPass = "pass"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+	CheckSkip = "skip"
+)
+
+// DiagnosticCheck is the result of a single `doctor` check
+type DiagnosticCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// DiagnosticReport collects the results of all `doctor` checks
+type DiagnosticReport struct {
+	Checks []DiagnosticCheck `json:"checks"`
+}
+
+// Add appends a check result to the report
+func (r *DiagnosticReport) Add(name, status, detail string) {
+	r.Checks = append(r.Checks, DiagnosticCheck{Name: name, Status: status, Detail: detail})
+}
+
+// Failed reports whether any check failed
+func (r *DiagnosticReport) Failed() bool {
+	for _, c := range r.Checks {
+		if c.Status == CheckFail {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPriceRating_Report(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	rating := &PriceRating{
+		ThresholdPercentages: &PriceRatingThresholds{High: 10, Low: 10},
+		Daily: &PriceRatingPeriod{Currency: "NOK", Entries: []PriceRatingEntry{
+			{Time: day(15), Total: 1.0, Level: PriceRatingLevelNormal},
+			{Time: day(16), Total: 2.0, Level: PriceRatingLevelHigh},
+			{Time: day(17), Total: 0.5, Difference: -50, Level: PriceRatingLevelLow},
+		}},
+	}
+
+	report, err := rating.Report(RatingDaily, day(17).Add(10*time.Hour))
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if report.Current == nil || !report.Current.Time.Equal(day(17)) {
+		t.Fatalf("Current = %+v, want the entry for the 17th", report.Current)
+	}
+	if report.Average != 1.5 {
+		t.Errorf("Average = %v, want 1.5 (mean of the entries before today)", report.Average)
+	}
+	if report.Thresholds.High != 10 || report.Currency != "NOK" {
+		t.Errorf("report = %+v, want thresholds and currency copied", report)
+	}
+
+	if _, err := rating.Report(RatingMonthly, day(17)); err == nil {
+		t.Error("Report() should fail for a period that was not fetched")
+	}
+	if _, err := rating.Report("weekly", day(17)); err == nil {
+		t.Error("Report() should fail for an unknown period")
+	}
+}
+
+func TestPrice_Payable(t *testing.T) {
+	p := Price{Total: 1.0, Energy: 0.8, Tax: 0.2}
+	if p.Payable() != 1.0 || p.Other() != 0 {
+		t.Errorf("Payable() = %v, Other() = %v, want Total and 0 without a tariff", p.Payable(), p.Other())
+	}
+
+	p.Grid, p.Fees, p.Subsidy = 0.4, 0.1, 0.3
+	p.SetEffective()
+	if p.Payable() != 1.2 || p.Component(PriceTotal) != 1.2 || p.Total != 1.0 {
+		t.Errorf("Payable() = %v, Component(total) = %v, want Effective 1.2", p.Payable(), p.Component(PriceTotal))
+	}
+	if math.Abs(p.Other()-0.2) > 1e-9 {
+		t.Errorf("Other() = %v, want 0.2", p.Other())
+	}
+}
+
+func TestPrice_EffectiveJSON(t *testing.T) {
+	p := Price{Total: 0.3}
+	if data, _ := json.Marshal(p); strings.Contains(string(data), "effective") {
+		t.Errorf("json = %s, want no effective price without a tariff or subsidy", data)
+	}
+
+	// A subsidy can bring the price to exactly zero
+	p.Subsidy = 0.3
+	p.SetEffective()
+	if data, _ := json.Marshal(p); !strings.Contains(string(data), `"effective":0`) {
+		t.Errorf("json = %s, want effective 0", data)
+	}
+}
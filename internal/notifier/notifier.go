@@ -0,0 +1,305 @@
+// Package notifier sends events to webhooks with retries, optional HMAC
+// signing and a dead-letter log for deliveries that keep failing.
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/config"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+const (
+	// SignatureHeader carries "sha256=<hex HMAC of the body>" when a secret is set
+	SignatureHeader = "X-Powerctl-Signature"
+
+	// EventHeader carries the event type
+	EventHeader = "X-Powerctl-Event"
+
+	// DefaultAttempts is the number of delivery attempts per webhook
+	DefaultAttempts = 4
+
+	// DefaultTimeout for webhook requests
+	DefaultTimeout = 10 * time.Second
+
+	// DefaultQueueSize is the number of events waiting for delivery before
+	// new ones are dead-lettered
+	DefaultQueueSize = 100
+
+	// DefaultDrainTimeout bounds how long closing a queue waits for the
+	// queued events
+	DefaultDrainTimeout = 10 * time.Second
+)
+
+// Notifier delivers events to the configured webhooks
+type Notifier struct {
+	webhooks   []config.WebhookConfig
+	httpClient *http.Client
+
+	// Attempts and Backoff control retries; the delay doubles per attempt
+	Attempts int
+	Backoff  time.Duration
+
+	// DeadLetterPath receives one JSON line per undeliverable event
+	DeadLetterPath string
+
+	mu sync.Mutex
+}
+
+// New creates a notifier for webhooks, logging failures to deadLetterPath
+func New(webhooks []config.WebhookConfig, deadLetterPath string) *Notifier {
+	return &Notifier{
+		webhooks:       webhooks,
+		httpClient:     &http.Client{Timeout: DefaultTimeout},
+		Attempts:       DefaultAttempts,
+		Backoff:        time.Second,
+		DeadLetterPath: deadLetterPath,
+	}
+}
+
+// DefaultDeadLetterPath returns the dead-letter log under the state directory
+func DefaultDeadLetterPath() string {
+	return filepath.Join(config.StateDir(), "webhooks-dead-letter.jsonl")
+}
+
+// deadLetter is one line in the dead-letter log
+type deadLetter struct {
+	Time  time.Time    `json:"time"`
+	URL   string       `json:"url"`
+	Error string       `json:"error"`
+	Event models.Event `json:"event"`
+}
+
+// Send delivers event to every webhook subscribed to its type. Failed
+// deliveries are written to the dead-letter log; the returned error joins
+// all delivery failures.
+func (n *Notifier) Send(ctx context.Context, event models.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	var failed []error
+	for _, hook := range n.webhooks {
+		if !subscribed(hook, event.Type) {
+			continue
+		}
+		if err := n.deliver(ctx, hook, event.Type, body); err != nil {
+			failed = append(failed, fmt.Errorf("%s: %w", hook.URL, err))
+			if dlErr := n.writeDeadLetter(hook.URL, err, event); dlErr != nil {
+				failed = append(failed, dlErr)
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("webhook delivery failed: %v", failed)
+	}
+	return nil
+}
+
+// Queue delivers events in the background, so a slow or failing webhook
+// never blocks the code producing events
+type Queue struct {
+	n       *Notifier
+	events  chan models.Event
+	onError func(error)
+	done    chan struct{}
+
+	// ctx is for deliveries; it outlives the caller's ctx so Close can
+	// still send what is queued after a Ctrl+C
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// DrainTimeout bounds how long Close waits for queued events
+	DrainTimeout time.Duration
+}
+
+// NewQueue starts delivering events through n until Close is called. Up to
+// size events wait for delivery. Deliveries keep ctx's values but not its
+// cancellation. onError, if set, receives delivery failures and dropped
+// events, possibly from another goroutine.
+func NewQueue(ctx context.Context, n *Notifier, size int, onError func(error)) *Queue {
+	q := &Queue{
+		n:            n,
+		events:       make(chan models.Event, size),
+		onError:      onError,
+		done:         make(chan struct{}),
+		DrainTimeout: DefaultDrainTimeout,
+	}
+	q.ctx, q.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	go q.run()
+	return q
+}
+
+// Enqueue queues event for delivery without blocking. When the queue is
+// full the event goes straight to the dead-letter log.
+func (q *Queue) Enqueue(event models.Event) {
+	select {
+	case q.events <- event:
+	default:
+		q.report(q.n.drop(event, fmt.Errorf("delivery queue full")))
+	}
+}
+
+// Close stops accepting events and waits up to DrainTimeout for the queued
+// ones to be sent. Events still queued after that fail straight to the
+// dead-letter log.
+func (q *Queue) Close() {
+	close(q.events)
+	defer q.cancel()
+
+	timer := time.NewTimer(q.DrainTimeout)
+	defer timer.Stop()
+	select {
+	case <-q.done:
+	case <-timer.C:
+		q.cancel()
+		<-q.done
+	}
+}
+
+func (q *Queue) run() {
+	defer close(q.done)
+	for event := range q.events {
+		q.report(q.n.Send(q.ctx, event))
+	}
+}
+
+func (q *Queue) report(err error) {
+	if err != nil && q.onError != nil {
+		q.onError(err)
+	}
+}
+
+// drop writes event to the dead-letter log for every subscribed webhook
+// without trying to deliver it
+func (n *Notifier) drop(event models.Event, cause error) error {
+	var failed []error
+	for _, hook := range n.webhooks {
+		if !subscribed(hook, event.Type) {
+			continue
+		}
+		failed = append(failed, fmt.Errorf("%s: %w", hook.URL, cause))
+		if err := n.writeDeadLetter(hook.URL, cause, event); err != nil {
+			failed = append(failed, err)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("webhook delivery failed: %v", failed)
+	}
+	return nil
+}
+
+// subscribed reports whether hook wants events of type eventType
+func subscribed(hook config.WebhookConfig, eventType string) bool {
+	if len(hook.Events) == 0 {
+		return true
+	}
+	for _, e := range hook.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// deliver POSTs body, retrying network errors, 429 and 5xx responses
+func (n *Notifier) deliver(ctx context.Context, hook config.WebhookConfig, eventType string, body []byte) error {
+	delay := n.Backoff
+	var lastErr error
+
+	for attempt := 1; attempt <= n.Attempts; attempt++ {
+		retry, err := n.post(ctx, hook, eventType, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry || attempt == n.Attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+
+	return lastErr
+}
+
+// post sends one request and reports whether a failure is worth retrying
+func (n *Notifier) post(ctx context.Context, hook config.WebhookConfig, eventType string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, eventType)
+	if hook.Secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(hook.Secret, body))
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("status %d", resp.StatusCode)
+}
+
+// Sign returns the hex HMAC-SHA256 of body with secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// writeDeadLetter appends an undeliverable event to the dead-letter log
+func (n *Notifier) writeDeadLetter(url string, cause error, event models.Event) error {
+	if n.DeadLetterPath == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(deadLetter{Time: time.Now(), URL: url, Error: cause.Error(), Event: event})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(n.DeadLetterPath), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	f, err := os.OpenFile(n.DeadLetterPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open dead-letter log: %w", err)
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,311 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/config"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+func testEvent() models.Event {
+	return models.Event{Type: config.RuleStreamLost, Timestamp: time.Now(), Message: "test"}
+}
+
+func TestNotifier_SignsPayload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if got, want := r.Header.Get(SignatureHeader), "sha256="+Sign("s3cret", body); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+		if r.Header.Get(EventHeader) != config.RuleStreamLost {
+			t.Errorf("event header = %q, want %q", r.Header.Get(EventHeader), config.RuleStreamLost)
+		}
+		var event models.Event
+		if err := json.Unmarshal(body, &event); err != nil || event.Message != "test" {
+			t.Errorf("payload = %s, want the event as JSON", body)
+		}
+	}))
+	defer server.Close()
+
+	n := New([]config.WebhookConfig{{URL: server.URL, Secret: "s3cret"}}, "")
+	if err := n.Send(context.Background(), testEvent()); err != nil {
+		t.Errorf("Send() error = %v", err)
+	}
+}
+
+func TestNotifier_RetriesThenSucceeds(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	n := New([]config.WebhookConfig{{URL: server.URL}}, "")
+	n.Backoff = time.Millisecond
+
+	if err := n.Send(context.Background(), testEvent()); err != nil {
+		t.Errorf("Send() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+func TestNotifier_DeadLetter(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	deadLetter := filepath.Join(t.TempDir(), "state", "dead.jsonl")
+	n := New([]config.WebhookConfig{{URL: server.URL}}, deadLetter)
+	n.Backoff = time.Millisecond
+
+	if err := n.Send(context.Background(), testEvent()); err == nil {
+		t.Error("Send() should fail for a 400 response")
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (4xx is not retried)", calls)
+	}
+
+	data, err := os.ReadFile(deadLetter)
+	if err != nil {
+		t.Fatalf("dead-letter log not written: %v", err)
+	}
+	if !strings.Contains(string(data), server.URL) || !strings.Contains(string(data), "status 400") {
+		t.Errorf("dead-letter line = %s, want URL and error", data)
+	}
+}
+
+func TestNotifier_EventFilter(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	n := New([]config.WebhookConfig{{URL: server.URL, Events: []string{config.RulePriceLevel}}}, "")
+	n.Send(context.Background(), testEvent())
+
+	if calls != 0 {
+		t.Errorf("calls = %d, want 0 for an unsubscribed event type", calls)
+	}
+}
+
+func TestQueue_SlowWebhookDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	received := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event models.Event
+		json.NewDecoder(r.Body).Decode(&event)
+		<-release
+		received <- event.Message
+	}))
+	defer server.Close()
+
+	deadLetter := filepath.Join(t.TempDir(), "dead.jsonl")
+	n := New([]config.WebhookConfig{{URL: server.URL}}, deadLetter)
+	var dropped []error
+	q := NewQueue(context.Background(), n, 1, func(err error) { dropped = append(dropped, err) })
+
+	// The first event is stuck at the server and the second waits in the
+	// queue; the rest must not wait for either
+	start := time.Now()
+	for _, msg := range []string{"first", "second", "third", "fourth"} {
+		event := testEvent()
+		event.Message = msg
+		q.Enqueue(event)
+		if msg == "first" {
+			// Let the worker pick it up before filling the queue
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Enqueue() blocked for %s behind a slow webhook", elapsed)
+	}
+	if len(dropped) != 2 {
+		t.Errorf("dropped = %v, want the 2 events that did not fit", dropped)
+	}
+
+	close(release)
+	q.Close()
+	close(received)
+	var got []string
+	for msg := range received {
+		got = append(got, msg)
+	}
+	if strings.Join(got, ",") != "first,second" {
+		t.Errorf("delivered %v, want first and second", got)
+	}
+
+	data, err := os.ReadFile(deadLetter)
+	if err != nil || strings.Count(string(data), "delivery queue full") != 2 {
+		t.Errorf("dead-letter log = %s (%v), want the 2 dropped events", data, err)
+	}
+}
+
+func TestQueue_CloseDeliversAfterCancel(t *testing.T) {
+	received := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event models.Event
+		json.NewDecoder(r.Body).Decode(&event)
+		time.Sleep(20 * time.Millisecond)
+		received <- event.Message
+	}))
+	defer server.Close()
+
+	n := New([]config.WebhookConfig{{URL: server.URL}}, filepath.Join(t.TempDir(), "dead.jsonl"))
+	var failed []error
+	ctx, cancel := context.WithCancel(context.Background())
+	q := NewQueue(ctx, n, DefaultQueueSize, func(err error) { failed = append(failed, err) })
+
+	// Ctrl+C cancels the command's context with events still queued
+	for _, msg := range []string{"first", "second", "third"} {
+		event := testEvent()
+		event.Message = msg
+		q.Enqueue(event)
+	}
+	cancel()
+	q.Close()
+
+	close(received)
+	var got []string
+	for msg := range received {
+		got = append(got, msg)
+	}
+	if strings.Join(got, ",") != "first,second,third" || len(failed) != 0 {
+		t.Errorf("delivered %v with failures %v, want all 3 events", got, failed)
+	}
+}
+
+func TestQueue_CloseGivesUpAfterDrainTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	deadLetter := filepath.Join(t.TempDir(), "dead.jsonl")
+	n := New([]config.WebhookConfig{{URL: server.URL}}, deadLetter)
+	n.Attempts = 1
+	var mu sync.Mutex
+	var failed []error
+	q := NewQueue(context.Background(), n, DefaultQueueSize, func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failed = append(failed, err)
+	})
+	q.DrainTimeout = 50 * time.Millisecond
+
+	q.Enqueue(testEvent())
+	q.Enqueue(testEvent())
+	start := time.Now()
+	q.Close()
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Close() waited %s, want about the drain timeout", elapsed)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(failed) != 2 {
+		t.Errorf("failures = %v, want both events reported", failed)
+	}
+	if data, err := os.ReadFile(deadLetter); err != nil || strings.Count(string(data), "\n") != 2 {
+		t.Errorf("dead-letter log = %s (%v), want both events", data, err)
+	}
+}
+
+func priceInfo(level models.PriceLevel, tomorrow bool, now time.Time) *models.PriceInfo {
+	start := now.Truncate(time.Hour)
+	info := &models.PriceInfo{
+		Today: []models.Price{{StartsAt: start, Level: level, Total: 1}},
+	}
+	if tomorrow {
+		info.Tomorrow = []models.Price{{StartsAt: start.Add(24 * time.Hour), Level: models.PriceLevelNormal}}
+	}
+	return info
+}
+
+func TestEngine_PriceRulesFireOnTransitions(t *testing.T) {
+	rules := []config.RuleConfig{
+		{Type: config.RulePriceLevel, Level: "VERY_CHEAP"},
+		{Type: config.RuleTomorrowPrices},
+	}
+	e := NewEngine(rules, "home-1")
+	now := time.Now()
+
+	if events := e.CheckPrices(priceInfo(models.PriceLevelVeryCheap, false, now), now); len(events) != 0 {
+		t.Errorf("first poll fired %v, want nothing (priming)", events)
+	}
+	if events := e.CheckPrices(priceInfo(models.PriceLevelVeryCheap, false, now), now); len(events) != 0 {
+		t.Errorf("unchanged poll fired %v", events)
+	}
+
+	events := e.CheckPrices(priceInfo(models.PriceLevelNormal, true, now), now)
+	if len(events) != 1 || events[0].Type != config.RuleTomorrowPrices {
+		t.Errorf("events = %+v, want tomorrow_prices", events)
+	}
+
+	events = e.CheckPrices(priceInfo(models.PriceLevelVeryCheap, true, now), now)
+	if len(events) != 1 || events[0].Type != config.RulePriceLevel || events[0].HomeID != "home-1" {
+		t.Errorf("events = %+v, want price_level for home-1", events)
+	}
+}
+
+func TestEngine_PowerAboveSustained(t *testing.T) {
+	e := NewEngine([]config.RuleConfig{{Type: config.RulePowerAbove, Watts: 5000, Minutes: 2}}, "")
+	start := time.Now()
+
+	measure := func(offset time.Duration, power float64) []models.Event {
+		return e.CheckPower(&models.LiveMeasurement{Timestamp: start.Add(offset), Power: power})
+	}
+
+	if events := measure(0, 6000); len(events) != 0 {
+		t.Errorf("fired immediately: %v", events)
+	}
+	if events := measure(2*time.Minute, 6000); len(events) != 1 {
+		t.Errorf("len(events) = %d after 2 min, want 1", len(events))
+	}
+	if events := measure(3*time.Minute, 6000); len(events) != 0 {
+		t.Errorf("fired twice for one episode: %v", events)
+	}
+	measure(4*time.Minute, 1000)
+	measure(5*time.Minute, 6000)
+	if events := measure(7*time.Minute, 6000); len(events) != 1 {
+		t.Errorf("len(events) = %d for a second episode, want 1", len(events))
+	}
+}
+
+func TestEngine_StreamLostOncePerOutage(t *testing.T) {
+	e := NewEngine([]config.RuleConfig{{Type: config.RuleStreamLost}}, "")
+	cause := errors.New("read error")
+
+	if events := e.StreamLost(cause, time.Now()); len(events) != 1 {
+		t.Fatalf("len(events) = %d, want 1", len(events))
+	}
+	if events := e.StreamLost(cause, time.Now()); len(events) != 0 {
+		t.Errorf("fired again during the same outage: %v", events)
+	}
+
+	e.CheckPower(&models.LiveMeasurement{Timestamp: time.Now()})
+	if events := e.StreamLost(cause, time.Now()); len(events) != 1 {
+		t.Errorf("len(events) = %d after recovery, want 1", len(events))
+	}
+}
@@ -0,0 +1,151 @@
+package notifier
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/config"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+// Engine evaluates notification rules against price polls and the live
+// stream. Rules fire on transitions only: the first price poll records the
+// current state without firing. Engine is safe for concurrent use.
+type Engine struct {
+	mu     sync.Mutex
+	rules  []config.RuleConfig
+	homeID string
+
+	primed       bool
+	lastLevel    models.PriceLevel
+	tomorrowDate string
+
+	powerSince map[int]time.Time
+	powerFired map[int]bool
+	streamDown bool
+}
+
+// NewEngine creates a rule engine for a home
+func NewEngine(rules []config.RuleConfig, homeID string) *Engine {
+	return &Engine{
+		rules:      rules,
+		homeID:     homeID,
+		powerSince: make(map[int]time.Time),
+		powerFired: make(map[int]bool),
+	}
+}
+
+// NeedsStream reports whether any rule uses the live stream
+func (e *Engine) NeedsStream() bool {
+	for _, r := range e.rules {
+		if r.Type == config.RulePowerAbove || r.Type == config.RuleStreamLost {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPrices evaluates price rules against a fresh price poll
+func (e *Engine) CheckPrices(info *models.PriceInfo, now time.Time) []models.Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var level models.PriceLevel
+	if current := info.At(now); current != nil {
+		level = current.Level
+	} else if info.Current != nil {
+		level = info.Current.Level
+	}
+
+	tomorrowDate := ""
+	if len(info.Tomorrow) > 0 {
+		tomorrowDate = info.Tomorrow[0].StartsAt.Format("2006-01-02")
+	}
+
+	var events []models.Event
+	if e.primed {
+		for _, r := range e.rules {
+			switch r.Type {
+			case config.RulePriceLevel:
+				want, _ := models.ParsePriceLevel(r.Level)
+				if level == want && e.lastLevel != want {
+					events = append(events, e.event(config.RulePriceLevel, now,
+						fmt.Sprintf("Price level is now %s", level),
+						map[string]interface{}{"level": level, "price": info.At(now)}))
+				}
+			case config.RuleTomorrowPrices:
+				if tomorrowDate != "" && tomorrowDate != e.tomorrowDate {
+					events = append(events, e.event(config.RuleTomorrowPrices, now,
+						fmt.Sprintf("Prices for %s are published", tomorrowDate),
+						map[string]interface{}{"date": tomorrowDate, "prices": info.Tomorrow}))
+				}
+			}
+		}
+	}
+
+	e.primed = true
+	e.lastLevel = level
+	if tomorrowDate != "" {
+		e.tomorrowDate = tomorrowDate
+	}
+	return events
+}
+
+// CheckPower evaluates power rules against a live measurement
+func (e *Engine) CheckPower(m *models.LiveMeasurement) []models.Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.streamDown = false
+
+	var events []models.Event
+	for i, r := range e.rules {
+		if r.Type != config.RulePowerAbove {
+			continue
+		}
+
+		if m.Power <= r.Watts {
+			delete(e.powerSince, i)
+			e.powerFired[i] = false
+			continue
+		}
+
+		since, ok := e.powerSince[i]
+		if !ok {
+			since = m.Timestamp
+			e.powerSince[i] = since
+		}
+		if !e.powerFired[i] && m.Timestamp.Sub(since) >= time.Duration(r.Minutes)*time.Minute {
+			e.powerFired[i] = true
+			events = append(events, e.event(config.RulePowerAbove, m.Timestamp,
+				fmt.Sprintf("Power has been above %.0f W for %d min (now %.0f W)", r.Watts, r.Minutes, m.Power),
+				map[string]interface{}{"power": m.Power, "watts": r.Watts, "minutes": r.Minutes, "since": since}))
+		}
+	}
+	return events
+}
+
+// StreamLost reports a live stream failure once per outage
+func (e *Engine) StreamLost(cause error, now time.Time) []models.Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.streamDown {
+		return nil
+	}
+	e.streamDown = true
+
+	for _, r := range e.rules {
+		if r.Type == config.RuleStreamLost {
+			return []models.Event{e.event(config.RuleStreamLost, now,
+				fmt.Sprintf("Live stream lost: %v", cause),
+				map[string]interface{}{"error": cause.Error()})}
+		}
+	}
+	return nil
+}
+
+func (e *Engine) event(eventType string, t time.Time, msg string, data map[string]interface{}) models.Event {
+	return models.Event{Type: eventType, HomeID: e.homeID, Timestamp: t, Message: msg, Data: data}
+}
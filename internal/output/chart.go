@@ -0,0 +1,255 @@
+package output
+
+import (
+	"strings"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+const (
+	// chartRows is the height of the price chart's bars
+	chartRows = 8
+
+	// minChartWidth is the narrowest output the price chart is drawn for;
+	// narrower ones get the price list
+	minChartWidth = 40
+
+	// maxBarColumns caps the columns per bar on wide terminals
+	maxBarColumns = 4
+)
+
+// Chart markers, shown under the bars
+const (
+	markNow      = '▲'
+	markCheapest = '○'
+	markDearest  = '●'
+)
+
+// chartBar is one bar of the price chart: a slot, or the average of
+// several when they don't fit the width
+type chartBar struct {
+	start time.Time
+	value float64
+	level models.PriceLevel
+}
+
+// priceChart lays out today's and tomorrow's prices as vertical bars
+type priceChart struct {
+	pf       *PriceFormat
+	loc      *time.Location
+	currency string
+	bars     []chartBar
+	cols     int // columns per bar, including the gap
+
+	// top and base are the values at the top and bottom of the chart; base
+	// is below zero only when prices are
+	top, base  float64
+	labelWidth int
+
+	// The slots marked under the bars, nil if unknown, and their bars
+	now, cheapest, dearest          *models.Price
+	nowBar, cheapestBar, dearestBar int
+}
+
+// newPriceChart fits prices into width columns, labeling times in loc. It
+// returns nil when there are no prices or the width is below minChartWidth.
+func newPriceChart(prices *models.PriceInfo, width int, pf *PriceFormat, loc *time.Location) *priceChart {
+	slots := append(append([]models.Price{}, prices.Today...), prices.Tomorrow...)
+	if len(slots) == 0 || width < minChartWidth {
+		return nil
+	}
+
+	c := &priceChart{pf: pf, loc: loc, currency: slots[0].Currency, nowBar: -1}
+	c.cheapest, c.dearest = &slots[0], &slots[0]
+	for i := range slots {
+		p := &slots[i]
+		if pf.Main(p) < pf.Main(c.cheapest) {
+			c.cheapest = p
+		}
+		if pf.Main(p) > pf.Main(c.dearest) {
+			c.dearest = p
+		}
+		if prices.Current != nil && p.StartsAt.Equal(prices.Current.StartsAt) {
+			c.now = p
+		}
+	}
+	c.top, c.base = max(pf.Main(c.dearest), 0), min(pf.Main(c.cheapest), 0)
+	c.labelWidth = max(len(pf.Number(c.top)), len(pf.Number(c.base)))
+
+	// Group slots when there are more than columns, e.g. quarter-hourly
+	// prices on a narrow terminal
+	avail := width - c.gutter() - 1
+	group := (len(slots) + avail - 1) / avail
+	for i := 0; i < len(slots); i += group {
+		chunk := slots[i:min(i+group, len(slots))]
+		sum := 0.0
+		for j := range chunk {
+			sum += pf.Main(&chunk[j])
+		}
+		c.bars = append(c.bars, chartBar{start: chunk[0].StartsAt, value: sum / float64(len(chunk)), level: chunk[0].Level})
+	}
+	c.cols = max(1, min(maxBarColumns, avail/len(c.bars)))
+
+	bar := func(p *models.Price) int {
+		for i := range slots {
+			if &slots[i] == p {
+				return i / group
+			}
+		}
+		return -1
+	}
+	if c.now != nil {
+		c.nowBar = bar(c.now)
+	}
+	c.cheapestBar, c.dearestBar = bar(c.cheapest), bar(c.dearest)
+	return c
+}
+
+// gutter is the width of the value axis left of the bars
+func (c *priceChart) gutter() int {
+	return 2 + c.labelWidth + 2
+}
+
+// height is the bar's height in eighths of a row
+func (c *priceChart) height(b chartBar) int {
+	if c.top <= c.base {
+		return 1
+	}
+	return max(1, int((b.value-c.base)/(c.top-c.base)*chartRows*8+0.5))
+}
+
+// lines draws the chart: the bars, the markers, the hour and day axis and
+// a legend. Bars are colored by price level when color is set.
+func (c *priceChart) lines(color bool) []string {
+	var lines []string
+	barWidth := max(1, c.cols-1)
+
+	for r := chartRows - 1; r >= 0; r-- {
+		var sb strings.Builder
+		switch r {
+		case chartRows - 1:
+			sb.WriteString("  " + padLeft(c.pf.Number(c.top), c.labelWidth) + " ┤")
+		case 0:
+			sb.WriteString("  " + padLeft(c.pf.Number(c.base), c.labelWidth) + " ┤")
+		default:
+			sb.WriteString("  " + strings.Repeat(" ", c.labelWidth) + " │")
+		}
+		// Colors only change between levels, so neighbours share a code
+		current := ""
+		for _, b := range c.bars {
+			cell := c.height(b) - r*8
+			glyph := " "
+			if cell > 0 {
+				glyph = string(sparkTicks[min(cell, 8)-1])
+				if color && PriceColor(b.level) != current {
+					current = PriceColor(b.level)
+					sb.WriteString(current)
+				}
+			}
+			sb.WriteString(strings.Repeat(glyph, barWidth) + strings.Repeat(" ", c.cols-barWidth))
+		}
+		line := strings.TrimRight(sb.String(), " ")
+		if current != "" {
+			line += Reset
+		}
+		lines = append(lines, line)
+	}
+
+	indent := strings.Repeat(" ", c.gutter())
+	lines = append(lines, "  "+strings.Repeat(" ", c.labelWidth)+" └"+strings.Repeat("─", len(c.bars)*c.cols))
+
+	marks := c.row()
+	for _, m := range []struct {
+		bar  int
+		mark rune
+	}{{c.dearestBar, markDearest}, {c.cheapestBar, markCheapest}, {c.nowBar, markNow}} {
+		if m.bar >= 0 {
+			marks[m.bar*c.cols] = m.mark
+		}
+	}
+	lines = append(lines, indent+strings.TrimRight(string(marks), " "))
+	lines = append(lines, indent+strings.TrimRight(string(c.hourAxis()), " "))
+	lines = append(lines, indent+strings.TrimRight(string(c.dayAxis()), " "))
+
+	return append(lines, "", "  "+c.legend())
+}
+
+// row returns a blank row as wide as the bars
+func (c *priceChart) row() []rune {
+	return []rune(strings.Repeat(" ", len(c.bars)*c.cols))
+}
+
+// hourAxis labels whole hours in the chart's timezone, every 1, 2, 3, 6 or 12 hours,
+// whichever is the first to leave a space between labels
+func (c *priceChart) hourAxis() []rune {
+	for _, step := range []int{1, 2, 3, 6, 12} {
+		row := c.row()
+		last, fits := -3, true
+		for i, b := range c.bars {
+			t := c.local(b.start)
+			if t.Minute() != 0 || t.Hour()%step != 0 {
+				continue
+			}
+			pos := i * c.cols
+			if pos-last < 3 || pos+2 > len(row) {
+				fits = false
+				break
+			}
+			copy(row[pos:], []rune(t.Format("15")))
+			last = pos
+		}
+		if fits {
+			return row
+		}
+	}
+	return c.row()
+}
+
+// dayAxis names the day under its first bar
+func (c *priceChart) dayAxis() []rune {
+	row := c.row()
+	day := ""
+	for i, b := range c.bars {
+		t := c.local(b.start)
+		if d := t.Format("2006-01-02"); d != day {
+			day = d
+			label := []rune(c.pf.locale.Date(t, "Mon 02 Jan"))
+			if pos := i * c.cols; pos+len(label) <= len(row) {
+				copy(row[pos:], label)
+			}
+		}
+	}
+	return row
+}
+
+// legend explains the markers with the time and price of each slot
+func (c *priceChart) legend() string {
+	layout := "15:04"
+	if c.local(c.bars[0].start).Format("2006-01-02") != c.local(c.bars[len(c.bars)-1].start).Format("2006-01-02") {
+		layout = "Mon 15:04"
+	}
+	item := func(mark rune, name string, p *models.Price) string {
+		return string(mark) + " " + c.pf.locale.T(name) + " " + c.pf.locale.Date(c.local(p.StartsAt), layout) + " " + c.pf.Number(c.pf.Main(p))
+	}
+
+	var parts []string
+	if c.now != nil {
+		parts = append(parts, item(markNow, "now", c.now))
+	}
+	parts = append(parts,
+		item(markCheapest, "cheapest", c.cheapest),
+		item(markDearest, "most expensive", c.dearest),
+		c.pf.Unit(c.currency)+"/kWh")
+	return strings.Join(parts, "   ")
+}
+
+// padLeft right-aligns s in width columns
+func padLeft(s string, width int) string {
+	return strings.Repeat(" ", max(0, width-len([]rune(s)))) + s
+}
+
+// local returns t in the chart's timezone
+func (c *priceChart) local(t time.Time) time.Time {
+	return inLocation(t, c.loc)
+}
@@ -1,25 +1,211 @@
 package output
 
-import "github.com/kristofferrisa/powerctl-cli/internal/models"
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/i18n"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
 
 // Formatter defines the interface for output formatting
 type Formatter interface {
 	FormatHome(home *models.HomeResponse) string
 	FormatHomes(homes []models.HomeResponse) string
 	FormatPrices(prices *models.PriceInfo, homeID string) string
+	FormatPriceChart(prices *models.PriceInfo, width int) string
+	FormatPriceRating(report *models.PriceRatingReport) string
+	FormatPriceRange(report *models.PriceRangeReport) string
+	FormatPriceBreakdown(b *models.PriceBreakdown) string
 	FormatLiveMeasurement(m *models.LiveMeasurement) string
+	FormatLiveStats(stats *models.LiveStats) string
+	FormatPeaks(report *models.PeakReport) string
+	FormatCostReport(report *models.CostReport) string
+	FormatAlerts(alerts []models.Alert) string
+	FormatEvent(event *models.Event) string
+	FormatPushResult(result *models.PushNotificationResult) string
+	FormatDiagnostics(report *models.DiagnosticReport) string
 }
 
-// New creates a formatter based on the format name
-func New(format string) Formatter {
+// New creates a formatter based on the format name. style, locale and loc
+// apply to the pretty and markdown formats; JSON is never changed.
+func New(format string, prices *PriceFormat, style Style, locale *i18n.Locale, loc *time.Location) Formatter {
 	switch format {
 	case "json":
 		return &JSONFormatter{}
 	case "markdown", "md":
-		return &MarkdownFormatter{}
+		return styled(&MarkdownFormatter{Prices: prices, Locale: locale, Location: loc}, style)
 	case "pretty", "":
-		return &PrettyFormatter{}
+		return styled(&PrettyFormatter{Prices: prices, Locale: locale, Location: loc}, style)
+	default:
+		return styled(&PrettyFormatter{Prices: prices, Locale: locale, Location: loc}, style)
+	}
+}
+
+// inLocation returns t in loc for display; nil is the system timezone
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t.Local()
+	}
+	return t.In(loc)
+}
+
+// timeNow returns the present moment; tests replace it
+var timeNow = time.Now
+
+// currentPrice returns the slot of today's and tomorrow's prices covering
+// the present moment, or nil. Slots are matched by absolute time, so the
+// repeated hour when DST ends is told apart.
+func currentPrice(today, tomorrow []models.Price) *models.Price {
+	return (&models.PriceInfo{Today: today, Tomorrow: tomorrow}).At(timeNow())
+}
+
+// sparkTicks are the glyphs used by sparkline, lowest first
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a one-line bar chart scaled to their range
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		i := 0
+		if hi > lo {
+			i = int(float64(len(sparkTicks)-1) * (v - lo) / (hi - lo))
+		}
+		sb.WriteRune(sparkTicks[i])
+	}
+	return sb.String()
+}
+
+// ratingCurrentLabel names the current entry of a price rating period
+func ratingCurrentLabel(period string, l *i18n.Locale) string {
+	switch period {
+	case models.RatingHourly:
+		return l.T("This hour")
+	case models.RatingMonthly:
+		return l.T("This month")
 	default:
-		return &PrettyFormatter{}
+		return l.T("Today")
+	}
+}
+
+// ratingTimeLayout is the time layout for entries of a price rating period
+func ratingTimeLayout(period string) string {
+	switch period {
+	case models.RatingHourly:
+		return "Mon 02 Jan 15:04"
+	case models.RatingMonthly:
+		return "Jan 2006"
+	default:
+		return "Mon 02 Jan"
+	}
+}
+
+// rangeTitle describes a price range report's dates; To is exclusive
+func rangeTitle(report *models.PriceRangeReport, l *i18n.Locale) string {
+	return fmt.Sprintf("%s – %s", l.Date(report.From, "2006-01-02"), l.Date(report.To.AddDate(0, 0, -1), "2006-01-02"))
+}
+
+// timingSummary describes what the timing of consumption saved or cost
+// compared with paying the monthly spot average for every kWh
+func timingSummary(report *models.CostReport, l *i18n.Locale) string {
+	percent := l.Float("%+.1f", report.TimingPercent)
+	if report.TimingSavings >= 0 {
+		return l.Sprintf("%s%%, saved %s %s", percent, l.Float("%.2f", report.TimingSavings), report.Currency)
+	}
+	return l.Sprintf("%s%%, cost %s %s extra", percent, l.Float("%.2f", -report.TimingSavings), report.Currency)
+}
+
+// costLevelName names a level in a cost report breakdown; hours without a
+// matching price have no level
+func costLevelName(level models.PriceLevel, l *i18n.Locale) string {
+	if level == 0 {
+		return l.T("No price")
+	}
+	return l.T(level.Label())
+}
+
+// hasOther reports whether any price in b has a grid tariff, fees or subsidy
+func hasOther(b *models.PriceBreakdown) bool {
+	for _, d := range b.Days {
+		if math.Abs(d.Other) > 1e-9 {
+			return true
+		}
+	}
+	return false
+}
+
+// taxShare is the percentage of a price that is tax, 0 for free or
+// negative prices
+func taxShare(p *models.Price) float64 {
+	if p.Payable() <= 0 {
+		return 0
+	}
+	return p.Tax / p.Payable() * 100
+}
+
+// priceNote splits a price with a tariff or subsidy applied into spot
+// price, grid charge, fees and subsidy; it is empty when neither applied
+func priceNote(p *models.Price, pf *PriceFormat) string {
+	if !p.Adjusted() {
+		return ""
+	}
+
+	l := pf.locale
+	parts := []string{l.T("spot") + " " + pf.Number(p.Total)}
+	if p.Grid != 0 || p.Fees != 0 {
+		parts = append(parts, l.T("grid")+" "+pf.Number(p.Grid), l.T("fees")+" "+pf.Number(p.Fees))
+	}
+	note := strings.Join(parts, " + ")
+	switch {
+	case p.Subsidy > 0:
+		note += " − " + l.T("subsidy") + " " + pf.Number(p.Subsidy)
+	case p.Subsidy < 0:
+		note += " + " + l.T("fixed price settlement") + " " + pf.Number(-p.Subsidy)
+	}
+	return note
+}
+
+// originalAmount shows a converted amount in its original currency, e.g.
+// " (12.40 NOK)"; it is empty without a conversion
+func originalAmount(conv *models.Conversion, amount float64, l *i18n.Locale) string {
+	if conv == nil {
+		return ""
+	}
+	return fmt.Sprintf(" (%s %s)", l.Float("%.2f", conv.Original(amount)), conv.From)
+}
+
+// conversionNote describes a currency conversion, e.g. "Converted from NOK
+// at 1 NOK = 0.0870 EUR (ECB, 2025-10-17)"; it is empty without one
+func conversionNote(conv *models.Conversion, l *i18n.Locale) string {
+	if conv == nil {
+		return ""
+	}
+
+	note := l.Sprintf("Converted from %s at 1 %s = %s %s", conv.From, conv.From, l.Float("%.4f", conv.Rate), conv.To)
+	var source []string
+	for _, s := range []string{conv.Source, conv.Date} {
+		if s != "" {
+			source = append(source, s)
+		}
+	}
+	if len(source) > 0 {
+		note += " (" + strings.Join(source, ", ") + ")"
 	}
+	return note
 }
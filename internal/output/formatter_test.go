@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kristofferrisa/powerctl-cli/internal/i18n"
 	"github.com/kristofferrisa/powerctl-cli/internal/models"
 )
 
@@ -24,7 +25,7 @@ func TestNew_ReturnsCorrectFormatter(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.format, func(t *testing.T) {
-			f := New(tt.format)
+			f := New(tt.format, nil, Style{Color: true}, nil, nil)
 
 			switch tt.wantType {
 			case "JSONFormatter":
@@ -50,7 +51,7 @@ func sampleHome() *models.HomeResponse {
 			ID:          "home-123",
 			AppNickname: "My House",
 			Size:        150,
-			Type:        "HOUSE",
+			Type:        models.HomeTypeHouse,
 			Address: models.Address{
 				Address1:   "123 Main St",
 				PostalCode: "12345",
@@ -72,13 +73,13 @@ func samplePrices() *models.PriceInfo {
 			Energy:   0.35,
 			Tax:      0.10,
 			StartsAt: now,
-			Level:    "NORMAL",
+			Level:    models.PriceLevelNormal,
 			Currency: "NOK",
 		},
 		Today: []models.Price{
-			{Total: 0.40, Level: "CHEAP", StartsAt: now.Add(-1 * time.Hour), Currency: "NOK"},
-			{Total: 0.45, Level: "NORMAL", StartsAt: now, Currency: "NOK"},
-			{Total: 0.60, Level: "EXPENSIVE", StartsAt: now.Add(1 * time.Hour), Currency: "NOK"},
+			{Total: 0.40, Level: models.PriceLevelCheap, StartsAt: now.Add(-1 * time.Hour), Currency: "NOK"},
+			{Total: 0.45, Level: models.PriceLevelNormal, StartsAt: now, Currency: "NOK"},
+			{Total: 0.60, Level: models.PriceLevelExpensive, StartsAt: now.Add(1 * time.Hour), Currency: "NOK"},
 		},
 	}
 }
@@ -308,3 +309,648 @@ func TestPrettyFormatter_PowerColorByUsage(t *testing.T) {
 		})
 	}
 }
+
+func sampleDiagnostics() *models.DiagnosticReport {
+	report := &models.DiagnosticReport{}
+	report.Add("API token", models.CheckPass, "from TIBBER_TOKEN environment variable")
+	report.Add("WebSocket handshake", models.CheckFail, "failed to connect")
+	return report
+}
+
+func TestFormatDiagnostics_AllFormats(t *testing.T) {
+	report := sampleDiagnostics()
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte((&JSONFormatter{}).FormatDiagnostics(report)), &result); err != nil {
+		t.Errorf("JSON FormatDiagnostics() output is not valid JSON: %v", err)
+	}
+
+	md := (&MarkdownFormatter{}).FormatDiagnostics(report)
+	if !strings.Contains(md, "| WebSocket handshake | FAIL |") {
+		t.Error("Markdown FormatDiagnostics() should contain a FAIL row")
+	}
+
+	pretty := (&PrettyFormatter{}).FormatDiagnostics(report)
+	if !strings.Contains(pretty, "Some checks failed") {
+		t.Error("Pretty FormatDiagnostics() should summarize failures")
+	}
+}
+
+func TestFormatPushResult_AllFormats(t *testing.T) {
+	sent := &models.PushNotificationResult{Successful: true, PushedToNumberOfDevices: 2}
+	failed := &models.PushNotificationResult{}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte((&JSONFormatter{}).FormatPushResult(sent)), &result); err != nil {
+		t.Errorf("JSON FormatPushResult() output is not valid JSON: %v", err)
+	}
+	if result["successful"] != true || result["pushedToNumberOfDevices"] != 2.0 {
+		t.Errorf("JSON FormatPushResult() = %v", result)
+	}
+
+	md := (&MarkdownFormatter{}).FormatPushResult(sent)
+	if !strings.Contains(md, "| Successful | true |") || !strings.Contains(md, "| Devices | 2 |") {
+		t.Errorf("Markdown FormatPushResult() should contain the result rows:\n%s", md)
+	}
+
+	pretty := Style{}.Apply((&PrettyFormatter{}).FormatPushResult(sent))
+	if !strings.Contains(pretty, "Push notification sent to 2 device(s)") {
+		t.Errorf("Pretty FormatPushResult() = %q, want the device count", pretty)
+	}
+	if pretty := (&PrettyFormatter{}).FormatPushResult(failed); !strings.Contains(pretty, "was not sent") {
+		t.Errorf("Pretty FormatPushResult() = %q, want a failure", pretty)
+	}
+}
+
+func sampleLiveStats() *models.LiveStats {
+	now := time.Now()
+	return &models.LiveStats{
+		Type:                "stats",
+		Samples:             10,
+		Avg1m:               1500,
+		Avg5m:               1200,
+		Avg15m:              900,
+		Peak:                models.PowerSample{Power: 4200, Timestamp: now},
+		Min:                 models.PowerSample{Power: 300, Timestamp: now},
+		Sparkline:           []float64{300, 1200, 4200},
+		SparkMinutes:        15,
+		HourEnergy:          0.4,
+		ProjectedHourEnergy: 1.1,
+		ProjectedHourCost:   0.5,
+		Price:               &models.Price{Total: 0.45, Currency: "NOK"},
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := Sparkline([]float64{0, 50, 100}); got != "▁▄█" {
+		t.Errorf("Sparkline() = %q, want %q", got, "▁▄█")
+	}
+	if got := Sparkline([]float64{5, 5}); got != "▁▁" {
+		t.Errorf("Sparkline() flat = %q, want %q", got, "▁▁")
+	}
+	if got := Sparkline(nil); got != "" {
+		t.Errorf("Sparkline(nil) = %q, want empty", got)
+	}
+}
+
+func TestFormatLiveStats(t *testing.T) {
+	s := sampleLiveStats()
+
+	pretty := (&PrettyFormatter{}).FormatLiveStats(s)
+	if !strings.Contains(pretty, "4200 W") || !strings.Contains(pretty, "▁") {
+		t.Error("Pretty FormatLiveStats() should contain peak and sparkline")
+	}
+
+	md := (&MarkdownFormatter{}).FormatLiveStats(s)
+	if !strings.Contains(md, "| Projected cost | 0.50 NOK") {
+		t.Error("Markdown FormatLiveStats() should contain projected cost")
+	}
+
+	var result map[string]interface{}
+	out := (&JSONFormatter{}).FormatLiveStats(s)
+	if err := json.Unmarshal([]byte(out), &result); err != nil || strings.Contains(out, "\n") {
+		t.Errorf("JSON FormatLiveStats() should be compact valid JSON, got %q", out)
+	}
+	if result["type"] != "stats" {
+		t.Errorf("JSON FormatLiveStats() type = %v, want stats", result["type"])
+	}
+}
+
+func samplePeakReport() *models.PeakReport {
+	now := time.Now()
+	return &models.PeakReport{
+		Month:         now.Format("2006-01"),
+		Peaks:         []models.Peak{{Start: now, Power: 6.1}, {Start: now, Power: 5.2}},
+		Average:       5.65,
+		Currency:      "NOK",
+		Step:          3,
+		StepLimit:     10,
+		StepPrice:     280,
+		Running:       &models.Peak{Start: now, Power: 7.0},
+		NewPeak:       true,
+		ProjectedStep: 3,
+		ProjectedAvg:  6.55,
+	}
+}
+
+func TestFormatPeaks(t *testing.T) {
+	r := samplePeakReport()
+
+	pretty := (&PrettyFormatter{}).FormatPeaks(r)
+	if !strings.Contains(pretty, "6.10 kW") || !strings.Contains(pretty, "New peak ahead") {
+		t.Error("Pretty FormatPeaks() should list peaks and warn about a new peak")
+	}
+
+	md := (&MarkdownFormatter{}).FormatPeaks(r)
+	if !strings.Contains(md, "step 3 (below 10 kW, 280 NOK/month)") {
+		t.Errorf("Markdown FormatPeaks() missing step summary:\n%s", md)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte((&JSONFormatter{}).FormatPeaks(r)), &result); err != nil {
+		t.Errorf("JSON FormatPeaks() output is not valid JSON: %v", err)
+	}
+	if result["newPeak"] != true {
+		t.Errorf("JSON FormatPeaks() newPeak = %v, want true", result["newPeak"])
+	}
+}
+
+func TestJSONFormatter_FormatAlerts(t *testing.T) {
+	f := &JSONFormatter{}
+	alerts := []models.Alert{
+		{Type: "alert", Kind: models.AlertFuseOverload, State: models.AlertRaised, Phase: 1},
+		{Type: "alert", Kind: models.AlertVoltageLow, State: models.AlertCleared, Phase: 2},
+	}
+
+	lines := strings.Split(f.FormatAlerts(alerts), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("FormatAlerts() lines = %d, want one per alert", len(lines))
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &result); err != nil {
+		t.Fatalf("FormatAlerts() line is not valid JSON: %v", err)
+	}
+	if result["type"] != "alert" || result["kind"] != models.AlertFuseOverload {
+		t.Errorf("FormatAlerts() = %v, want type alert and kind fuse_overload", result)
+	}
+}
+
+func TestFormatEvent(t *testing.T) {
+	event := &models.Event{Type: "price_level", Timestamp: time.Now(), Message: "Price level is now VERY_CHEAP"}
+
+	if out := (&PrettyFormatter{}).FormatEvent(event); !strings.Contains(out, "Price level is now VERY_CHEAP") {
+		t.Errorf("Pretty FormatEvent() = %q, want message", out)
+	}
+	if out := (&MarkdownFormatter{}).FormatEvent(event); !strings.HasPrefix(out, "- ") {
+		t.Errorf("Markdown FormatEvent() = %q, want list item", out)
+	}
+	out := (&JSONFormatter{}).FormatEvent(event)
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &result); err != nil || result["type"] != "price_level" {
+		t.Errorf("JSON FormatEvent() = %q, want event JSON", out)
+	}
+}
+
+func TestFormatPriceRating(t *testing.T) {
+	now := time.Now()
+	entries := []models.PriceRatingEntry{
+		{Time: now.AddDate(0, 0, -1), Total: 1.00, Difference: 2.0, Level: models.PriceRatingLevelNormal},
+		{Time: now, Total: 1.50, Difference: 18.5, Level: models.PriceRatingLevelHigh},
+	}
+	r := &models.PriceRatingReport{
+		Period:     models.RatingDaily,
+		Currency:   "NOK",
+		Thresholds: models.PriceRatingThresholds{High: 10, Low: 10},
+		Current:    &entries[1],
+		Average:    1.00,
+		Entries:    entries,
+	}
+
+	pretty := (&PrettyFormatter{}).FormatPriceRating(r)
+	if !strings.Contains(pretty, "+18.5%") || !strings.Contains(pretty, "High") || !strings.Contains(pretty, "▶") {
+		t.Errorf("Pretty FormatPriceRating() should show today's difference, level and marker:\n%s", pretty)
+	}
+
+	md := (&MarkdownFormatter{}).FormatPriceRating(r)
+	if !strings.Contains(md, "**Today:** 1.50 NOK/kWh, +18.5%") || !strings.Contains(md, "| +2.0% | NORMAL |") {
+		t.Errorf("Markdown FormatPriceRating() missing summary or table:\n%s", md)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte((&JSONFormatter{}).FormatPriceRating(r)), &result); err != nil {
+		t.Fatalf("JSON FormatPriceRating() output is not valid JSON: %v", err)
+	}
+	current, _ := result["current"].(map[string]interface{})
+	if current["level"] != "HIGH" || current["difference"] != 18.5 {
+		t.Errorf("JSON FormatPriceRating() current = %v, want level HIGH and difference 18.5", current)
+	}
+}
+
+func TestFormatPriceRange(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)
+	low := models.Price{StartsAt: from.Add(3 * time.Hour), Total: 0.25}
+	high := models.Price{StartsAt: from.Add(8 * time.Hour), Total: 2.75}
+	r := &models.PriceRangeReport{
+		From: from, To: from.AddDate(0, 0, 31), Resolution: models.PriceResolutionHourly,
+		Currency: "NOK", Count: 48, Average: 1.10, Min: &low, Max: &high,
+		Days: []models.PriceDay{
+			{Date: "2025-01-01", Count: 24, Average: 1.00, Min: 0.25, Max: 2.75},
+			{Date: "2025-01-02", Count: 24, Average: 1.20, Min: 0.80, Max: 1.60},
+		},
+	}
+
+	pretty := (&PrettyFormatter{}).FormatPriceRange(r)
+	if !strings.Contains(pretty, "2025-01-01 – 2025-01-31") || !strings.Contains(pretty, "Wed 01 Jan") {
+		t.Errorf("Pretty FormatPriceRange() missing inclusive range or day rows:\n%s", pretty)
+	}
+
+	md := (&MarkdownFormatter{}).FormatPriceRange(r)
+	if !strings.Contains(md, "| Average | 1.10 NOK/kWh |") || !strings.Contains(md, "| 2025-01-02 | 1.20 | 0.80 | 1.60 |") {
+		t.Errorf("Markdown FormatPriceRange() missing summary or day table:\n%s", md)
+	}
+	md = (&MarkdownFormatter{Locale: i18n.New(i18n.Norwegian)}).FormatPriceRange(r)
+	for _, want := range []string{"01.01.2025 – 31.01.2025", "(01.01.2025 03:00)", "| 02.01.2025 | 1,20 |"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown FormatPriceRange() in Norwegian missing %q:\n%s", want, md)
+		}
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte((&JSONFormatter{}).FormatPriceRange(r)), &result); err != nil {
+		t.Fatalf("JSON FormatPriceRange() output is not valid JSON: %v", err)
+	}
+	if days, _ := result["days"].([]interface{}); len(days) != 2 {
+		t.Errorf("JSON FormatPriceRange() days = %v, want 2", result["days"])
+	}
+}
+
+func TestFormatCostReport(t *testing.T) {
+	start := time.Date(2025, 9, 1, 18, 0, 0, 0, time.Local)
+	r := &models.CostReport{
+		Month: "2025-09", Currency: "NOK", Hours: 720, Energy: 400, Cost: 480,
+		AveragePrice: 1.20, SpotAverage: 1.25, TimingPercent: -4.0, TimingSavings: 20,
+		TopHours: []models.CostHour{
+			{Start: start, Energy: 3.0, Price: 2.50, Cost: 7.50, Level: models.PriceLevelVeryExpensive},
+		},
+		Levels: []models.LevelBreakdown{
+			{Level: models.PriceLevelCheap, Hours: 300, Energy: 200, Cost: 180, Share: 50},
+			{Level: models.PriceLevelVeryExpensive, Hours: 20, Energy: 40, Cost: 100, Share: 10},
+		},
+	}
+
+	pretty := (&PrettyFormatter{}).FormatCostReport(r)
+	if !strings.Contains(pretty, "-4.0%, saved 20.00 NOK") || !strings.Contains(pretty, "Mon 01 Sep 18:00") {
+		t.Errorf("Pretty FormatCostReport() missing timing or top hours:\n%s", pretty)
+	}
+
+	md := (&MarkdownFormatter{}).FormatCostReport(r)
+	if !strings.Contains(md, "| Average price paid | 1.20 NOK/kWh |") || !strings.Contains(md, "| Cheap | 300 | 200.00 kWh | 50.0% | 180.00 |") {
+		t.Errorf("Markdown FormatCostReport() missing summary or level table:\n%s", md)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte((&JSONFormatter{}).FormatCostReport(r)), &result); err != nil {
+		t.Fatalf("JSON FormatCostReport() output is not valid JSON: %v", err)
+	}
+	levels, _ := result["levels"].([]interface{})
+	if len(levels) != 2 || levels[1].(map[string]interface{})["level"] != "VERY_EXPENSIVE" {
+		t.Errorf("JSON FormatCostReport() levels = %v, want CHEAP and VERY_EXPENSIVE", result["levels"])
+	}
+}
+
+func TestFormatPrices_PriceNote(t *testing.T) {
+	current := &models.Price{Total: 1.00, Grid: 0.45, Fees: 0.15, Currency: "NOK", Level: models.PriceLevelNormal}
+	current.SetEffective()
+	prices := &models.PriceInfo{Current: current}
+
+	if out := (&PrettyFormatter{}).FormatPrices(prices, ""); !strings.Contains(out, "spot 1.00 + grid 0.45 + fees 0.15") {
+		t.Errorf("Pretty FormatPrices() missing tariff split:\n%s", out)
+	}
+	if out := (&MarkdownFormatter{}).FormatPrices(prices, ""); !strings.Contains(out, "Effective price: spot 1.00") {
+		t.Errorf("Markdown FormatPrices() missing tariff split:\n%s", out)
+	}
+
+	current.Subsidy = 0.30
+	current.SetEffective()
+	if out := (&PrettyFormatter{}).FormatPrices(prices, ""); !strings.Contains(out, "spot 1.00 + grid 0.45 + fees 0.15 − subsidy 0.30") {
+		t.Errorf("Pretty FormatPrices() missing subsidy:\n%s", out)
+	}
+
+	current.Grid, current.Fees, current.Subsidy = 0, 0, 0
+	if out := (&PrettyFormatter{}).FormatPrices(prices, ""); strings.Contains(out, "grid") {
+		t.Errorf("Pretty FormatPrices() should not mention a tariff without one:\n%s", out)
+	}
+}
+
+func TestFormatConversion(t *testing.T) {
+	conv := &models.Conversion{From: "NOK", To: "EUR", Rate: 0.08, Date: "2025-10-17", Source: "ECB"}
+	r := &models.CostReport{Month: "2025-09", Currency: "EUR", Hours: 1, Energy: 100, Cost: 8, Total: 8, Conversion: conv}
+
+	pretty := (&PrettyFormatter{}).FormatCostReport(r)
+	if !strings.Contains(pretty, "8.00 EUR") || !strings.Contains(pretty, "(100.00 NOK)") {
+		t.Errorf("Pretty FormatCostReport() should show the original amount:\n%s", pretty)
+	}
+
+	md := (&MarkdownFormatter{}).FormatCostReport(r)
+	if !strings.Contains(md, "| Cost | 8.00 EUR (100.00 NOK) |") || !strings.Contains(md, "*Converted from NOK at 1 NOK = 0.0800 EUR (ECB, 2025-10-17)*") {
+		t.Errorf("Markdown FormatCostReport() missing original amount or note:\n%s", md)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte((&JSONFormatter{}).FormatCostReport(r)), &result); err != nil {
+		t.Fatalf("JSON FormatCostReport() output is not valid JSON: %v", err)
+	}
+	if c, _ := result["conversion"].(map[string]interface{}); c["from"] != "NOK" || c["rate"] != 0.08 {
+		t.Errorf("JSON FormatCostReport() conversion = %v", result["conversion"])
+	}
+
+	r.Conversion = nil
+	if out := (&MarkdownFormatter{}).FormatCostReport(r); strings.Contains(out, "Converted") {
+		t.Errorf("Markdown FormatCostReport() should not mention a conversion without one:\n%s", out)
+	}
+}
+
+func TestPriceFormat(t *testing.T) {
+	p := &models.Price{Total: 1.2345, Energy: 0.9876, Tax: 0.2469, Currency: "NOK"}
+
+	pf := &PriceFormat{MinorUnit: true, Decimals: 0, Components: []string{models.PriceEnergy, models.PriceTax}}
+	if got := pf.Price(pf.Main(p), "NOK"); got != "99 øre/kWh" {
+		t.Errorf("Price() = %q, want 99 øre/kWh", got)
+	}
+	if got := pf.ExtraSummary(p); got != "tax 25" {
+		t.Errorf("ExtraSummary() = %q, want tax 25", got)
+	}
+	if got := pf.Unit("PLN"); got != "1/100 PLN" {
+		t.Errorf("Unit(PLN) = %q", got)
+	}
+
+	if got := DefaultPriceFormat.Price(p.Total, "NOK"); got != "1.23 NOK/kWh" {
+		t.Errorf("default Price() = %q, want 1.23 NOK/kWh", got)
+	}
+}
+
+func TestFormatPrices_DisplayPreferences(t *testing.T) {
+	prices := &models.PriceInfo{
+		Current: &models.Price{Total: 1.25, Energy: 1.0, Tax: 0.25, Currency: "NOK", Level: models.PriceLevelNormal},
+		Today: []models.Price{
+			{Total: 1.25, Energy: 1.0, Tax: 0.25, Currency: "NOK", Level: models.PriceLevelNormal, StartsAt: time.Now()},
+			{Total: 2.5, Energy: 2.0, Tax: 0.5, Currency: "NOK", Level: models.PriceLevelExpensive, StartsAt: time.Now().Add(time.Hour)},
+		},
+	}
+	pf := &PriceFormat{MinorUnit: true, Decimals: 1, Components: []string{models.PriceTotal, models.PriceEnergy, models.PriceTax}}
+
+	pretty := (&PrettyFormatter{Prices: pf}).FormatPrices(prices, "")
+	if !strings.Contains(pretty, "125.0 øre/kWh") || !strings.Contains(pretty, "energy 200.0 · tax 50.0") {
+		t.Errorf("Pretty FormatPrices() ignores display preferences:\n%s", pretty)
+	}
+
+	md := (&MarkdownFormatter{Prices: pf}).FormatPrices(prices, "")
+	for _, want := range []string{"**125.0 øre/kWh**", "| Time | Total | Energy | Tax | Level |", "| 250.0 øre | 200.0 øre | 50.0 øre |"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown FormatPrices() missing %q:\n%s", want, md)
+		}
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(New("json", pf, Style{}, nil, nil).FormatPrices(prices, "")), &result); err != nil {
+		t.Fatalf("JSON FormatPrices() output is not valid JSON: %v", err)
+	}
+	if c, _ := result["current"].(map[string]interface{}); c["total"] != 1.25 {
+		t.Errorf("JSON FormatPrices() should keep raw prices, current = %v", result["current"])
+	}
+}
+
+func TestFormatPriceBreakdown(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)
+	b := &models.PriceBreakdown{
+		Currency: "NOK",
+		Today: []models.Price{
+			{StartsAt: start, Total: 1.0, Energy: 0.8, Tax: 0.2, Currency: "NOK"},
+			{StartsAt: start.Add(time.Hour), Total: 2.0, Energy: 1.6, Tax: 0.4, Currency: "NOK"},
+		},
+		Days: []models.BreakdownDay{{Date: "2025-01-01", Count: 2, Average: 1.5, Energy: 1.2, Tax: 0.3, TaxShare: 20}},
+	}
+
+	pretty := (&PrettyFormatter{}).FormatPriceBreakdown(b)
+	for _, want := range []string{"Price Breakdown", strings.Repeat(energySegment, 24), strings.Repeat(taxSegment, 6), "0.80 + 0.20", "20% tax"} {
+		if !strings.Contains(pretty, want) {
+			t.Errorf("Pretty FormatPriceBreakdown() missing %q:\n%s", want, pretty)
+		}
+	}
+	if strings.Contains(pretty, "grid & fees") {
+		t.Errorf("Pretty FormatPriceBreakdown() should leave out grid & fees without a tariff:\n%s", pretty)
+	}
+
+	md := (&MarkdownFormatter{}).FormatPriceBreakdown(b)
+	for _, want := range []string{"| Time | Energy | Tax | Total | Tax share |", "| 01:00 | 1.60 | 0.40 | 2.00 | 20.0% |", "| 2025-01-01 | 1.50 | 1.20 | 0.30 | 20.0% |"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown FormatPriceBreakdown() missing %q:\n%s", want, md)
+		}
+	}
+
+	b.Today[1].Grid = 0.5
+	b.Today[1].SetEffective()
+	b.Days[0].Other = 0.25
+	if md := (&MarkdownFormatter{}).FormatPriceBreakdown(b); !strings.Contains(md, "| Grid & fees |") || !strings.Contains(md, "| 01:00 | 1.60 | 0.40 | 0.50 | 2.50 | 16.0% |") {
+		t.Errorf("Markdown FormatPriceBreakdown() should add a grid & fees column:\n%s", md)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte((&JSONFormatter{}).FormatPriceBreakdown(b)), &result); err != nil {
+		t.Fatalf("JSON FormatPriceBreakdown() output is not valid JSON: %v", err)
+	}
+	if days, _ := result["days"].([]interface{}); len(days) != 1 {
+		t.Errorf("JSON FormatPriceBreakdown() days = %v", result["days"])
+	}
+}
+
+// chartPrices returns two days of hourly prices from start, cheapest at
+// 03:00 on the first day and most expensive at 18:00 on the second
+func chartPrices(start time.Time) *models.PriceInfo {
+	info := &models.PriceInfo{}
+	for h := 0; h < 48; h++ {
+		p := models.Price{StartsAt: start.Add(time.Duration(h) * time.Hour), Total: 1 + float64(h%24)/20, Currency: "NOK", Level: models.PriceLevelNormal}
+		switch h {
+		case 3:
+			p.Total = 0.1
+		case 42:
+			p.Total = 3
+		}
+		if h < 24 {
+			info.Today = append(info.Today, p)
+		} else {
+			info.Tomorrow = append(info.Tomorrow, p)
+		}
+	}
+	info.Current = &info.Today[10]
+	return info
+}
+
+func TestFormatPriceChart(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)
+	prices := chartPrices(start)
+
+	for _, width := range []int{60, 80, 250} {
+		chart := newPriceChart(prices, width, priceFormat(nil, nil), nil)
+		lines := chart.lines(false)
+		for _, line := range lines[:len(lines)-1] {
+			if w := len([]rune(line)); w >= width {
+				t.Errorf("width %d: line is %d columns wide: %q", width, w, line)
+			}
+		}
+		if width >= 250 && chart.cols != maxBarColumns {
+			t.Errorf("width %d: %d columns per bar, want %d", width, chart.cols, maxBarColumns)
+		}
+
+		marks := lines[chartRows+1]
+		gutter := chart.gutter()
+		for mark, bar := range map[rune]int{markNow: 10, markCheapest: 3, markDearest: 42} {
+			if i := strings.IndexRune(marks, mark); i < 0 || len([]rune(marks[:i]))-gutter != bar/(48/len(chart.bars))*chart.cols {
+				t.Errorf("width %d: %c not under bar %d: %q", width, mark, bar, marks)
+			}
+		}
+	}
+
+	pretty := (&PrettyFormatter{}).FormatPriceChart(prices, 80)
+	for _, want := range []string{"Today & Tomorrow", "3.00 ┤", "00", "Wed 01 Jan", "Thu 02 Jan", "▲ now Wed 10:00 1.50", "○ cheapest Wed 03:00 0.10", "● most expensive Thu 18:00 3.00", "NOK/kWh"} {
+		if !strings.Contains(pretty, want) {
+			t.Errorf("Pretty FormatPriceChart() missing %q:\n%s", want, pretty)
+		}
+	}
+	if list := (&PrettyFormatter{}).FormatPriceChart(prices, 30); list != (&PrettyFormatter{}).FormatPrices(prices, "") {
+		t.Errorf("Pretty FormatPriceChart() should fall back to the list when narrow:\n%s", list)
+	}
+
+	md := (&MarkdownFormatter{}).FormatPriceChart(prices, 80)
+	if !strings.Contains(md, "```text\n") || strings.Contains(md, "\033[") {
+		t.Errorf("Markdown FormatPriceChart() should be a plain code block:\n%s", md)
+	}
+
+	// Quarter-hourly prices are averaged into fewer bars
+	quarters := &models.PriceInfo{}
+	for i := 0; i < 96; i++ {
+		quarters.Today = append(quarters.Today, models.Price{StartsAt: start.Add(time.Duration(i) * 15 * time.Minute), Total: float64(i), Currency: "NOK"})
+	}
+	if chart := newPriceChart(quarters, 60, priceFormat(nil, nil), nil); len(chart.bars) != 48 || chart.bars[0].value != 0.5 {
+		t.Errorf("newPriceChart() of 96 quarters at width 60 = %d bars, first %v", len(chart.bars), chart.bars[0].value)
+	}
+}
+
+func TestStyle(t *testing.T) {
+	prices := chartPrices(time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local))
+	outputs := map[string]func(Formatter) string{
+		"FormatHome":        func(f Formatter) string { return f.FormatHome(sampleHome()) },
+		"FormatPrices":      func(f Formatter) string { return f.FormatPrices(prices, "") },
+		"FormatPriceChart":  func(f Formatter) string { return f.FormatPriceChart(prices, 80) },
+		"FormatLiveStats":   func(f Formatter) string { return f.FormatLiveStats(sampleLiveStats()) },
+		"FormatPeaks":       func(f Formatter) string { return f.FormatPeaks(samplePeakReport()) },
+		"FormatDiagnostics": func(f Formatter) string { return f.FormatDiagnostics(sampleDiagnostics()) },
+	}
+
+	for name, format := range outputs {
+		plain := format(New("pretty", nil, Style{}, nil, nil))
+		if strings.Contains(plain, "\033[") {
+			t.Errorf("%s without color has escape codes:\n%q", name, plain)
+		}
+		if colored := format(New("pretty", nil, Style{Color: true}, nil, nil)); !strings.Contains(colored, "\033[") {
+			t.Errorf("%s with color has no escape codes", name)
+		}
+
+		for _, f := range []string{"pretty", "markdown"} {
+			ascii := format(New(f, nil, Style{ASCII: true}, nil, nil))
+			for _, r := range ascii {
+				if r > 127 {
+					t.Errorf("%s %s in ASCII has %q:\n%s", f, name, r, ascii)
+					break
+				}
+			}
+		}
+	}
+
+	if got := (Style{ASCII: true}).Apply("  📅 Today\n   ▶ 14:00 ██░░ energy 0.98 · tax 0.25 ⚠️ peak"); got != "  Today\n   > 14:00 ##.. energy 0.98, tax 0.25 ! peak" {
+		t.Errorf("Apply() = %q", got)
+	}
+	if f := New("json", nil, Style{ASCII: true}, nil, nil); f.FormatPrices(prices, "") != (&JSONFormatter{}).FormatPrices(prices, "") {
+		t.Error("New() should not style JSON output")
+	}
+}
+
+func TestLocalizedOutput(t *testing.T) {
+	prices := chartPrices(time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local))
+	nb := i18n.New(i18n.Norwegian)
+
+	pretty := (&PrettyFormatter{Locale: nb}).FormatPrices(prices, "")
+	for _, want := range []string{"Strømpriser", "I dag", "I morgen", "1,50 NOK/kWh", "NÅ"} {
+		if !strings.Contains(pretty, want) {
+			t.Errorf("Pretty FormatPrices() in Norwegian missing %q:\n%s", want, pretty)
+		}
+	}
+	if strings.Contains(pretty, "Today") || strings.Contains(pretty, "1.50") {
+		t.Errorf("Pretty FormatPrices() in Norwegian has English labels or numbers:\n%s", pretty)
+	}
+
+	chart := (&PrettyFormatter{Locale: nb}).FormatPriceChart(prices, 80)
+	for _, want := range []string{"ons 01. jan", "▲ nå ons 10:00 1,50", "○ billigst ons 03:00 0,10", "● dyrest tor 18:00 3,00"} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("Pretty FormatPriceChart() in Norwegian missing %q:\n%s", want, chart)
+		}
+	}
+
+	md := (&MarkdownFormatter{Locale: i18n.New(i18n.German)}).FormatPrices(prices, "")
+	for _, want := range []string{"# Strompreise", "## Heute", "| Zeit | Preis | Stufe |", "| 1,50 NOK |"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown FormatPrices() in German missing %q:\n%s", want, md)
+		}
+	}
+
+	if out := New("json", nil, Style{}, nb, nil).FormatPrices(prices, ""); strings.Contains(out, "I dag") {
+		t.Errorf("JSON output should not be localized:\n%s", out)
+	}
+}
+
+// dstDay returns the hourly prices of the day starting at midnight in loc,
+// priced 1.00, 1.10 and so on
+func dstDay(year int, month time.Month, day int, loc *time.Location) *models.PriceInfo {
+	start := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	info := &models.PriceInfo{}
+	for at, i := start, 0; at.Before(start.AddDate(0, 0, 1)); at, i = at.Add(time.Hour), i+1 {
+		info.Today = append(info.Today, models.Price{StartsAt: at, Total: 1 + float64(i)/10, Currency: "NOK", Level: models.PriceLevelNormal})
+	}
+	return info
+}
+
+func TestFormatPrices_DSTDays(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+	defer func(orig func() time.Time) { timeNow = orig }(timeNow)
+
+	// At 01:30 UTC it is 03:30 in spring and the second 02:30 in autumn
+	tests := []struct {
+		name     string
+		month    time.Month
+		day      int
+		slots    int
+		hours    string
+		current  string
+		utcFirst string
+	}{
+		{"spring forward", time.March, 30, 23, "00:00 01:00 03:00 04:00", "▶ 03:00 █░░░░░░░░░░░░░░░░░░░1.20", "23:00"},
+		{"fall back", time.October, 26, 25, "00:00 01:00 02:00 02:00 03:00", "▶ 02:00 ██░░░░░░░░░░░░░░░░░░1.30", "22:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prices := dstDay(2025, tt.month, tt.day, oslo)
+			if len(prices.Today) != tt.slots {
+				t.Fatalf("%d slots, want %d", len(prices.Today), tt.slots)
+			}
+			timeNow = func() time.Time { return time.Date(2025, tt.month, tt.day, 1, 30, 0, 0, time.UTC) }
+
+			var hours, current []string
+			out := Style{}.Apply((&PrettyFormatter{Location: oslo}).FormatPrices(prices, ""))
+			for _, line := range strings.Split(out, "\n") {
+				fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), "▶"))
+				if len(fields) == 0 || len(fields[0]) != 5 || fields[0][2] != ':' {
+					continue
+				}
+				hours = append(hours, fields[0])
+				if strings.Contains(line, "▶") {
+					current = append(current, strings.TrimSpace(line))
+				}
+			}
+			if len(hours) != tt.slots || !strings.HasPrefix(strings.Join(hours, " "), tt.hours) {
+				t.Errorf("hours = %v, want %d starting %s", hours, tt.slots, tt.hours)
+			}
+			if len(current) != 1 || !strings.HasPrefix(current[0], tt.current) {
+				t.Errorf("current = %q, want one %q", current, tt.current)
+			}
+
+			// The same slots shown in UTC start the evening before
+			utc := Style{}.Apply((&PrettyFormatter{Location: time.UTC}).FormatPrices(prices, ""))
+			if !strings.Contains(utc, "     "+tt.utcFirst+" █") {
+				t.Errorf("UTC output should start at %s:\n%s", tt.utcFirst, utc)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package output
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/kristofferrisa/powerctl-cli/internal/models"
 )
@@ -27,8 +28,78 @@ func (f *JSONFormatter) FormatPrices(prices *models.PriceInfo, homeID string) st
 	return string(data)
 }
 
+// FormatPriceChart formats prices as JSON; the chart is only drawn for
+// people
+func (f *JSONFormatter) FormatPriceChart(prices *models.PriceInfo, width int) string {
+	return f.FormatPrices(prices, "")
+}
+
 // FormatLiveMeasurement formats live data as compact JSON (for streaming)
 func (f *JSONFormatter) FormatLiveMeasurement(m *models.LiveMeasurement) string {
 	data, _ := json.Marshal(m)
 	return string(data)
 }
+
+// FormatLiveStats formats rolling statistics as compact JSON
+func (f *JSONFormatter) FormatLiveStats(stats *models.LiveStats) string {
+	data, _ := json.Marshal(stats)
+	return string(data)
+}
+
+// FormatPriceRange formats a price range report as JSON
+func (f *JSONFormatter) FormatPriceRange(report *models.PriceRangeReport) string {
+	data, _ := json.MarshalIndent(report, "", "  ")
+	return string(data)
+}
+
+// FormatPriceBreakdown formats a price breakdown as JSON
+func (f *JSONFormatter) FormatPriceBreakdown(b *models.PriceBreakdown) string {
+	data, _ := json.MarshalIndent(b, "", "  ")
+	return string(data)
+}
+
+// FormatPriceRating formats a price rating report as JSON
+func (f *JSONFormatter) FormatPriceRating(report *models.PriceRatingReport) string {
+	data, _ := json.MarshalIndent(report, "", "  ")
+	return string(data)
+}
+
+// FormatPeaks formats a capacity tariff peak report as JSON
+func (f *JSONFormatter) FormatPeaks(report *models.PeakReport) string {
+	data, _ := json.MarshalIndent(report, "", "  ")
+	return string(data)
+}
+
+// FormatCostReport formats a monthly cost report as JSON
+func (f *JSONFormatter) FormatCostReport(report *models.CostReport) string {
+	data, _ := json.MarshalIndent(report, "", "  ")
+	return string(data)
+}
+
+// FormatAlerts formats alert events as compact JSON, one object per line
+func (f *JSONFormatter) FormatAlerts(alerts []models.Alert) string {
+	lines := make([]string, 0, len(alerts))
+	for _, a := range alerts {
+		data, _ := json.Marshal(a)
+		lines = append(lines, string(data))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FormatEvent formats a notification event as compact JSON
+func (f *JSONFormatter) FormatEvent(event *models.Event) string {
+	data, _ := json.Marshal(event)
+	return string(data)
+}
+
+// FormatPushResult formats a push notification result as JSON
+func (f *JSONFormatter) FormatPushResult(result *models.PushNotificationResult) string {
+	data, _ := json.MarshalIndent(result, "", "  ")
+	return string(data)
+}
+
+// FormatDiagnostics formats a doctor report as JSON
+func (f *JSONFormatter) FormatDiagnostics(report *models.DiagnosticReport) string {
+	data, _ := json.MarshalIndent(report, "", "  ")
+	return string(data)
+}
@@ -2,46 +2,58 @@ package output
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
+	"github.com/kristofferrisa/powerctl-cli/internal/i18n"
 	"github.com/kristofferrisa/powerctl-cli/internal/models"
 )
 
 // MarkdownFormatter outputs data as Markdown tables
-type MarkdownFormatter struct{}
+type MarkdownFormatter struct {
+	// Prices controls how prices per kWh are printed; nil uses
+	// DefaultPriceFormat
+	Prices *PriceFormat
+	// Locale translates labels and formats numbers and dates; nil is
+	// English
+	Locale *i18n.Locale
+	// Location is the timezone times are shown in; nil is the system's
+	Location *time.Location
+}
 
 // FormatHome formats a single home as Markdown
 func (f *MarkdownFormatter) FormatHome(home *models.HomeResponse) string {
 	var sb strings.Builder
+	l := f.Locale
 
-	sb.WriteString(fmt.Sprintf("## %s\n\n", homeTitle(home)))
+	sb.WriteString(fmt.Sprintf("## %s\n\n", homeTitle(home, l)))
 
-	sb.WriteString("| Property | Value |\n")
+	sb.WriteString(fmt.Sprintf("| %s | %s |\n", l.T("Property"), l.T("Value")))
 	sb.WriteString("|----------|-------|\n")
 	sb.WriteString(fmt.Sprintf("| ID | `%s` |\n", home.ID))
 
 	if home.Address.Address1 != "" {
-		sb.WriteString(fmt.Sprintf("| Address | %s |\n", formatAddress(&home.Address)))
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", l.T("Address"), formatAddress(&home.Address)))
 	}
 	if home.Size > 0 {
-		sb.WriteString(fmt.Sprintf("| Size | %d m² |\n", home.Size))
+		sb.WriteString(fmt.Sprintf("| %s | %d m² |\n", l.T("Size"), home.Size))
 	}
-	if home.Type != "" {
-		sb.WriteString(fmt.Sprintf("| Type | %s |\n", home.Type))
+	if home.Type != 0 {
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", l.T("Type"), l.T(home.Type.Label())))
 	}
 	if home.NumberOfResidents > 0 {
-		sb.WriteString(fmt.Sprintf("| Residents | %d |\n", home.NumberOfResidents))
+		sb.WriteString(fmt.Sprintf("| %s | %d |\n", l.T("Residents"), home.NumberOfResidents))
 	}
 	if home.MainFuseSize > 0 {
-		sb.WriteString(fmt.Sprintf("| Main Fuse | %d A |\n", home.MainFuseSize))
+		sb.WriteString(fmt.Sprintf("| %s | %d A |\n", l.T("Main Fuse"), home.MainFuseSize))
 	}
 
 	pulseStatus := "No"
 	if home.Features.RealTimeConsumptionEnabled {
 		pulseStatus = "Yes"
 	}
-	sb.WriteString(fmt.Sprintf("| Pulse Enabled | %s |\n", pulseStatus))
+	sb.WriteString(fmt.Sprintf("| %s | %s |\n", l.T("Pulse Enabled"), l.T(pulseStatus)))
 
 	return sb.String()
 }
@@ -50,7 +62,7 @@ func (f *MarkdownFormatter) FormatHome(home *models.HomeResponse) string {
 func (f *MarkdownFormatter) FormatHomes(homes []models.HomeResponse) string {
 	var sb strings.Builder
 
-	sb.WriteString("# Tibber Homes\n\n")
+	sb.WriteString("# " + f.Locale.T("Tibber Homes") + "\n\n")
 
 	for i, home := range homes {
 		sb.WriteString(f.FormatHome(&home))
@@ -66,30 +78,78 @@ func (f *MarkdownFormatter) FormatHomes(homes []models.HomeResponse) string {
 func (f *MarkdownFormatter) FormatPrices(prices *models.PriceInfo, homeID string) string {
 	var sb strings.Builder
 
-	sb.WriteString("# Electricity Prices\n\n")
-
-	// Current price
-	if prices.Current != nil {
-		sb.WriteString("## Current Price\n\n")
-		sb.WriteString(fmt.Sprintf("**%.2f %s/kWh** (%s)\n\n",
-			prices.Current.Total,
-			prices.Current.Currency,
-			levelEmoji(prices.Current.Level)))
-	}
+	sb.WriteString(f.formatPricesHeader(prices))
+	pf := priceFormat(f.Prices, f.Locale)
 
 	// Today's prices
 	if len(prices.Today) > 0 {
-		sb.WriteString("## Today\n\n")
-		sb.WriteString(formatPriceTable(prices.Today))
+		sb.WriteString("## " + f.Locale.T("Today") + "\n\n")
+		sb.WriteString(f.formatPriceTable(prices.Today, pf))
 		sb.WriteString("\n")
 	}
 
 	// Tomorrow's prices
 	if len(prices.Tomorrow) > 0 {
-		sb.WriteString("## Tomorrow\n\n")
-		sb.WriteString(formatPriceTable(prices.Tomorrow))
+		sb.WriteString("## " + f.Locale.T("Tomorrow") + "\n\n")
+		sb.WriteString(f.formatPriceTable(prices.Tomorrow, pf))
 	} else {
-		sb.WriteString("*Tomorrow's prices not yet available (published around 13:00)*\n")
+		sb.WriteString("*" + f.Locale.T("Tomorrow's prices not yet available (published around 13:00)") + "*\n")
+	}
+	sb.WriteString(mdConversionNote(prices.Conversion, f.Locale))
+
+	return sb.String()
+}
+
+// FormatPriceChart formats prices as a text bar chart in a code block,
+// falling back to the tables when width is too narrow for it
+func (f *MarkdownFormatter) FormatPriceChart(prices *models.PriceInfo, width int) string {
+	chart := newPriceChart(prices, width, priceFormat(f.Prices, f.Locale), f.Location)
+	if chart == nil {
+		return f.FormatPrices(prices, "")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(f.formatPricesHeader(prices))
+
+	if len(prices.Tomorrow) > 0 {
+		sb.WriteString("## " + f.Locale.T("Today & Tomorrow") + "\n\n")
+	} else {
+		sb.WriteString("## " + f.Locale.T("Today") + "\n\n")
+	}
+	sb.WriteString("```text\n")
+	for _, line := range chart.lines(false) {
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString("```\n")
+	if len(prices.Tomorrow) == 0 {
+		sb.WriteString("\n*" + f.Locale.T("Tomorrow's prices not yet available (published around 13:00)") + "*\n")
+	}
+	sb.WriteString(mdConversionNote(prices.Conversion, f.Locale))
+
+	return sb.String()
+}
+
+// formatPricesHeader formats the title and the current price
+func (f *MarkdownFormatter) formatPricesHeader(prices *models.PriceInfo) string {
+	var sb strings.Builder
+
+	l := f.Locale
+	sb.WriteString("# " + l.T("Electricity Prices") + "\n\n")
+
+	// Current price
+	pf := priceFormat(f.Prices, l)
+	if c := prices.Current; c != nil {
+		sb.WriteString("## " + l.T("Current Price") + "\n\n")
+		sb.WriteString(fmt.Sprintf("**%s**%s (%s)\n\n",
+			pf.Price(pf.Main(c), c.Currency),
+			pf.Original(prices.Conversion, pf.Main(c)),
+			levelEmoji(c.Level, l)))
+		if extra := pf.ExtraSummary(c); extra != "" {
+			sb.WriteString(fmt.Sprintf("%s: %s\n\n", l.T("Components"), extra))
+		}
+		if note := priceNote(c, pf); note != "" {
+			sb.WriteString(fmt.Sprintf("%s: %s\n\n", l.T("Effective price"), note))
+		}
 	}
 
 	return sb.String()
@@ -98,41 +158,329 @@ func (f *MarkdownFormatter) FormatPrices(prices *models.PriceInfo, homeID string
 // FormatLiveMeasurement formats live data as Markdown
 func (f *MarkdownFormatter) FormatLiveMeasurement(m *models.LiveMeasurement) string {
 	var sb strings.Builder
+	l := f.Locale
 
-	sb.WriteString("## Live Power\n\n")
-	sb.WriteString("| Metric | Value |\n")
+	sb.WriteString("## " + l.T("Live Power") + "\n\n")
+	sb.WriteString(fmt.Sprintf("| %s | %s |\n", l.T("Metric"), l.T("Value")))
 	sb.WriteString("|--------|-------|\n")
-	sb.WriteString(fmt.Sprintf("| Power | %.0f W |\n", m.Power))
+	sb.WriteString(fmt.Sprintf("| %s | %.0f W |\n", l.T("Power"), m.Power))
 	if m.PowerProduction > 0 {
-		sb.WriteString(fmt.Sprintf("| Production | %.0f W |\n", m.PowerProduction))
+		sb.WriteString(fmt.Sprintf("| %s | %.0f W |\n", l.T("Production"), m.PowerProduction))
 	}
-	sb.WriteString(fmt.Sprintf("| Today | %.2f kWh |\n", m.AccumulatedConsumption))
-	sb.WriteString(fmt.Sprintf("| Cost | %.2f %s |\n", m.AccumulatedCost, m.Currency))
+	sb.WriteString(fmt.Sprintf("| %s | %s kWh |\n", l.T("Today"), l.Float("%.2f", m.AccumulatedConsumption)))
+	sb.WriteString(fmt.Sprintf("| %s | %s %s%s |\n", l.T("Cost"), l.Float("%.2f", m.AccumulatedCost), m.Currency, originalAmount(m.Conversion, m.AccumulatedCost, l)))
 
 	if m.VoltagePhase1 > 0 {
-		sb.WriteString(fmt.Sprintf("| Voltage | %.1f / %.1f / %.1f V |\n",
-			m.VoltagePhase1, m.VoltagePhase2, m.VoltagePhase3))
+		sb.WriteString(fmt.Sprintf("| %s | %s / %s / %s V |\n", l.T("Voltage"),
+			l.Float("%.1f", m.VoltagePhase1), l.Float("%.1f", m.VoltagePhase2), l.Float("%.1f", m.VoltagePhase3)))
 	}
 	if m.CurrentL1 > 0 {
-		sb.WriteString(fmt.Sprintf("| Current | %.1f / %.1f / %.1f A |\n",
-			m.CurrentL1, m.CurrentL2, m.CurrentL3))
+		sb.WriteString(fmt.Sprintf("| %s | %s / %s / %s A |\n", l.T("Current"),
+			l.Float("%.1f", m.CurrentL1), l.Float("%.1f", m.CurrentL2), l.Float("%.1f", m.CurrentL3)))
+	}
+
+	sb.WriteString(fmt.Sprintf("| %s | %s |\n", l.T("Updated"), m.Timestamp.Format(time.RFC3339)))
+
+	return sb.String()
+}
+
+// FormatLiveStats formats rolling statistics as a Markdown table
+func (f *MarkdownFormatter) FormatLiveStats(stats *models.LiveStats) string {
+	var sb strings.Builder
+	l := f.Locale
+
+	sb.WriteString("## " + l.T("Trend") + "\n\n")
+	sb.WriteString(fmt.Sprintf("| %s | %s |\n", l.T("Metric"), l.T("Value")))
+	sb.WriteString("|--------|-------|\n")
+	sb.WriteString(fmt.Sprintf("| %s 1m / 5m / 15m | %.0f / %.0f / %.0f W |\n", l.T("Average"), stats.Avg1m, stats.Avg5m, stats.Avg15m))
+	sb.WriteString(fmt.Sprintf("| %s | %.0f W %s %s |\n", l.T("Peak"), stats.Peak.Power, l.T("at"), f.local(stats.Peak.Timestamp).Format("15:04:05")))
+	sb.WriteString(fmt.Sprintf("| %s | %.0f W %s %s |\n", l.T("Min"), stats.Min.Power, l.T("at"), f.local(stats.Min.Timestamp).Format("15:04:05")))
+	if line := Sparkline(stats.Sparkline); line != "" {
+		sb.WriteString(fmt.Sprintf("| %s | `%s` |\n", l.Sprintf("Last %d min", stats.SparkMinutes), line))
+	}
+	sb.WriteString(fmt.Sprintf("| %s | %s |\n", l.T("This hour"),
+		l.Sprintf("%s kWh so far, %s kWh projected", l.Float("%.2f", stats.HourEnergy), l.Float("%.2f", stats.ProjectedHourEnergy))))
+	if stats.Price != nil {
+		sb.WriteString(fmt.Sprintf("| %s | %s %s%s %s %s |\n", l.T("Projected cost"),
+			l.Float("%.2f", stats.ProjectedHourCost), stats.Price.Currency, originalAmount(stats.Conversion, stats.ProjectedHourCost, l),
+			l.T("at"), priceFormat(f.Prices, l).Price(stats.Price.Payable(), stats.Price.Currency)))
+	}
+
+	return sb.String()
+}
+
+// FormatPriceBreakdown formats a price breakdown as Markdown tables
+func (f *MarkdownFormatter) FormatPriceBreakdown(b *models.PriceBreakdown) string {
+	var sb strings.Builder
+	l := f.Locale
+	pf := priceFormat(f.Prices, l)
+
+	sb.WriteString("# " + l.T("Price Breakdown") + "\n\n")
+
+	if len(b.Days) == 0 {
+		sb.WriteString("*" + l.T("No prices available") + "*\n")
+		return sb.String()
 	}
 
-	sb.WriteString(fmt.Sprintf("| Updated | %s |\n", m.Timestamp.Format(time.RFC3339)))
+	other := hasOther(b)
+	otherHeader, otherRule := "", ""
+	if other {
+		otherHeader, otherRule = " "+l.T("Grid & fees")+" |", "-------------|"
+	}
+	sb.WriteString(l.Sprintf("Prices in %s/kWh.", pf.Unit(b.Currency)) + "\n\n")
+
+	for _, day := range []struct {
+		title  string
+		prices []models.Price
+	}{{"Today", b.Today}, {"Tomorrow", b.Tomorrow}} {
+		if len(day.prices) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("## %s\n\n", l.T(day.title)))
+		sb.WriteString(tableHeader(l, "Time", "Energy", "Tax") + otherHeader + tableHeader(l, "Total", "Tax share")[1:] + "\n")
+		sb.WriteString("|------|--------|-----|" + otherRule + "-------|-----------|\n")
+		for i := range day.prices {
+			p := &day.prices[i]
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |", f.local(p.StartsAt).Format("15:04"), pf.Number(p.Energy), pf.Number(p.Tax)))
+			if other {
+				sb.WriteString(fmt.Sprintf(" %s |", pf.Number(p.Other())))
+			}
+			sb.WriteString(fmt.Sprintf(" %s | %s%% |\n", pf.Number(p.Payable()), l.Float("%.1f", taxShare(p))))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## " + l.T("Daily Summary") + "\n\n")
+	sb.WriteString(tableHeader(l, "Date", "Average", "Energy", "Tax") + otherHeader + tableHeader(l, "Tax share")[1:] + "\n")
+	sb.WriteString("|------|---------|--------|-----|" + otherRule + "-----------|\n")
+	for _, d := range b.Days {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |", mdDate(d.Date, l), pf.Number(d.Average), pf.Number(d.Energy), pf.Number(d.Tax)))
+		if other {
+			sb.WriteString(fmt.Sprintf(" %s |", pf.Number(d.Other)))
+		}
+		sb.WriteString(fmt.Sprintf(" %s%% |\n", l.Float("%.1f", d.TaxShare)))
+	}
+	sb.WriteString(mdConversionNote(b.Conversion, l))
+
+	return sb.String()
+}
+
+// FormatPriceRange formats a price range report as Markdown
+func (f *MarkdownFormatter) FormatPriceRange(report *models.PriceRangeReport) string {
+	var sb strings.Builder
+	l := f.Locale
+
+	sb.WriteString(fmt.Sprintf("# %s %s\n\n", l.T("Prices"), rangeTitle(report, l)))
+
+	if report.Count == 0 {
+		sb.WriteString("*" + l.T("No prices in this range") + "*\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("| %s | %s |\n", l.T("Metric"), l.T("Value")))
+	sb.WriteString("|--------|-------|\n")
+	pf := priceFormat(f.Prices, l)
+	sb.WriteString(fmt.Sprintf("| %s | %s%s |\n", l.T("Average"), pf.Price(report.Average, report.Currency), pf.Original(report.Conversion, report.Average)))
+	sb.WriteString(fmt.Sprintf("| %s | %s (%s) |\n", l.T("Lowest"),
+		pf.Price(report.Min.Payable(), report.Currency), l.Date(f.local(report.Min.StartsAt), "2006-01-02 15:04")))
+	sb.WriteString(fmt.Sprintf("| %s | %s (%s) |\n", l.T("Highest"),
+		pf.Price(report.Max.Payable(), report.Currency), l.Date(f.local(report.Max.StartsAt), "2006-01-02 15:04")))
+	sb.WriteString(fmt.Sprintf("| %s | %d (%s) |\n\n", l.T("Prices"), report.Count, l.T(strings.ToLower(report.Resolution))))
+
+	sb.WriteString(tableHeader(l, "Date", "Average", "Min", "Max") + "\n")
+	sb.WriteString("|------|---------|-----|-----|\n")
+	for _, d := range report.Days {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", mdDate(d.Date, l), pf.Number(d.Average), pf.Number(d.Min), pf.Number(d.Max)))
+	}
+	sb.WriteString(mdConversionNote(report.Conversion, l))
+
+	return sb.String()
+}
+
+// FormatPriceRating formats a price rating report as Markdown
+func (f *MarkdownFormatter) FormatPriceRating(report *models.PriceRatingReport) string {
+	var sb strings.Builder
+	l := f.Locale
+
+	sb.WriteString(fmt.Sprintf("# %s (%s)\n\n", l.T("Price Rating"), l.T(report.Period)))
+
+	pf := priceFormat(f.Prices, l)
+	if c := report.Current; c != nil {
+		sb.WriteString(fmt.Sprintf("**%s:** %s, %s%% %s (%s)\n\n",
+			ratingCurrentLabel(report.Period, l), pf.Price(pf.Main(c), report.Currency),
+			l.Float("%+.1f", c.Difference), l.T("vs. trailing average"), l.T(c.Level.String())))
+		if report.Average > 0 {
+			sb.WriteString(fmt.Sprintf("**%s:** %s\n\n", l.T("Recent average"), pf.Price(report.Average, report.Currency)))
+		}
+	}
+	sb.WriteString(l.Sprintf("Thresholds: high above %+.0f%%, low below %+.0f%%",
+		report.Thresholds.High, -math.Abs(report.Thresholds.Low)) + "\n\n")
+
+	if len(report.Entries) == 0 {
+		sb.WriteString("*" + l.T("No entries") + "*\n")
+		return sb.String()
+	}
+
+	sb.WriteString(tableHeader(l, "Period") + componentHeader(pf, "Total") + tableHeader(l, "Difference", "Level")[1:] + "\n")
+	sb.WriteString("|--------|" + strings.Repeat("-------|", max(1, len(pf.Components))) + "------------|-------|\n")
+	for i := range report.Entries {
+		e := &report.Entries[i]
+		sb.WriteString(fmt.Sprintf("| %s |%s %s%% | %s |\n",
+			l.Date(f.local(e.Time), ratingTimeLayout(report.Period)), componentCells(pf, e, report.Currency),
+			l.Float("%+.1f", e.Difference), l.T(e.Level.String())))
+	}
+
+	return sb.String()
+}
+
+// FormatPeaks formats a capacity tariff peak report as Markdown
+func (f *MarkdownFormatter) FormatPeaks(report *models.PeakReport) string {
+	var sb strings.Builder
+	l := f.Locale
+
+	sb.WriteString(fmt.Sprintf("## %s %s\n\n", l.T("Capacity Peaks"), report.Month))
+
+	if len(report.Peaks) == 0 {
+		sb.WriteString("*" + l.T("No hourly data yet this month") + "*\n")
+	} else {
+		sb.WriteString(tableHeader(l, "#", "Hour", "Average") + "\n")
+		sb.WriteString("|---|------|---------|\n")
+		for i, p := range report.Peaks {
+			sb.WriteString(fmt.Sprintf("| %d | %s | %s kW |\n", i+1, l.Date(f.local(p.Start), "2006-01-02 15:04"), l.Float("%.2f", p.Power)))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("**%s:** %s kW — %s (%s, %.0f %s)\n",
+		l.T("Average"), l.Float("%.2f", report.Average), l.Sprintf("step %d", report.Step),
+		stepRange(report.StepLimit, l), report.StepPrice, l.Sprintf("%s/month", report.Currency)))
+
+	if report.Running != nil {
+		sb.WriteString(fmt.Sprintf("\n**%s:** %s kW\n", l.T("This hour (projected)"), l.Float("%.2f", report.Running.Power)))
+		if report.NewPeak {
+			sb.WriteString("\n> ⚠️ " + l.Sprintf("This hour is projected to set a new peak (average %s kW, step %d)",
+				l.Float("%.2f", report.ProjectedAvg), report.ProjectedStep) + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// FormatCostReport formats a monthly cost report as Markdown tables
+func (f *MarkdownFormatter) FormatCostReport(report *models.CostReport) string {
+	var sb strings.Builder
+	l := f.Locale
+	amount := func(v float64) string { return l.Float("%.2f", v) }
+
+	sb.WriteString(fmt.Sprintf("# %s %s\n\n", l.T("Cost Report"), report.Month))
+
+	if report.Hours == 0 {
+		sb.WriteString("*" + l.T("No consumption this month") + "*\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("| %s | %s |\n", l.T("Metric"), l.T("Value")))
+	sb.WriteString("|--------|-------|\n")
+	sb.WriteString(fmt.Sprintf("| %s | %s kWh (%s) |\n", l.T("Energy"), amount(report.Energy), l.Sprintf("%d hours", report.Hours)))
+	sb.WriteString(fmt.Sprintf("| %s | %s %s%s |\n", l.T("Cost"), amount(report.Cost), report.Currency, originalAmount(report.Conversion, report.Cost, l)))
+	if report.GridCost > 0 {
+		sb.WriteString(fmt.Sprintf("| %s | %s %s |\n", l.T("Grid tariff and fees (in cost)"), amount(report.GridCost), report.Currency))
+	}
+	if report.Subsidy != 0 {
+		sb.WriteString(fmt.Sprintf("| %s | %s %s |\n", l.T("Estimated subsidy (deducted)"), amount(report.Subsidy), report.Currency))
+	}
+	if report.FixedFee > 0 {
+		sb.WriteString(fmt.Sprintf("| %s | %s %s |\n", l.T("Fixed monthly fee"), amount(report.FixedFee), report.Currency))
+		sb.WriteString(fmt.Sprintf("| %s | %s %s%s |\n", l.T("Total"), amount(report.Total), report.Currency, originalAmount(report.Conversion, report.Total, l)))
+	}
+	pf := priceFormat(f.Prices, l)
+	sb.WriteString(fmt.Sprintf("| %s | %s |\n", l.T("Average price paid"), pf.Price(report.AveragePrice, report.Currency)))
+	sb.WriteString(fmt.Sprintf("| %s | %s |\n", l.T("Spot average"), pf.Price(report.SpotAverage, report.Currency)))
+	sb.WriteString(fmt.Sprintf("| %s | %s |\n\n", l.T("Timing"), timingSummary(report, l)))
+
+	sb.WriteString("## " + l.T("Most Expensive Hours") + "\n\n")
+	sb.WriteString(tableHeader(l, "Hour", "Energy", "Price", "Cost", "Level") + "\n")
+	sb.WriteString("|------|--------|-------|------|-------|\n")
+	for _, h := range report.TopHours {
+		sb.WriteString(fmt.Sprintf("| %s | %s kWh | %s | %s | %s |\n",
+			l.Date(f.local(h.Start), "2006-01-02 15:04"), amount(h.Energy), pf.Number(h.Price), amount(h.Cost), costLevelName(h.Level, l)))
+	}
+
+	sb.WriteString("\n## " + l.T("By Price Level") + "\n\n")
+	sb.WriteString(tableHeader(l, "Level", "Hours", "Energy", "Share", "Cost") + "\n")
+	sb.WriteString("|-------|-------|--------|-------|------|\n")
+	for _, b := range report.Levels {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %s kWh | %s%% | %s |\n",
+			costLevelName(b.Level, l), b.Hours, amount(b.Energy), l.Float("%.1f", b.Share), amount(b.Cost)))
+	}
+	sb.WriteString(mdConversionNote(report.Conversion, l))
+
+	return sb.String()
+}
+
+// FormatAlerts formats alerts as a Markdown table
+func (f *MarkdownFormatter) FormatAlerts(alerts []models.Alert) string {
+	if len(alerts) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("## " + f.Locale.T("Alerts") + "\n\n")
+	sb.WriteString(tableHeader(f.Locale, "Alert", "State", "Detail") + "\n")
+	sb.WriteString("|-------|-------|--------|\n")
+	for _, a := range alerts {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", a.Kind, a.State, a.Message))
+	}
+
+	return sb.String()
+}
+
+// FormatEvent formats a notification event as a Markdown list item
+func (f *MarkdownFormatter) FormatEvent(event *models.Event) string {
+	return fmt.Sprintf("- **%s** `%s` — %s",
+		f.Locale.Date(f.local(event.Timestamp), "2006-01-02 15:04:05"), event.Type, event.Message)
+}
+
+// FormatPushResult formats a push notification result as Markdown
+func (f *MarkdownFormatter) FormatPushResult(result *models.PushNotificationResult) string {
+	var sb strings.Builder
+
+	l := f.Locale
+	sb.WriteString("## " + l.T("Push Notification") + "\n\n")
+	sb.WriteString(tableHeader(l, "Property", "Value") + "\n")
+	sb.WriteString("|----------|-------|\n")
+	sb.WriteString(fmt.Sprintf("| %s | %t |\n", l.T("Successful"), result.Successful))
+	sb.WriteString(fmt.Sprintf("| %s | %d |\n", l.T("Devices"), result.PushedToNumberOfDevices))
+
+	return sb.String()
+}
+
+// FormatDiagnostics formats a doctor report as a Markdown table
+func (f *MarkdownFormatter) FormatDiagnostics(report *models.DiagnosticReport) string {
+	var sb strings.Builder
+
+	sb.WriteString("# " + f.Locale.T("Diagnostics") + "\n\n")
+	sb.WriteString(tableHeader(f.Locale, "Check", "Status", "Detail") + "\n")
+	sb.WriteString("|-------|--------|--------|\n")
+
+	for _, c := range report.Checks {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", c.Name, strings.ToUpper(c.Status), c.Detail))
+	}
 
 	return sb.String()
 }
 
 // Helper functions
 
-func homeTitle(home *models.HomeResponse) string {
+func homeTitle(home *models.HomeResponse, l *i18n.Locale) string {
 	if home.AppNickname != "" {
 		return home.AppNickname
 	}
 	if home.Address.Address1 != "" {
 		return home.Address.Address1
 	}
-	return "Home"
+	return l.T("Home")
 }
 
 func formatAddress(addr *models.Address) string {
@@ -146,34 +494,90 @@ func formatAddress(addr *models.Address) string {
 	return strings.Join(parts, ", ")
 }
 
-func formatPriceTable(prices []models.Price) string {
+func (f *MarkdownFormatter) formatPriceTable(prices []models.Price, pf *PriceFormat) string {
 	var sb strings.Builder
 
-	sb.WriteString("| Time | Price | Level |\n")
-	sb.WriteString("|------|-------|-------|\n")
+	sb.WriteString(tableHeader(pf.locale, "Time") + componentHeader(pf, "Price") + tableHeader(pf.locale, "Level")[1:] + "\n")
+	sb.WriteString("|------|" + strings.Repeat("-------|", max(1, len(pf.Components))) + "-------|\n")
+
+	for i := range prices {
+		p := &prices[i]
+		hour := f.local(p.StartsAt).Format("15:04")
+		sb.WriteString(fmt.Sprintf("| %s |%s %s |\n",
+			hour, componentCells(pf, p, p.Currency), levelEmoji(p.Level, pf.locale)))
+	}
+
+	return sb.String()
+}
+
+// tableHeader translates the column names of a Markdown table header row
+func tableHeader(l *i18n.Locale, columns ...string) string {
+	var sb strings.Builder
+	sb.WriteString("|")
+	for _, c := range columns {
+		sb.WriteString(" " + l.T(c) + " |")
+	}
+	return sb.String()
+}
 
-	for _, p := range prices {
-		hour := p.StartsAt.Local().Format("15:04")
-		sb.WriteString(fmt.Sprintf("| %s | %.2f %s | %s |\n",
-			hour, p.Total, p.Currency, levelEmoji(p.Level)))
+// componentHeader names one table column per displayed price component;
+// a lone component is called single
+func componentHeader(pf *PriceFormat, single string) string {
+	if len(pf.Components) <= 1 {
+		return " " + pf.locale.T(single) + " |"
 	}
+	var sb strings.Builder
+	for _, c := range pf.Components {
+		sb.WriteString(" " + pf.locale.T(strings.ToUpper(c[:1])+c[1:]) + " |")
+	}
+	return sb.String()
+}
 
+// componentCells formats the cells matching componentHeader
+func componentCells(pf *PriceFormat, p components, currency string) string {
+	if len(pf.Components) == 0 {
+		return fmt.Sprintf(" %s %s |", pf.Number(pf.Main(p)), pf.Unit(currency))
+	}
+	var sb strings.Builder
+	for _, c := range pf.Components {
+		sb.WriteString(fmt.Sprintf(" %s %s |", pf.Number(p.Component(c)), pf.Unit(currency)))
+	}
 	return sb.String()
 }
 
-func levelEmoji(level string) string {
-	switch level {
-	case "VERY_CHEAP":
-		return "VERY_CHEAP"
-	case "CHEAP":
-		return "CHEAP"
-	case "NORMAL":
-		return "NORMAL"
-	case "EXPENSIVE":
-		return "EXPENSIVE"
-	case "VERY_EXPENSIVE":
-		return "VERY_EXPENSIVE"
-	default:
-		return level
+// stepRange describes a capacity step's upper limit
+func stepRange(limit float64, l *i18n.Locale) string {
+	if limit == 0 {
+		return l.T("no upper limit")
+	}
+	return l.Sprintf("below %s kW", l.Float("%g", limit))
+}
+
+// mdConversionNote is conversionNote as a trailing italic paragraph
+func mdConversionNote(conv *models.Conversion, l *i18n.Locale) string {
+	if conv == nil {
+		return ""
+	}
+	return fmt.Sprintf("\n*%s*\n", conversionNote(conv, l))
+}
+
+// mdDate formats a yyyy-mm-dd date in the locale's numeric date layout
+func mdDate(date string, l *i18n.Locale) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
 	}
+	return l.Date(t, "2006-01-02")
+}
+
+func levelEmoji(level models.PriceLevel, l *i18n.Locale) string {
+	if !level.Known() {
+		return level.Label()
+	}
+	return l.T(level.String())
+}
+
+// local returns t in the formatter's timezone
+func (f *MarkdownFormatter) local(t time.Time) time.Time {
+	return inLocation(t, f.Location)
 }
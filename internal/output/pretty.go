@@ -2,9 +2,11 @@ package output
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
+	"github.com/kristofferrisa/powerctl-cli/internal/i18n"
 	"github.com/kristofferrisa/powerctl-cli/internal/models"
 )
 
@@ -30,7 +32,16 @@ const (
 )
 
 // PrettyFormatter outputs data with colors and nice formatting
-type PrettyFormatter struct{}
+type PrettyFormatter struct {
+	// Prices controls how prices per kWh are printed; nil uses
+	// DefaultPriceFormat
+	Prices *PriceFormat
+	// Locale translates labels and formats numbers and dates; nil is
+	// English
+	Locale *i18n.Locale
+	// Location is the timezone times are shown in; nil is the system's
+	Location *time.Location
+}
 
 // FormatHome formats a single home with colors
 func (f *PrettyFormatter) FormatHome(home *models.HomeResponse) string {
@@ -41,7 +52,7 @@ func (f *PrettyFormatter) FormatHome(home *models.HomeResponse) string {
 		title = home.Address.Address1
 	}
 	if title == "" {
-		title = "Home"
+		title = f.Locale.T("Home")
 	}
 
 	sb.WriteString(fmt.Sprintf("\n%s%s %s%s\n", Bold, Cyan, title, Reset))
@@ -49,7 +60,7 @@ func (f *PrettyFormatter) FormatHome(home *models.HomeResponse) string {
 
 	// Address
 	if home.Address.Address1 != "" {
-		sb.WriteString(fmt.Sprintf("  %s📍 Address%s\n", Bold, Reset))
+		sb.WriteString(fmt.Sprintf("  %s📍 %s%s\n", Bold, f.Locale.T("Address"), Reset))
 		sb.WriteString(fmt.Sprintf("     %s\n", home.Address.Address1))
 		if home.Address.PostalCode != "" || home.Address.City != "" {
 			sb.WriteString(fmt.Sprintf("     %s %s, %s\n", home.Address.PostalCode, home.Address.City, home.Address.Country))
@@ -58,27 +69,27 @@ func (f *PrettyFormatter) FormatHome(home *models.HomeResponse) string {
 	}
 
 	// Details
-	sb.WriteString(fmt.Sprintf("  %s🏠 Details%s\n", Bold, Reset))
+	sb.WriteString(fmt.Sprintf("  %s🏠 %s%s\n", Bold, f.Locale.T("Details"), Reset))
 	if home.Size > 0 {
-		sb.WriteString(fmt.Sprintf("     Size:      %s%d m²%s\n", BrightCyan, home.Size, Reset))
+		sb.WriteString(fmt.Sprintf("     %-10s %s%d m²%s\n", f.Locale.T("Size")+":", BrightCyan, home.Size, Reset))
 	}
-	if home.Type != "" {
-		sb.WriteString(fmt.Sprintf("     Type:      %s\n", home.Type))
+	if home.Type != 0 {
+		sb.WriteString(fmt.Sprintf("     %-10s %s\n", f.Locale.T("Type")+":", f.Locale.T(home.Type.Label())))
 	}
 	if home.NumberOfResidents > 0 {
-		sb.WriteString(fmt.Sprintf("     Residents: %d\n", home.NumberOfResidents))
+		sb.WriteString(fmt.Sprintf("     %-10s %d\n", f.Locale.T("Residents")+":", home.NumberOfResidents))
 	}
 	if home.MainFuseSize > 0 {
-		sb.WriteString(fmt.Sprintf("     Main Fuse: %d A\n", home.MainFuseSize))
+		sb.WriteString(fmt.Sprintf("     %-10s %d A\n", f.Locale.T("Main Fuse")+":", home.MainFuseSize))
 	}
 	sb.WriteString("\n")
 
 	// Pulse status
 	sb.WriteString(fmt.Sprintf("  %s⚡ Pulse%s\n", Bold, Reset))
 	if home.Features.RealTimeConsumptionEnabled {
-		sb.WriteString(fmt.Sprintf("     Status: %s● Connected%s\n", BrightGreen, Reset))
+		sb.WriteString(fmt.Sprintf("     %s %s● %s%s\n", f.Locale.T("Status:"), BrightGreen, f.Locale.T("Connected"), Reset))
 	} else {
-		sb.WriteString(fmt.Sprintf("     Status: %s○ Not connected%s\n", Dim, Reset))
+		sb.WriteString(fmt.Sprintf("     %s %s○ %s%s\n", f.Locale.T("Status:"), Dim, f.Locale.T("Not connected"), Reset))
 	}
 
 	sb.WriteString(fmt.Sprintf("\n  %sID: %s%s\n", Dim, home.ID, Reset))
@@ -90,8 +101,9 @@ func (f *PrettyFormatter) FormatHome(home *models.HomeResponse) string {
 func (f *PrettyFormatter) FormatHomes(homes []models.HomeResponse) string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("\n%s%s⚡ Tibber Homes%s\n", Bold, Cyan, Reset))
-	sb.WriteString(fmt.Sprintf("%s%s%s\n", Dim, strings.Repeat("─", 16), Reset))
+	title := "⚡ " + f.Locale.T("Tibber Homes")
+	sb.WriteString(fmt.Sprintf("\n%s%s%s%s\n", Bold, Cyan, title, Reset))
+	sb.WriteString(fmt.Sprintf("%s%s%s\n", Dim, strings.Repeat("─", len([]rune(title))+2), Reset))
 
 	for _, home := range homes {
 		sb.WriteString(f.FormatHome(&home))
@@ -105,77 +117,348 @@ func (f *PrettyFormatter) FormatHomes(homes []models.HomeResponse) string {
 func (f *PrettyFormatter) FormatPrices(prices *models.PriceInfo, homeID string) string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("\n%s%s⚡ Electricity Prices%s\n", Bold, Cyan, Reset))
-	sb.WriteString(fmt.Sprintf("%s%s%s\n\n", Dim, strings.Repeat("─", 22), Reset))
-
-	// Current price - big and prominent
-	if prices.Current != nil {
-		sb.WriteString(fmt.Sprintf("  %s%sNOW%s  ", Bold, BrightYellow, Reset))
-		sb.WriteString(fmt.Sprintf("%s%s%.2f %s/kWh%s", Bold, priceColor(prices.Current.Level), prices.Current.Total, prices.Current.Currency, Reset))
-		sb.WriteString(fmt.Sprintf("  %s\n\n", levelLabel(prices.Current.Level)))
-	}
+	sb.WriteString(f.formatPricesHeader(prices))
+	current := currentPrice(prices.Today, prices.Tomorrow)
 
 	// Today's prices
 	if len(prices.Today) > 0 {
-		sb.WriteString(fmt.Sprintf("  %s📅 Today%s\n", Bold, Reset))
-		sb.WriteString(f.formatPriceList(prices.Today))
+		sb.WriteString(fmt.Sprintf("  %s📅 %s%s\n", Bold, f.Locale.T("Today"), Reset))
+		sb.WriteString(f.formatPriceList(prices.Today, current))
 		sb.WriteString("\n")
 	}
 
 	// Tomorrow's prices
+	sb.WriteString(fmt.Sprintf("  %s📅 %s%s\n", Bold, f.Locale.T("Tomorrow"), Reset))
 	if len(prices.Tomorrow) > 0 {
-		sb.WriteString(fmt.Sprintf("  %s📅 Tomorrow%s\n", Bold, Reset))
-		sb.WriteString(f.formatPriceList(prices.Tomorrow))
+		sb.WriteString(f.formatPriceList(prices.Tomorrow, current))
 	} else {
-		sb.WriteString(fmt.Sprintf("  %s📅 Tomorrow%s\n", Bold, Reset))
-		sb.WriteString(fmt.Sprintf("     %sNot yet available (published ~13:00)%s\n", Dim, Reset))
+		sb.WriteString(fmt.Sprintf("     %s%s%s\n", Dim, f.Locale.T("Not yet available (published ~13:00, see 'powerctl prices wait-tomorrow')"), Reset))
+	}
+	sb.WriteString(dimConversionNote(prices.Conversion, f.Locale))
+
+	return sb.String()
+}
+
+// FormatPriceChart formats today's and tomorrow's prices as one bar chart
+// fitted to width columns, falling back to the list when it is too narrow
+func (f *PrettyFormatter) FormatPriceChart(prices *models.PriceInfo, width int) string {
+	chart := newPriceChart(prices, width, priceFormat(f.Prices, f.Locale), f.Location)
+	if chart == nil {
+		return f.FormatPrices(prices, "")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(f.formatPricesHeader(prices))
+
+	title := "Today"
+	if len(prices.Tomorrow) > 0 {
+		title = "Today & Tomorrow"
+	}
+	sb.WriteString(fmt.Sprintf("  %s📅 %s%s\n", Bold, f.Locale.T(title), Reset))
+	for _, line := range chart.lines(true) {
+		sb.WriteString(line + "\n")
+	}
+	if len(prices.Tomorrow) == 0 {
+		sb.WriteString(fmt.Sprintf("\n  %s%s%s\n", Dim, f.Locale.T("Tomorrow's prices are not yet available (published ~13:00, see 'powerctl prices wait-tomorrow')"), Reset))
+	}
+	sb.WriteString(dimConversionNote(prices.Conversion, f.Locale))
+
+	return sb.String()
+}
+
+// formatPricesHeader formats the title and the current price
+func (f *PrettyFormatter) formatPricesHeader(prices *models.PriceInfo) string {
+	var sb strings.Builder
+
+	title := "⚡ " + f.Locale.T("Electricity Prices")
+	sb.WriteString(fmt.Sprintf("\n%s%s%s%s\n", Bold, Cyan, title, Reset))
+	sb.WriteString(fmt.Sprintf("%s%s%s\n\n", Dim, strings.Repeat("─", len([]rune(title))+2), Reset))
+
+	// Current price - big and prominent
+	pf := priceFormat(f.Prices, f.Locale)
+	if c := prices.Current; c != nil {
+		sb.WriteString(fmt.Sprintf("  %s%s%s%s  ", Bold, BrightYellow, f.Locale.T("NOW"), Reset))
+		sb.WriteString(fmt.Sprintf("%s%s%s%s%s", Bold, PriceColor(c.Level), pf.Price(pf.Main(c), c.Currency), Reset,
+			dimmed(pf.Original(prices.Conversion, pf.Main(c)))))
+		sb.WriteString(fmt.Sprintf("  %s\n", levelLabel(c.Level, f.Locale)))
+		if extra := pf.ExtraSummary(c); extra != "" {
+			sb.WriteString(fmt.Sprintf("       %s%s%s\n", Dim, extra, Reset))
+		}
+		if note := priceNote(c, pf); note != "" {
+			sb.WriteString(fmt.Sprintf("       %s%s%s\n", Dim, note, Reset))
+		}
+		sb.WriteString("\n")
 	}
 
 	return sb.String()
 }
 
-func (f *PrettyFormatter) formatPriceList(prices []models.Price) string {
+func (f *PrettyFormatter) formatPriceList(prices []models.Price, current *models.Price) string {
 	var sb strings.Builder
+	pf := priceFormat(f.Prices, f.Locale)
 
 	// Find min/max for highlighting
-	var minPrice, maxPrice float64 = prices[0].Total, prices[0].Total
-	for _, p := range prices {
-		if p.Total < minPrice {
-			minPrice = p.Total
+	var minPrice, maxPrice float64 = pf.Main(&prices[0]), pf.Main(&prices[0])
+	for i := range prices {
+		value := pf.Main(&prices[i])
+		if value < minPrice {
+			minPrice = value
 		}
-		if p.Total > maxPrice {
-			maxPrice = p.Total
+		if value > maxPrice {
+			maxPrice = value
 		}
 	}
 
 	for _, p := range prices {
-		hour := p.StartsAt.Local().Format("15:04")
-
-		// Highlight current hour
-		now := time.Now()
-		isCurrent := p.StartsAt.Local().Hour() == now.Hour() &&
-			p.StartsAt.Local().Day() == now.Day()
+		hour := f.Locale.Date(f.local(p.StartsAt), "15:04")
 
+		// Highlight the current slot
 		prefix := "  "
-		if isCurrent {
+		if current != nil && p.StartsAt.Equal(current.StartsAt) {
 			prefix = fmt.Sprintf("%s▶%s ", BrightYellow, Reset)
 		}
 
+		extra := ""
+		if s := pf.ExtraSummary(&p); s != "" {
+			extra = "  " + s
+		}
+
 		// Price bar visualization
 		barWidth := 20
+		value := pf.Main(&p)
 		if maxPrice > minPrice {
-			barLen := int(float64(barWidth) * (p.Total - minPrice) / (maxPrice - minPrice))
+			barLen := int(float64(barWidth) * (value - minPrice) / (maxPrice - minPrice))
 			if barLen < 1 {
 				barLen = 1
 			}
 			bar := strings.Repeat("█", barLen) + strings.Repeat("░", barWidth-barLen)
-			sb.WriteString(fmt.Sprintf("   %s%s %s%s%.2f%s %s%s%s\n",
+			sb.WriteString(fmt.Sprintf("   %s%s %s%s%s%s %s%s%s%s\n",
 				prefix, hour,
-				priceColor(p.Level), bar, p.Total, Reset,
-				Dim, p.Currency, Reset))
+				PriceColor(p.Level), bar, pf.Number(value), Reset,
+				Dim, pf.Unit(p.Currency), extra, Reset))
 		} else {
-			sb.WriteString(fmt.Sprintf("   %s%s %.2f %s\n", prefix, hour, p.Total, p.Currency))
+			sb.WriteString(fmt.Sprintf("   %s%s %s %s%s%s%s\n", prefix, hour, pf.Number(value), Dim, pf.Unit(p.Currency), extra, Reset))
+		}
+	}
+
+	return sb.String()
+}
+
+// Breakdown bar segments, told apart by glyph as well as color
+const (
+	energySegment = "█"
+	taxSegment    = "▓"
+	otherSegment  = "▒"
+)
+
+// FormatPriceBreakdown formats prices as stacked energy and tax bars with a
+// daily summary of the tax share
+func (f *PrettyFormatter) FormatPriceBreakdown(b *models.PriceBreakdown) string {
+	var sb strings.Builder
+	pf := priceFormat(f.Prices, f.Locale)
+
+	title := "⚡ " + f.Locale.T("Price Breakdown")
+	sb.WriteString(fmt.Sprintf("\n%s%s%s%s\n", Bold, Cyan, title, Reset))
+	sb.WriteString(fmt.Sprintf("%s%s%s\n\n", Dim, strings.Repeat("─", len([]rune(title))+2), Reset))
+
+	if len(b.Days) == 0 {
+		sb.WriteString(fmt.Sprintf("  %s%s%s\n", Dim, f.Locale.T("No prices available"), Reset))
+		return sb.String()
+	}
+
+	other := hasOther(b)
+	sb.WriteString(fmt.Sprintf("  %s%s%s %s  %s%s%s %s", BrightCyan, energySegment, Reset, f.Locale.T("energy"), Yellow, taxSegment, Reset, f.Locale.T("tax")))
+	if other {
+		sb.WriteString(fmt.Sprintf("  %s%s%s %s", Blue, otherSegment, Reset, f.Locale.T("grid & fees")))
+	}
+	sb.WriteString(fmt.Sprintf("  %s(%s)%s\n\n", Dim, pf.Unit(b.Currency)+"/kWh", Reset))
+
+	// One scale for both days so the bars compare
+	scale := 0.0
+	for _, prices := range [][]models.Price{b.Today, b.Tomorrow} {
+		for i := range prices {
+			scale = max(scale, breakdownLength(&prices[i]))
+		}
+	}
+
+	current := currentPrice(b.Today, b.Tomorrow)
+	for _, day := range []struct {
+		title  string
+		prices []models.Price
+	}{{"Today", b.Today}, {"Tomorrow", b.Tomorrow}} {
+		if len(day.prices) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  %s📅 %s%s\n", Bold, f.Locale.T(day.title), Reset))
+		for i := range day.prices {
+			p := &day.prices[i]
+			sb.WriteString(f.formatBreakdownRow(p, current != nil && p.StartsAt.Equal(current.StartsAt), scale, other))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("  %s%s%s\n", Bold, f.Locale.T("Daily summary"), Reset))
+	for _, d := range b.Days {
+		date := d.Date
+		if t, err := time.Parse("2006-01-02", d.Date); err == nil {
+			date = f.Locale.Date(t, "Mon 02 Jan")
+		}
+		sb.WriteString(fmt.Sprintf("   %s  %s %s%s%s  %s%s %s · %s %s",
+			date, f.Locale.T("avg"), Bold, pf.Price(d.Average, b.Currency), Reset,
+			Dim, f.Locale.T("energy"), pf.Number(d.Energy), f.Locale.T("tax"), pf.Number(d.Tax)))
+		if other {
+			sb.WriteString(" · " + f.Locale.T("grid & fees") + " " + pf.Number(d.Other))
+		}
+		sb.WriteString(fmt.Sprintf("%s  %s%.0f%% %s%s\n", Reset, Yellow, d.TaxShare, f.Locale.T("tax"), Reset))
+	}
+	sb.WriteString(dimConversionNote(b.Conversion, f.Locale))
+
+	return sb.String()
+}
+
+// breakdownWidth is the width of the longest breakdown bar
+const breakdownWidth = 30
+
+// breakdownLength is the stacked length of p's bar; negative parts are
+// left out
+func breakdownLength(p *models.Price) float64 {
+	return max(p.Energy, 0) + max(p.Tax, 0) + max(p.Other(), 0)
+}
+
+func (f *PrettyFormatter) formatBreakdownRow(p *models.Price, now bool, scale float64, other bool) string {
+	pf := priceFormat(f.Prices, f.Locale)
+
+	prefix := "  "
+	if now {
+		prefix = fmt.Sprintf("%s▶%s ", BrightYellow, Reset)
+	}
+
+	cells := func(v float64) int {
+		if scale <= 0 || v <= 0 {
+			return 0
+		}
+		return int(math.Round(breakdownWidth * v / scale))
+	}
+	energy, tax, rest := cells(p.Energy), cells(p.Tax), cells(p.Other())
+	pad := max(0, breakdownWidth-energy-tax-rest)
+	bar := BrightCyan + strings.Repeat(energySegment, energy) +
+		Yellow + strings.Repeat(taxSegment, tax) +
+		Blue + strings.Repeat(otherSegment, rest) + Reset +
+		strings.Repeat(" ", pad)
+
+	parts := pf.Number(p.Energy) + " + " + pf.Number(p.Tax)
+	if other {
+		parts += " + " + pf.Number(p.Other())
+	}
+	return fmt.Sprintf("   %s%s %s %s%s%s  %s%s%s\n",
+		prefix, f.Locale.Date(f.local(p.StartsAt), "15:04"), bar,
+		Bold, pf.Number(p.Payable()), Reset, Dim, parts, Reset)
+}
+
+// FormatPriceRange formats a price range report with daily averages
+func (f *PrettyFormatter) FormatPriceRange(report *models.PriceRangeReport) string {
+	var sb strings.Builder
+
+	title := "💰 " + f.Locale.T("Prices") + " " + rangeTitle(report, f.Locale)
+	sb.WriteString(fmt.Sprintf("\n%s%s%s%s\n", Bold, Cyan, title, Reset))
+	sb.WriteString(fmt.Sprintf("%s%s%s\n\n", Dim, strings.Repeat("─", len([]rune(title))), Reset))
+
+	if report.Count == 0 {
+		sb.WriteString(fmt.Sprintf("  %s%s%s\n", Dim, f.Locale.T("No prices in this range"), Reset))
+		return sb.String()
+	}
+
+	pf := priceFormat(f.Prices, f.Locale)
+	sb.WriteString(fmt.Sprintf("  %-8s %s%s%s%s  %s(%s)%s\n",
+		f.Locale.T("Average"), Bold, pf.Price(report.Average, report.Currency), Reset, dimmed(pf.Original(report.Conversion, report.Average)),
+		Dim, f.Locale.Sprintf("%d "+strings.ToLower(report.Resolution)+" prices", report.Count), Reset))
+	sb.WriteString(fmt.Sprintf("  %-8s %s%s%s  %s%s%s\n",
+		f.Locale.T("Lowest"), BrightGreen, pf.Number(report.Min.Payable()), Reset, Dim, f.Locale.Date(f.local(report.Min.StartsAt), "Mon 02 Jan 15:04"), Reset))
+	sb.WriteString(fmt.Sprintf("  %-8s %s%s%s  %s%s%s\n\n",
+		f.Locale.T("Highest"), BrightRed, pf.Number(report.Max.Payable()), Reset, Dim, f.Locale.Date(f.local(report.Max.StartsAt), "Mon 02 Jan 15:04"), Reset))
+
+	lo, hi := report.Days[0].Average, report.Days[0].Average
+	for _, d := range report.Days {
+		lo = min(lo, d.Average)
+		hi = max(hi, d.Average)
+	}
+
+	barWidth := 20
+	for _, d := range report.Days {
+		barLen := barWidth
+		if hi > lo {
+			barLen = max(1, int(float64(barWidth)*(d.Average-lo)/(hi-lo)))
+		}
+		bar := strings.Repeat("█", barLen) + strings.Repeat("░", barWidth-barLen)
+
+		date := d.Date
+		if t, err := time.Parse("2006-01-02", d.Date); err == nil {
+			date = f.Locale.Date(t, "Mon 02 Jan")
+		}
+
+		sb.WriteString(fmt.Sprintf("   %s %s %s  %s%s–%s%s\n", date, bar, pf.Number(d.Average), Dim, pf.Number(d.Min), pf.Number(d.Max), Reset))
+	}
+	sb.WriteString(dimConversionNote(report.Conversion, f.Locale))
+
+	return sb.String()
+}
+
+// FormatPriceRating formats a price rating report as a bar chart
+func (f *PrettyFormatter) FormatPriceRating(report *models.PriceRatingReport) string {
+	var sb strings.Builder
+
+	title := fmt.Sprintf("📊 %s (%s)", f.Locale.T("Price Rating"), f.Locale.T(report.Period))
+	sb.WriteString(fmt.Sprintf("\n%s%s%s%s\n", Bold, Cyan, title, Reset))
+	sb.WriteString(fmt.Sprintf("%s%s%s\n\n", Dim, strings.Repeat("─", len([]rune(title))), Reset))
+
+	pf := priceFormat(f.Prices, f.Locale)
+	if c := report.Current; c != nil {
+		sb.WriteString(fmt.Sprintf("  %s%s%s  %s%s%s%s  %s%s%%%s %s  %s\n",
+			Bold, ratingCurrentLabel(report.Period, f.Locale), Reset,
+			Bold, ratingColor(c.Level), pf.Price(pf.Main(c), report.Currency), Reset,
+			ratingColor(c.Level), f.Locale.Float("%+.1f", c.Difference), Reset, f.Locale.T("vs. trailing average"), ratingLabel(c.Level, f.Locale)))
+		if report.Average > 0 {
+			sb.WriteString(fmt.Sprintf("  %s%s %s%s\n", Dim, f.Locale.T("Recent average"), pf.Price(report.Average, report.Currency), Reset))
+		}
+	}
+	sb.WriteString(fmt.Sprintf("  %s%s%s\n\n",
+		Dim, f.Locale.Sprintf("High above %+.0f%%, low below %+.0f%%", report.Thresholds.High, -math.Abs(report.Thresholds.Low)), Reset))
+
+	if len(report.Entries) == 0 {
+		sb.WriteString(fmt.Sprintf("  %s%s%s\n", Dim, f.Locale.T("No entries"), Reset))
+		return sb.String()
+	}
+
+	minTotal, maxTotal := pf.Main(&report.Entries[0]), pf.Main(&report.Entries[0])
+	for i := range report.Entries {
+		minTotal = min(minTotal, pf.Main(&report.Entries[i]))
+		maxTotal = max(maxTotal, pf.Main(&report.Entries[i]))
+	}
+
+	barWidth := 20
+	layout := ratingTimeLayout(report.Period)
+	for i := range report.Entries {
+		e := &report.Entries[i]
+
+		prefix := "  "
+		if e == report.Current {
+			prefix = fmt.Sprintf("%s▶%s ", BrightYellow, Reset)
+		}
+
+		barLen := barWidth
+		if maxTotal > minTotal {
+			barLen = max(1, int(float64(barWidth)*(pf.Main(e)-minTotal)/(maxTotal-minTotal)))
 		}
+		bar := strings.Repeat("█", barLen) + strings.Repeat("░", barWidth-barLen)
+
+		extra := ""
+		if s := pf.ExtraSummary(e); s != "" {
+			extra = "  " + s
+		}
+		sb.WriteString(fmt.Sprintf("   %s%s %s%s %s%s %s%s%%%s%s\n",
+			prefix, f.Locale.Date(f.local(e.Time), layout),
+			ratingColor(e.Level), bar, pf.Number(pf.Main(e)), Reset,
+			Dim, f.Locale.Float("%+6.1f", e.Difference), extra, Reset))
 	}
 
 	return sb.String()
@@ -185,8 +468,9 @@ func (f *PrettyFormatter) formatPriceList(prices []models.Price) string {
 func (f *PrettyFormatter) FormatLiveMeasurement(m *models.LiveMeasurement) string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("\n%s%s⚡ Live Power%s\n", Bold, Cyan, Reset))
-	sb.WriteString(fmt.Sprintf("%s%s%s\n\n", Dim, strings.Repeat("─", 14), Reset))
+	title := "⚡ " + f.Locale.T("Live Power")
+	sb.WriteString(fmt.Sprintf("\n%s%s%s%s\n", Bold, Cyan, title, Reset))
+	sb.WriteString(fmt.Sprintf("%s%s%s\n\n", Dim, strings.Repeat("─", len([]rune(title))+2), Reset))
 
 	// Power - big and prominent
 	powerColor := BrightGreen
@@ -200,61 +484,292 @@ func (f *PrettyFormatter) FormatLiveMeasurement(m *models.LiveMeasurement) strin
 
 	// Production if any
 	if m.PowerProduction > 0 {
-		sb.WriteString(fmt.Sprintf("  %s☀️  Production:%s %.0f W\n", Green, Reset, m.PowerProduction))
+		sb.WriteString(fmt.Sprintf("  %s☀️  %s%s %.0f W\n", Green, f.Locale.T("Production:"), Reset, m.PowerProduction))
 	}
 
 	// Today's stats
-	sb.WriteString(fmt.Sprintf("  %s📊 Today%s\n", Bold, Reset))
-	sb.WriteString(fmt.Sprintf("     Consumed: %s%.2f kWh%s\n", BrightCyan, m.AccumulatedConsumption, Reset))
-	sb.WriteString(fmt.Sprintf("     Cost:     %s%.2f %s%s\n", BrightYellow, m.AccumulatedCost, m.Currency, Reset))
+	sb.WriteString(fmt.Sprintf("  %s📊 %s%s\n", Bold, f.Locale.T("Today"), Reset))
+	sb.WriteString(fmt.Sprintf("     %-9s %s%s kWh%s\n", f.Locale.T("Consumed:"), BrightCyan, f.Locale.Float("%.2f", m.AccumulatedConsumption), Reset))
+	sb.WriteString(fmt.Sprintf("     %-9s %s%s %s%s%s\n", f.Locale.T("Cost:"), BrightYellow, f.Locale.Float("%.2f", m.AccumulatedCost), m.Currency, Reset,
+		dimOriginal(m.Conversion, m.AccumulatedCost, f.Locale)))
 
 	// Voltage and current if available
 	if m.VoltagePhase1 > 0 {
-		sb.WriteString(fmt.Sprintf("\n  %s🔌 Grid%s\n", Bold, Reset))
-		sb.WriteString(fmt.Sprintf("     Voltage: %.0f / %.0f / %.0f V\n",
-			m.VoltagePhase1, m.VoltagePhase2, m.VoltagePhase3))
-		sb.WriteString(fmt.Sprintf("     Current: %.1f / %.1f / %.1f A\n",
-			m.CurrentL1, m.CurrentL2, m.CurrentL3))
+		sb.WriteString(fmt.Sprintf("\n  %s🔌 %s%s\n", Bold, f.Locale.T("Grid"), Reset))
+		sb.WriteString(fmt.Sprintf("     %s %.0f / %.0f / %.0f V\n",
+			f.Locale.T("Voltage:"), m.VoltagePhase1, m.VoltagePhase2, m.VoltagePhase3))
+		sb.WriteString(fmt.Sprintf("     %s %s / %s / %s A\n",
+			f.Locale.T("Current:"), f.Locale.Float("%.1f", m.CurrentL1), f.Locale.Float("%.1f", m.CurrentL2), f.Locale.Float("%.1f", m.CurrentL3)))
 	}
 
 	// Timestamp
-	sb.WriteString(fmt.Sprintf("\n  %s%s%s\n", Dim, m.Timestamp.Local().Format("15:04:05"), Reset))
+	sb.WriteString(fmt.Sprintf("\n  %s%s%s\n", Dim, f.local(m.Timestamp).Format("15:04:05"), Reset))
+
+	return sb.String()
+}
+
+// FormatLiveStats formats rolling statistics with a sparkline
+func (f *PrettyFormatter) FormatLiveStats(stats *models.LiveStats) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("  %s📈 %s%s\n", Bold, f.Locale.T("Trend"), Reset))
+	sb.WriteString(fmt.Sprintf("     %-6s %s%.0f%s / %.0f / %.0f W %s(1m / 5m / 15m)%s\n",
+		f.Locale.T("Avg:"), BrightCyan, stats.Avg1m, Reset, stats.Avg5m, stats.Avg15m, Dim, Reset))
+	sb.WriteString(fmt.Sprintf("     %-6s %s%.0f W%s %s%s %s%s\n",
+		f.Locale.T("Peak:"), BrightRed, stats.Peak.Power, Reset, Dim, f.Locale.T("at"), f.local(stats.Peak.Timestamp).Format("15:04:05"), Reset))
+	sb.WriteString(fmt.Sprintf("     %-6s %s%.0f W%s %s%s %s%s\n",
+		f.Locale.T("Min:"), BrightGreen, stats.Min.Power, Reset, Dim, f.Locale.T("at"), f.local(stats.Min.Timestamp).Format("15:04:05"), Reset))
+	if line := Sparkline(stats.Sparkline); line != "" {
+		sb.WriteString(fmt.Sprintf("     %s%s%s %s%s%s\n", BrightCyan, line, Reset, Dim, f.Locale.Sprintf("last %d min", stats.SparkMinutes), Reset))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n  %s⏱  %s%s\n", Bold, f.Locale.T("This hour"), Reset))
+	sb.WriteString(fmt.Sprintf("     %-10s %s kWh\n", f.Locale.T("So far:"), f.Locale.Float("%.2f", stats.HourEnergy)))
+	sb.WriteString(fmt.Sprintf("     %-10s %s%s kWh%s", f.Locale.T("Projected:"), BrightCyan, f.Locale.Float("%.2f", stats.ProjectedHourEnergy), Reset))
+	if stats.Price != nil {
+		sb.WriteString(fmt.Sprintf("  ≈ %s%s %s%s%s", BrightYellow, f.Locale.Float("%.2f", stats.ProjectedHourCost), stats.Price.Currency, Reset,
+			dimOriginal(stats.Conversion, stats.ProjectedHourCost, f.Locale)))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// FormatPeaks formats a capacity tariff peak report with colors
+func (f *PrettyFormatter) FormatPeaks(report *models.PeakReport) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("  %s🏔  %s %s%s\n", Bold, f.Locale.T("Capacity peaks"), report.Month, Reset))
+
+	if len(report.Peaks) == 0 {
+		sb.WriteString(fmt.Sprintf("     %s%s%s\n", Dim, f.Locale.T("No hourly data yet this month"), Reset))
+	}
+	for i, p := range report.Peaks {
+		sb.WriteString(fmt.Sprintf("     %d. %s%s kW%s %s%s%s\n",
+			i+1, BrightCyan, f.Locale.Float("%.2f", p.Power), Reset, Dim, f.Locale.Date(f.local(p.Start), "Mon 02 Jan 15:04"), Reset))
+	}
+
+	sb.WriteString(fmt.Sprintf("     %s %s%s kW%s → %s %s(%s, %.0f %s)%s\n",
+		f.Locale.T("Average:"), Bold, f.Locale.Float("%.2f", report.Average), Reset, f.Locale.Sprintf("step %d", report.Step),
+		Dim, stepRange(report.StepLimit, f.Locale), report.StepPrice, f.Locale.Sprintf("%s/month", report.Currency), Reset))
+
+	if report.Running != nil {
+		color := BrightGreen
+		if report.NewPeak {
+			color = BrightRed
+		}
+		sb.WriteString(fmt.Sprintf("     %s %s%s kW%s %s\n", f.Locale.T("This hour:"), color, f.Locale.Float("%.2f", report.Running.Power), Reset, f.Locale.T("projected")))
+		if report.NewPeak {
+			sb.WriteString(fmt.Sprintf("     %s%s⚠  %s%s\n",
+				Bold, BrightRed, f.Locale.Sprintf("New peak ahead: average %s kW, step %d", f.Locale.Float("%.2f", report.ProjectedAvg), report.ProjectedStep), Reset))
+		}
+	}
+
+	return sb.String()
+}
+
+// FormatCostReport formats a monthly cost report with the most expensive
+// hours and a breakdown by price level
+func (f *PrettyFormatter) FormatCostReport(report *models.CostReport) string {
+	var sb strings.Builder
+
+	title := "🧾 " + f.Locale.T("Cost report") + " " + report.Month
+	sb.WriteString(fmt.Sprintf("\n%s%s%s%s\n", Bold, Cyan, title, Reset))
+	sb.WriteString(fmt.Sprintf("%s%s%s\n\n", Dim, strings.Repeat("─", len([]rune(title))), Reset))
+
+	if report.Hours == 0 {
+		sb.WriteString(fmt.Sprintf("  %s%s%s\n", Dim, f.Locale.T("No consumption this month"), Reset))
+		return sb.String()
+	}
+
+	timingColor := BrightGreen
+	if report.TimingSavings < 0 {
+		timingColor = BrightRed
+	}
+
+	l := f.Locale
+	amount := func(v float64) string { return l.Float("%.2f", v) }
+	sb.WriteString(fmt.Sprintf("  %-8s %s%s kWh%s  %s(%s)%s\n", l.T("Energy"), Bold, amount(report.Energy), Reset, Dim, l.Sprintf("%d hours", report.Hours), Reset))
+	sb.WriteString(fmt.Sprintf("  %-8s %s%s %s%s%s\n", l.T("Cost"), Bold, amount(report.Cost), report.Currency, Reset, dimOriginal(report.Conversion, report.Cost, l)))
+	if report.GridCost > 0 {
+		sb.WriteString(fmt.Sprintf("           %s%s%s\n", Dim, l.Sprintf("incl. %s %s grid tariff and fees", amount(report.GridCost), report.Currency), Reset))
+	}
+	if report.Subsidy != 0 {
+		sb.WriteString(fmt.Sprintf("           %s%s%s\n", Dim, l.Sprintf("after %s %s estimated subsidy", amount(report.Subsidy), report.Currency), Reset))
+	}
+	if report.FixedFee > 0 {
+		sb.WriteString(fmt.Sprintf("  %-8s %s %s  %s(%s)%s\n", l.T("Fee"), amount(report.FixedFee), report.Currency, Dim, l.T("fixed monthly"), Reset))
+		sb.WriteString(fmt.Sprintf("  %-8s %s%s %s%s%s\n", l.T("Total"), Bold, amount(report.Total), report.Currency, Reset, dimOriginal(report.Conversion, report.Total, l)))
+	}
+	pf := priceFormat(f.Prices, l)
+	sb.WriteString(fmt.Sprintf("  %-8s %s  %s(%s)%s\n", l.T("Paid"), pf.Price(report.AveragePrice, report.Currency), Dim, l.T("volume-weighted"), Reset))
+	sb.WriteString(fmt.Sprintf("  %-8s %s  %s(%s)%s\n", l.T("Spot"), pf.Price(report.SpotAverage, report.Currency), Dim, l.T("monthly average"), Reset))
+	sb.WriteString(fmt.Sprintf("  %-8s %s%s%s\n\n", l.T("Timing"), timingColor, timingSummary(report, l), Reset))
+
+	sb.WriteString(fmt.Sprintf("  %s%s%s\n", Bold, l.T("Most expensive hours"), Reset))
+	for i, h := range report.TopHours {
+		sb.WriteString(fmt.Sprintf("   %d. %s  %s kWh × %s = %s%s %s%s  %s\n",
+			i+1, l.Date(f.local(h.Start), "Mon 02 Jan 15:04"), amount(h.Energy), pf.Price(h.Price, report.Currency), Bold, amount(h.Cost), report.Currency, Reset, levelLabel(h.Level, l)))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n  %s%s%s\n", Bold, l.T("By price level"), Reset))
+	for _, b := range report.Levels {
+		name := fmt.Sprintf("%s%-14s%s", Dim, costLevelName(b.Level, l), Reset)
+		if b.Level.Known() {
+			name = fmt.Sprintf("%s● %-12s%s", PriceColor(b.Level), l.T(b.Level.Label()), Reset)
+		}
+		sb.WriteString(fmt.Sprintf("   %s %4d h  %s kWh  %s%%  %s %s\n",
+			name, b.Hours, l.Float("%8.2f", b.Energy), l.Float("%5.1f", b.Share), amount(b.Cost), report.Currency))
+	}
+	sb.WriteString(dimConversionNote(report.Conversion, l))
+
+	return sb.String()
+}
+
+// FormatAlerts formats alerts as a warning panel
+func (f *PrettyFormatter) FormatAlerts(alerts []models.Alert) string {
+	if len(alerts) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("  %s%s🚨 %s%s\n", Bold, BrightRed, f.Locale.T("Alerts"), Reset))
+	for _, a := range alerts {
+		color := BrightRed
+		if a.State == models.AlertCleared {
+			color = Dim
+		}
+		sb.WriteString(fmt.Sprintf("     %s%s%s\n", color, a.Message, Reset))
+	}
+
+	return sb.String()
+}
+
+// FormatEvent formats a notification event as a single log line
+func (f *PrettyFormatter) FormatEvent(event *models.Event) string {
+	return fmt.Sprintf("%s%s%s  %s%-15s%s %s",
+		Dim, f.local(event.Timestamp).Format("15:04:05"), Reset,
+		BrightCyan, event.Type, Reset, event.Message)
+}
+
+// FormatPushResult formats a push notification result
+func (f *PrettyFormatter) FormatPushResult(result *models.PushNotificationResult) string {
+	if !result.Successful {
+		return fmt.Sprintf("%s✘ %s%s", BrightRed, f.Locale.T("Push notification was not sent"), Reset)
+	}
+	return fmt.Sprintf("%s✔ %s%s %s",
+		BrightGreen, f.Locale.T("Push notification sent"), Reset,
+		f.Locale.Sprintf("to %s device(s)", fmt.Sprintf("%s%d%s", Bold, result.PushedToNumberOfDevices, Reset)))
+}
+
+// FormatDiagnostics formats a doctor report with pass/fail markers
+func (f *PrettyFormatter) FormatDiagnostics(report *models.DiagnosticReport) string {
+	var sb strings.Builder
+
+	title := "🩺 " + f.Locale.T("Diagnostics")
+	sb.WriteString(fmt.Sprintf("\n%s%s%s%s\n", Bold, Cyan, title, Reset))
+	sb.WriteString(fmt.Sprintf("%s%s%s\n\n", Dim, strings.Repeat("─", len([]rune(title))+2), Reset))
+
+	for _, c := range report.Checks {
+		sb.WriteString(fmt.Sprintf("  %s %s", checkMarker(c.Status), c.Name))
+		if c.Detail != "" {
+			sb.WriteString(fmt.Sprintf("  %s%s%s", Dim, c.Detail, Reset))
+		}
+		sb.WriteString("\n")
+	}
+
+	if report.Failed() {
+		sb.WriteString(fmt.Sprintf("\n  %s%s%s%s\n", Bold, BrightRed, f.Locale.T("Some checks failed"), Reset))
+	} else {
+		sb.WriteString(fmt.Sprintf("\n  %s%s%s%s\n", Bold, BrightGreen, f.Locale.T("All checks passed"), Reset))
+	}
 
 	return sb.String()
 }
 
 // Helper functions
 
-func priceColor(level string) string {
+func checkMarker(status string) string {
+	switch status {
+	case models.CheckPass:
+		return fmt.Sprintf("%s✔%s", BrightGreen, Reset)
+	case models.CheckWarn:
+		return fmt.Sprintf("%s!%s", BrightYellow, Reset)
+	case models.CheckFail:
+		return fmt.Sprintf("%s✘%s", BrightRed, Reset)
+	default:
+		return fmt.Sprintf("%s-%s", Dim, Reset)
+	}
+}
+
+// dimOriginal is originalAmount, dimmed
+func dimOriginal(conv *models.Conversion, amount float64, l *i18n.Locale) string {
+	return dimmed(originalAmount(conv, amount, l))
+}
+
+// dimmed dims s; it is empty when s is
+func dimmed(s string) string {
+	if s == "" {
+		return ""
+	}
+	return Dim + s + Reset
+}
+
+// dimConversionNote is conversionNote on its own dimmed line
+func dimConversionNote(conv *models.Conversion, l *i18n.Locale) string {
+	if conv == nil {
+		return ""
+	}
+	return fmt.Sprintf("\n  %s%s%s\n", Dim, conversionNote(conv, l), Reset)
+}
+
+// PriceColor is the ANSI color for a price level
+func PriceColor(level models.PriceLevel) string {
 	switch level {
-	case "VERY_CHEAP":
+	case models.PriceLevelVeryCheap:
 		return BrightGreen
-	case "CHEAP":
+	case models.PriceLevelCheap:
 		return Green
-	case "NORMAL":
+	case models.PriceLevelNormal:
 		return Yellow
-	case "EXPENSIVE":
+	case models.PriceLevelExpensive:
 		return Red
-	case "VERY_EXPENSIVE":
+	case models.PriceLevelVeryExpensive:
 		return BrightRed
 	default:
 		return Reset
 	}
 }
 
-func levelLabel(level string) string {
+func ratingColor(level models.PriceRatingLevel) string {
 	switch level {
-	case "VERY_CHEAP":
-		return fmt.Sprintf("%s● Very Cheap%s", BrightGreen, Reset)
-	case "CHEAP":
-		return fmt.Sprintf("%s● Cheap%s", Green, Reset)
-	case "NORMAL":
-		return fmt.Sprintf("%s● Normal%s", Yellow, Reset)
-	case "EXPENSIVE":
-		return fmt.Sprintf("%s● Expensive%s", Red, Reset)
-	case "VERY_EXPENSIVE":
-		return fmt.Sprintf("%s● Very Expensive%s", BrightRed, Reset)
+	case models.PriceRatingLevelLow:
+		return Green
+	case models.PriceRatingLevelNormal:
+		return Yellow
+	case models.PriceRatingLevelHigh:
+		return Red
 	default:
-		return level
+		return Reset
+	}
+}
+
+func ratingLabel(level models.PriceRatingLevel, l *i18n.Locale) string {
+	if !level.Known() {
+		return fmt.Sprintf("%s%s%s", Dim, level.Label(), Reset)
+	}
+	return fmt.Sprintf("%s● %s%s", ratingColor(level), l.T(level.Label()), Reset)
+}
+
+func levelLabel(level models.PriceLevel, l *i18n.Locale) string {
+	if !level.Known() {
+		return fmt.Sprintf("%s%s%s", Dim, level.Label(), Reset)
 	}
+	return fmt.Sprintf("%s● %s%s", PriceColor(level), l.T(level.Label()), Reset)
+}
+
+// local returns t in the formatter's timezone
+func (f *PrettyFormatter) local(t time.Time) time.Time {
+	return inLocation(t, f.Location)
 }
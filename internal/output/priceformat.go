@@ -0,0 +1,123 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/i18n"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+// PriceFormat controls how the pretty and markdown formats print prices per
+// kWh. JSON output always carries the raw values.
+type PriceFormat struct {
+	// MinorUnit prints øre, öre or cents instead of NOK, SEK or EUR
+	MinorUnit bool
+	Decimals  int
+
+	// Components are the parts of each price to show; the first is the
+	// main value, used for bars and highlighting
+	Components []string
+
+	// locale is the formatter's, set by priceFormat
+	locale *i18n.Locale
+}
+
+// DefaultPriceFormat prints totals in major units with two decimals
+var DefaultPriceFormat = PriceFormat{Decimals: 2, Components: []string{models.PriceTotal}}
+
+// minorUnits names the hundredth of a currency
+var minorUnits = map[string]string{
+	"NOK": "øre",
+	"DKK": "øre",
+	"SEK": "öre",
+	"EUR": "cent",
+	"USD": "cent",
+}
+
+// components is implemented by prices and price rating entries
+type components interface {
+	Component(name string) float64
+}
+
+// priceFormat returns a copy of pf, or of the default format if pf is nil,
+// that formats numbers for locale
+func priceFormat(pf *PriceFormat, locale *i18n.Locale) *PriceFormat {
+	if pf == nil {
+		pf = &DefaultPriceFormat
+	}
+	localized := *pf
+	localized.locale = locale
+	return &localized
+}
+
+// WithLocale returns a copy of pf, or of the default format if pf is nil,
+// that formats numbers for locale
+func (pf *PriceFormat) WithLocale(locale *i18n.Locale) *PriceFormat {
+	return priceFormat(pf, locale)
+}
+
+// Number formats a per-kWh amount in the configured unit, without the unit
+func (pf *PriceFormat) Number(amount float64) string {
+	if pf.MinorUnit {
+		amount *= 100
+	}
+	return pf.locale.Float(fmt.Sprintf("%%.%df", pf.Decimals), amount)
+}
+
+// Unit returns the unit prices are shown in, e.g. "NOK" or "øre". Minor
+// units of unknown currencies are written as "1/100 XYZ".
+func (pf *PriceFormat) Unit(currency string) string {
+	if !pf.MinorUnit {
+		return currency
+	}
+	if unit, ok := minorUnits[currency]; ok {
+		return unit
+	}
+	return "1/100 " + currency
+}
+
+// Price formats a per-kWh amount with its unit, e.g. "1.23 NOK/kWh"
+func (pf *PriceFormat) Price(amount float64, currency string) string {
+	return pf.Number(amount) + " " + pf.Unit(currency) + "/kWh"
+}
+
+// Original shows a converted per-kWh amount in its original currency, e.g.
+// " (1.23 NOK/kWh)"; it is empty without a conversion
+func (pf *PriceFormat) Original(conv *models.Conversion, amount float64) string {
+	if conv == nil {
+		return ""
+	}
+	return " (" + pf.Price(conv.Original(amount), conv.From) + ")"
+}
+
+// Main returns the component of p shown as its value
+func (pf *PriceFormat) Main(p components) float64 {
+	return p.Component(pf.MainComponent())
+}
+
+// MainComponent is the first configured component
+func (pf *PriceFormat) MainComponent() string {
+	if len(pf.Components) == 0 {
+		return models.PriceTotal
+	}
+	return pf.Components[0]
+}
+
+// Extra returns the components after the main one
+func (pf *PriceFormat) Extra() []string {
+	if len(pf.Components) < 2 {
+		return nil
+	}
+	return pf.Components[1:]
+}
+
+// ExtraSummary describes the components after the main one, e.g.
+// "energy 0.98 · tax 0.25"; it is empty when only one is shown
+func (pf *PriceFormat) ExtraSummary(p components) string {
+	var parts []string
+	for _, c := range pf.Extra() {
+		parts = append(parts, pf.locale.T(c)+" "+pf.Number(p.Component(c)))
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,122 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+// Style controls the escape codes and glyphs in human-readable output
+type Style struct {
+	// Color keeps ANSI colors and attributes; without it they are stripped
+	Color bool
+	// ASCII replaces emoji, box-drawing and block glyphs with plain ASCII
+	ASCII bool
+}
+
+// ansiPattern matches ANSI control sequences such as colors
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// asciiGlyphs maps the glyphs used in output to ASCII. Markers that carry
+// meaning get a stand-in; decorative emoji are dropped by emojiPattern.
+var asciiGlyphs = strings.NewReplacer(
+	"⚠️", "!", "⚠", "!", "🚨", "!",
+	"✔", "+", "✘", "x",
+	"●", "*", "○", "o", "▶", ">", "▲", "^",
+	"→", "->", "←", "<-", "≈", "~", "…", "...",
+	"−", "-", "–", "-", "—", "-", "×", "x", "²", "2",
+	" · ", ", ", "·", "-",
+	"─", "-", "│", "|", "┤", "|", "┌", "+", "┐", "+", "└", "+", "┘", "+",
+	"▁", "_", "▂", ".", "▃", ":", "▄", "-", "▅", "=", "▆", "+", "▇", "*", "█", "#",
+	"░", ".", "▒", "+", "▓", "=",
+)
+
+// emojiPattern matches a decorative emoji with the spaces after it
+var emojiPattern = regexp.MustCompile(`[\x{1F300}-\x{1FAFF}\x{2600}-\x{26FF}\x{23E9}-\x{23FA}]\x{FE0F}? {0,2}`)
+
+// Apply strips colors and replaces glyphs in s as the style asks
+func (s Style) Apply(text string) string {
+	if !s.Color {
+		text = ansiPattern.ReplaceAllString(text, "")
+	}
+	if s.ASCII {
+		text = emojiPattern.ReplaceAllString(asciiGlyphs.Replace(text), "")
+	}
+	return text
+}
+
+// styled wraps f so its output follows style. Styles that change nothing
+// return f as is.
+func styled(f Formatter, style Style) Formatter {
+	if style.Color && !style.ASCII {
+		return f
+	}
+	return &styledFormatter{f: f, style: style}
+}
+
+// styledFormatter applies a Style to another formatter's output
+type styledFormatter struct {
+	f     Formatter
+	style Style
+}
+
+func (s *styledFormatter) FormatHome(home *models.HomeResponse) string {
+	return s.style.Apply(s.f.FormatHome(home))
+}
+
+func (s *styledFormatter) FormatHomes(homes []models.HomeResponse) string {
+	return s.style.Apply(s.f.FormatHomes(homes))
+}
+
+func (s *styledFormatter) FormatPrices(prices *models.PriceInfo, homeID string) string {
+	return s.style.Apply(s.f.FormatPrices(prices, homeID))
+}
+
+func (s *styledFormatter) FormatPriceChart(prices *models.PriceInfo, width int) string {
+	return s.style.Apply(s.f.FormatPriceChart(prices, width))
+}
+
+func (s *styledFormatter) FormatPriceRating(report *models.PriceRatingReport) string {
+	return s.style.Apply(s.f.FormatPriceRating(report))
+}
+
+func (s *styledFormatter) FormatPriceRange(report *models.PriceRangeReport) string {
+	return s.style.Apply(s.f.FormatPriceRange(report))
+}
+
+func (s *styledFormatter) FormatPriceBreakdown(b *models.PriceBreakdown) string {
+	return s.style.Apply(s.f.FormatPriceBreakdown(b))
+}
+
+func (s *styledFormatter) FormatLiveMeasurement(m *models.LiveMeasurement) string {
+	return s.style.Apply(s.f.FormatLiveMeasurement(m))
+}
+
+func (s *styledFormatter) FormatLiveStats(stats *models.LiveStats) string {
+	return s.style.Apply(s.f.FormatLiveStats(stats))
+}
+
+func (s *styledFormatter) FormatPeaks(report *models.PeakReport) string {
+	return s.style.Apply(s.f.FormatPeaks(report))
+}
+
+func (s *styledFormatter) FormatCostReport(report *models.CostReport) string {
+	return s.style.Apply(s.f.FormatCostReport(report))
+}
+
+func (s *styledFormatter) FormatAlerts(alerts []models.Alert) string {
+	return s.style.Apply(s.f.FormatAlerts(alerts))
+}
+
+func (s *styledFormatter) FormatEvent(event *models.Event) string {
+	return s.style.Apply(s.f.FormatEvent(event))
+}
+
+func (s *styledFormatter) FormatPushResult(result *models.PushNotificationResult) string {
+	return s.style.Apply(s.f.FormatPushResult(result))
+}
+
+func (s *styledFormatter) FormatDiagnostics(report *models.DiagnosticReport) string {
+	return s.style.Apply(s.f.FormatDiagnostics(report))
+}
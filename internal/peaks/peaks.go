@@ -0,0 +1,187 @@
+// Package peaks tracks capacity tariff (effekttariff) peaks: the average of
+// the highest hourly power peaks in a month, each on a different day.
+package peaks
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/config"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+// Count is the number of distinct-day peaks averaged by Norwegian grid companies
+const Count = 3
+
+// Hour is the energy used in one clock hour. Energy in kWh over one hour
+// equals the hour's average power in kW.
+type Hour struct {
+	Start  time.Time
+	Energy float64
+}
+
+// FromConsumption converts hourly consumption nodes to hours
+func FromConsumption(nodes []models.Consumption) []Hour {
+	hours := make([]Hour, 0, len(nodes))
+	for _, n := range nodes {
+		hours = append(hours, Hour{Start: n.From, Energy: n.Consumption})
+	}
+	return hours
+}
+
+// MonthStart returns midnight on the first day of t's month in loc
+func MonthStart(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+}
+
+// HourStart returns the start of the clock hour containing t in loc. It
+// subtracts the minutes instead of building the time from the clock hour,
+// which is ambiguous in the hour repeated when daylight saving time ends.
+func HourStart(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return t.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+}
+
+// Top returns the n highest hours, at most one per day (in loc), highest first
+func Top(hours []Hour, n int, loc *time.Location) []models.Peak {
+	byDay := make(map[string]Hour)
+	for _, h := range hours {
+		day := h.Start.In(loc).Format("2006-01-02")
+		if best, ok := byDay[day]; !ok || h.Energy > best.Energy {
+			byDay[day] = h
+		}
+	}
+
+	peaks := make([]models.Peak, 0, len(byDay))
+	for _, h := range byDay {
+		peaks = append(peaks, models.Peak{Start: h.Start, Power: h.Energy})
+	}
+	sort.Slice(peaks, func(i, j int) bool {
+		if peaks[i].Power != peaks[j].Power {
+			return peaks[i].Power > peaks[j].Power
+		}
+		return peaks[i].Start.Before(peaks[j].Start)
+	})
+
+	if len(peaks) > n {
+		peaks = peaks[:n]
+	}
+	return peaks
+}
+
+// Average returns the mean power of peaks
+func Average(peaks []models.Peak) float64 {
+	if len(peaks) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, p := range peaks {
+		sum += p.Power
+	}
+	return sum / float64(len(peaks))
+}
+
+// Step returns the 1-based step for avg and the step itself
+func Step(avg float64, steps []config.CapacityStep) (int, config.CapacityStep) {
+	for i, step := range steps {
+		if step.LimitKW == 0 || avg < step.LimitKW {
+			return i + 1, step
+		}
+	}
+	if len(steps) == 0 {
+		return 0, config.CapacityStep{}
+	}
+	return len(steps), steps[len(steps)-1]
+}
+
+// BuildReport computes the peak report for the month starting at month.
+// running, if not nil, is the projected current hour.
+func BuildReport(month time.Time, hours []Hour, running *Hour, steps []config.CapacityStep, loc *time.Location) *models.PeakReport {
+	top := Top(hours, Count, loc)
+	avg := Average(top)
+	step, s := Step(avg, steps)
+
+	report := &models.PeakReport{
+		Type:      "peaks",
+		Month:     month.In(loc).Format("2006-01"),
+		Peaks:     top,
+		Average:   avg,
+		Step:      step,
+		StepLimit: s.LimitKW,
+		StepPrice: s.Price,
+	}
+
+	if running != nil {
+		report.Running = &models.Peak{Start: running.Start, Power: running.Energy}
+
+		// Replace any completed sample of the same hour with the projection
+		withRunning := make([]Hour, 0, len(hours)+1)
+		for _, h := range hours {
+			if !h.Start.Equal(running.Start) {
+				withRunning = append(withRunning, h)
+			}
+		}
+		withRunning = append(withRunning, *running)
+
+		projected := Top(withRunning, Count, loc)
+		for _, p := range projected {
+			if p.Start.Equal(running.Start) {
+				report.NewPeak = true
+				report.ProjectedAvg = Average(projected)
+				report.ProjectedStep, _ = Step(report.ProjectedAvg, steps)
+				break
+			}
+		}
+	}
+
+	return report
+}
+
+// Tracker follows hourly energy from the live stream on top of the
+// month's history
+type Tracker struct {
+	loc     *time.Location
+	steps   []config.CapacityStep
+	month   time.Time
+	hours   []Hour
+	current Hour
+}
+
+// NewTracker creates a tracker seeded with history for the current month
+func NewTracker(history []Hour, steps []config.CapacityStep, loc *time.Location) *Tracker {
+	return &Tracker{
+		loc:   loc,
+		steps: steps,
+		month: MonthStart(time.Now(), loc),
+		hours: history,
+	}
+}
+
+// Observe records a live measurement. AccumulatedConsumptionLastHour is
+// the running hour's energy, which becomes final when the hour changes.
+func (t *Tracker) Observe(m *models.LiveMeasurement) {
+	start := HourStart(m.Timestamp, t.loc)
+
+	if !start.Equal(t.current.Start) {
+		if !t.current.Start.IsZero() {
+			t.hours = append(t.hours, t.current)
+		}
+		if month := MonthStart(start, t.loc); !month.Equal(t.month) {
+			t.month = month
+			t.hours = nil
+		}
+		t.current = Hour{Start: start}
+	}
+	t.current.Energy = m.AccumulatedConsumptionLastHour
+}
+
+// Report builds the month's report, treating projected (kWh) as the
+// expected energy of the running hour
+func (t *Tracker) Report(projected float64) *models.PeakReport {
+	var running *Hour
+	if !t.current.Start.IsZero() {
+		running = &Hour{Start: t.current.Start, Energy: projected}
+	}
+	return BuildReport(t.month, t.hours, running, t.steps, t.loc)
+}
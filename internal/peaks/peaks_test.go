@@ -0,0 +1,110 @@
+package peaks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/config"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+func hour(day, h int, kwh float64) Hour {
+	return Hour{Start: time.Date(2025, 9, day, h, 0, 0, 0, time.UTC), Energy: kwh}
+}
+
+func TestTop_DistinctDays(t *testing.T) {
+	hours := []Hour{
+		hour(1, 8, 6.0),
+		hour(1, 18, 7.5), // highest, but day 1 may only count once
+		hour(2, 17, 5.0),
+		hour(3, 7, 4.0),
+		hour(4, 19, 3.0),
+	}
+
+	top := Top(hours, Count, time.UTC)
+
+	if len(top) != 3 {
+		t.Fatalf("len(Top) = %d, want 3", len(top))
+	}
+	want := []float64{7.5, 5.0, 4.0}
+	for i, p := range top {
+		if p.Power != want[i] {
+			t.Errorf("Top[%d] = %v, want %v", i, p.Power, want[i])
+		}
+	}
+	if avg := Average(top); avg < 5.49 || avg > 5.51 {
+		t.Errorf("Average = %v, want 5.5", avg)
+	}
+}
+
+func TestStep(t *testing.T) {
+	steps := config.DefaultCapacitySteps
+
+	tests := []struct {
+		avg  float64
+		want int
+	}{
+		{1.5, 1},
+		{2.0, 2},
+		{5.5, 3},
+		{99, len(steps)},
+	}
+
+	for _, tt := range tests {
+		if got, _ := Step(tt.avg, steps); got != tt.want {
+			t.Errorf("Step(%v) = %d, want %d", tt.avg, got, tt.want)
+		}
+	}
+}
+
+func TestBuildReport_WarnsOnNewPeak(t *testing.T) {
+	month := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	hours := []Hour{hour(1, 18, 6.0), hour(2, 17, 5.0), hour(3, 7, 4.0)}
+
+	quiet := hour(4, 12, 3.0)
+	if r := BuildReport(month, hours, &quiet, config.DefaultCapacitySteps, time.UTC); r.NewPeak {
+		t.Error("NewPeak = true for a projection below the top peaks")
+	}
+
+	busy := hour(4, 12, 8.0)
+	r := BuildReport(month, hours, &busy, config.DefaultCapacitySteps, time.UTC)
+	if !r.NewPeak {
+		t.Fatal("NewPeak = false for a projection above the top peaks")
+	}
+	if r.ProjectedAvg <= r.Average {
+		t.Errorf("ProjectedAvg = %v, want above Average %v", r.ProjectedAvg, r.Average)
+	}
+}
+
+func TestTracker_FinalizesHours(t *testing.T) {
+	tr := NewTracker(nil, config.DefaultCapacitySteps, time.UTC)
+	start := time.Date(2025, 9, 2, 10, 0, 0, 0, time.UTC)
+
+	tr.Observe(&models.LiveMeasurement{Timestamp: start.Add(10 * time.Minute), AccumulatedConsumptionLastHour: 1.0})
+	tr.Observe(&models.LiveMeasurement{Timestamp: start.Add(59 * time.Minute), AccumulatedConsumptionLastHour: 4.2})
+	tr.Observe(&models.LiveMeasurement{Timestamp: start.Add(61 * time.Minute), AccumulatedConsumptionLastHour: 0.1})
+
+	r := tr.Report(0.5)
+	if len(r.Peaks) != 1 || r.Peaks[0].Power != 4.2 {
+		t.Errorf("Peaks = %+v, want one finalized hour of 4.2 kW", r.Peaks)
+	}
+	if r.Running == nil || !r.Running.Start.Equal(start.Add(time.Hour)) {
+		t.Errorf("Running = %+v, want the 11:00 hour", r.Running)
+	}
+}
+
+func TestHourStart_DST(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+	// 02:00-03:00 happens twice on 2025-10-26; the second one is 01:30 UTC
+	second := time.Date(2025, 10, 26, 1, 30, 0, 0, time.UTC)
+	if got := HourStart(second, oslo); !got.Equal(time.Date(2025, 10, 26, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("HourStart(second 02:30) = %v, want 01:00 UTC", got.UTC())
+	}
+	first := time.Date(2025, 10, 26, 0, 30, 0, 0, time.UTC)
+	if got := HourStart(first, oslo); !got.Equal(time.Date(2025, 10, 26, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("HourStart(first 02:30) = %v, want 00:00 UTC", got.UTC())
+	}
+}
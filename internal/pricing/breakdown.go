@@ -0,0 +1,53 @@
+package pricing
+
+import (
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+// Breakdown builds an energy and tax breakdown of today's and tomorrow's
+// prices, averaging each day in loc
+func Breakdown(info *models.PriceInfo, loc *time.Location) *models.PriceBreakdown {
+	b := &models.PriceBreakdown{
+		Today:      info.Today,
+		Tomorrow:   info.Tomorrow,
+		Days:       []models.BreakdownDay{},
+		Conversion: info.Conversion,
+	}
+
+	for _, prices := range [][]models.Price{info.Today, info.Tomorrow} {
+		for i := range prices {
+			p := &prices[i]
+			if b.Currency == "" {
+				b.Currency = p.Currency
+			}
+
+			date := p.StartsAt.In(loc).Format("2006-01-02")
+			n := len(b.Days)
+			if n == 0 || b.Days[n-1].Date != date {
+				b.Days = append(b.Days, models.BreakdownDay{Date: date})
+				n++
+			}
+			day := &b.Days[n-1]
+			day.Count++
+			day.Average += p.Payable()
+			day.Energy += p.Energy
+			day.Tax += p.Tax
+			day.Other += p.Other()
+		}
+	}
+
+	for i := range b.Days {
+		day := &b.Days[i]
+		count := float64(day.Count)
+		day.Average /= count
+		day.Energy /= count
+		day.Tax /= count
+		day.Other /= count
+		if day.Average > 0 {
+			day.TaxShare = day.Tax / day.Average * 100
+		}
+	}
+	return b
+}
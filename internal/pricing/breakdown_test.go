@@ -0,0 +1,75 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+func TestBreakdown(t *testing.T) {
+	loc := time.UTC
+	start := time.Date(2025, 1, 1, 22, 0, 0, 0, loc)
+	info := &models.PriceInfo{
+		Today: []models.Price{
+			{StartsAt: start, Total: 1.0, Energy: 0.8, Tax: 0.2, Currency: "NOK"},
+			{StartsAt: start.Add(time.Hour), Total: 3.0, Energy: 2.4, Tax: 0.6, Currency: "NOK"},
+		},
+		Tomorrow: []models.Price{
+			// With a 0.5 grid tariff applied
+			{StartsAt: start.Add(2 * time.Hour), Total: 1.0, Energy: 0.8, Tax: 0.2, Grid: 0.5, Currency: "NOK"},
+		},
+	}
+
+	info.Tomorrow[0].SetEffective()
+
+	b := Breakdown(info, loc)
+
+	if b.Currency != "NOK" || len(b.Days) != 2 {
+		t.Fatalf("Breakdown() = %+v, want NOK and 2 days", b)
+	}
+	today := b.Days[0]
+	if today.Date != "2025-01-01" || today.Count != 2 || today.Average != 2.0 || today.Tax != 0.4 {
+		t.Errorf("Days[0] = %+v", today)
+	}
+	if math.Abs(today.TaxShare-20) > 1e-9 || math.Abs(today.Other) > 1e-9 {
+		t.Errorf("Days[0] TaxShare = %v, Other = %v, want 20 and 0", today.TaxShare, today.Other)
+	}
+	tomorrow := b.Days[1]
+	if math.Abs(tomorrow.Other-0.5) > 1e-9 || math.Abs(tomorrow.TaxShare-0.2/1.5*100) > 1e-9 {
+		t.Errorf("Days[1] = %+v, want Other 0.5", tomorrow)
+	}
+}
+
+func TestBreakdown_Empty(t *testing.T) {
+	b := Breakdown(&models.PriceInfo{}, time.UTC)
+	if b.Days == nil || len(b.Days) != 0 {
+		t.Errorf("Days = %v, want empty", b.Days)
+	}
+}
+
+func TestBreakdown_DSTDays(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+
+	for _, tt := range []struct {
+		date  string
+		hours int
+	}{{"2025-03-30", 23}, {"2025-10-26", 25}} {
+		start, _ := time.ParseInLocation("2006-01-02", tt.date, oslo)
+		info := &models.PriceInfo{}
+		for at := start; at.Before(start.AddDate(0, 0, 1)); at = at.Add(time.Hour) {
+			info.Today = append(info.Today, models.Price{StartsAt: at, Total: 1, Currency: "NOK"})
+		}
+
+		if b := Breakdown(info, oslo); len(b.Days) != 1 || b.Days[0].Date != tt.date || b.Days[0].Count != tt.hours {
+			t.Errorf("%s: Days = %+v, want one day of %d hours", tt.date, b.Days, tt.hours)
+		}
+		if b := Breakdown(info, time.UTC); len(b.Days) != 2 {
+			t.Errorf("%s in UTC: Days = %+v, want 2", tt.date, b.Days)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Package pricing aggregates and adjusts electricity prices.
+package pricing
+
+import (
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+// Summarize builds a range report for prices, grouping days in loc. from
+// and to are the requested range and are copied into the report.
+func Summarize(prices []models.Price, from, to time.Time, resolution string, loc *time.Location) *models.PriceRangeReport {
+	report := &models.PriceRangeReport{
+		From:       from,
+		To:         to,
+		Resolution: resolution,
+		Count:      len(prices),
+		Days:       []models.PriceDay{},
+	}
+	if len(prices) == 0 {
+		return report
+	}
+	report.Currency = prices[0].Currency
+
+	sum := 0.0
+	for i := range prices {
+		p := &prices[i]
+		sum += p.Payable()
+		if report.Min == nil || p.Payable() < report.Min.Payable() {
+			report.Min = p
+		}
+		if report.Max == nil || p.Payable() > report.Max.Payable() {
+			report.Max = p
+		}
+
+		date := p.StartsAt.In(loc).Format("2006-01-02")
+		n := len(report.Days)
+		if n == 0 || report.Days[n-1].Date != date {
+			report.Days = append(report.Days, models.PriceDay{Date: date, Min: p.Payable(), Max: p.Payable()})
+			n++
+		}
+		day := &report.Days[n-1]
+		day.Average = (day.Average*float64(day.Count) + p.Payable()) / float64(day.Count+1)
+		day.Count++
+		day.Min = min(day.Min, p.Payable())
+		day.Max = max(day.Max, p.Payable())
+	}
+	report.Average = sum / float64(len(prices))
+
+	return report
+}
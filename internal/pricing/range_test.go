@@ -0,0 +1,51 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+func TestSummarize(t *testing.T) {
+	loc := time.UTC
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, loc)
+	prices := []models.Price{
+		{StartsAt: start, Total: 1.0, Currency: "NOK"},
+		{StartsAt: start.Add(time.Hour), Total: 3.0},
+		{StartsAt: start.Add(24 * time.Hour), Total: 0.5},
+		{StartsAt: start.Add(25 * time.Hour), Total: 1.5},
+	}
+
+	r := Summarize(prices, start, start.AddDate(0, 0, 2), models.PriceResolutionHourly, loc)
+
+	if r.Count != 4 || r.Currency != "NOK" {
+		t.Errorf("Count = %d, Currency = %q", r.Count, r.Currency)
+	}
+	if r.Average != 1.5 {
+		t.Errorf("Average = %v, want 1.5", r.Average)
+	}
+	if r.Min.Total != 0.5 || r.Max.Total != 3.0 {
+		t.Errorf("Min = %v, Max = %v, want 0.5 and 3.0", r.Min.Total, r.Max.Total)
+	}
+
+	want := []models.PriceDay{
+		{Date: "2025-01-01", Count: 2, Average: 2.0, Min: 1.0, Max: 3.0},
+		{Date: "2025-01-02", Count: 2, Average: 1.0, Min: 0.5, Max: 1.5},
+	}
+	if len(r.Days) != len(want) {
+		t.Fatalf("Days = %+v, want %d days", r.Days, len(want))
+	}
+	for i := range want {
+		if r.Days[i] != want[i] {
+			t.Errorf("Days[%d] = %+v, want %+v", i, r.Days[i], want[i])
+		}
+	}
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	r := Summarize(nil, time.Now(), time.Now(), models.PriceResolutionDaily, time.UTC)
+	if r.Count != 0 || r.Min != nil || len(r.Days) != 0 {
+		t.Errorf("report = %+v, want an empty summary", r)
+	}
+}
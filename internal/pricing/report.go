@@ -0,0 +1,106 @@
+package pricing
+
+import (
+	"sort"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+// DefaultTopHours is the number of most expensive hours in a cost report
+const DefaultTopHours = 5
+
+// BuildCostReport reconciles hourly consumption with the hourly prices of
+// the same month. Prices provide the spot average, each hour's level and,
+// when a tariff or subsidy was applied to them, the grid charge, fees and
+// subsidy; hours without a matching price are reported with no level.
+// fixedFee is the tariff's monthly fee, if any.
+func BuildCostReport(month string, nodes []models.Consumption, prices []models.Price, fixedFee float64, top int) *models.CostReport {
+	report := &models.CostReport{
+		Month:    month,
+		FixedFee: fixedFee,
+		TopHours: []models.CostHour{},
+		Levels:   []models.LevelBreakdown{},
+	}
+
+	byStart := make(map[int64]models.Price, len(prices))
+	spotSum := 0.0
+	for _, p := range prices {
+		byStart[p.StartsAt.Unix()] = p
+		spotSum += p.Total
+		if report.Currency == "" {
+			report.Currency = p.Currency
+		}
+	}
+	if len(prices) > 0 {
+		report.SpotAverage = spotSum / float64(len(prices))
+	}
+
+	var hours []models.CostHour
+	spotCost := 0.0
+	byLevel := make(map[models.PriceLevel]*models.LevelBreakdown)
+	for _, n := range nodes {
+		cost := n.Cost
+		if cost == 0 {
+			cost = n.Consumption * n.UnitPrice
+		}
+		price := byStart[n.From.Unix()]
+		tariff := price.Grid + price.Fees
+		adjust := tariff - price.Subsidy
+		h := models.CostHour{
+			Start:  n.From,
+			Energy: n.Consumption,
+			Price:  n.UnitPrice + adjust,
+			Cost:   cost + n.Consumption*adjust,
+			Level:  price.Level,
+		}
+		hours = append(hours, h)
+
+		report.Hours++
+		report.Energy += h.Energy
+		report.Cost += h.Cost
+		spotCost += cost
+		report.GridCost += n.Consumption * tariff
+		report.Subsidy += n.Consumption * price.Subsidy
+		if n.Currency != "" {
+			report.Currency = n.Currency
+		}
+
+		b, ok := byLevel[h.Level]
+		if !ok {
+			b = &models.LevelBreakdown{Level: h.Level}
+			byLevel[h.Level] = b
+		}
+		b.Hours++
+		b.Energy += h.Energy
+		b.Cost += h.Cost
+	}
+
+	report.Total = report.Cost + report.FixedFee
+
+	if report.Energy > 0 {
+		report.AveragePrice = report.Cost / report.Energy
+		// Timing compares spot with spot; the tariff and subsidy don't
+		// depend on when the energy was used
+		if report.SpotAverage > 0 {
+			spotPaid := spotCost / report.Energy
+			report.TimingPercent = (spotPaid/report.SpotAverage - 1) * 100
+			report.TimingSavings = (report.SpotAverage - spotPaid) * report.Energy
+		}
+	}
+
+	for _, b := range byLevel {
+		if report.Energy > 0 {
+			b.Share = b.Energy / report.Energy * 100
+		}
+		report.Levels = append(report.Levels, *b)
+	}
+	sort.Slice(report.Levels, func(i, j int) bool { return report.Levels[i].Level < report.Levels[j].Level })
+
+	sort.SliceStable(hours, func(i, j int) bool { return hours[i].Cost > hours[j].Cost })
+	if len(hours) > top {
+		hours = hours[:top]
+	}
+	report.TopHours = append(report.TopHours, hours...)
+
+	return report
+}
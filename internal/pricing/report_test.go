@@ -0,0 +1,96 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+func TestBuildCostReport(t *testing.T) {
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	prices := []models.Price{
+		{StartsAt: start, Total: 0.5, Level: models.PriceLevelCheap, Currency: "NOK"},
+		{StartsAt: start.Add(time.Hour), Total: 1.0, Level: models.PriceLevelNormal},
+		{StartsAt: start.Add(2 * time.Hour), Total: 1.5, Level: models.PriceLevelExpensive},
+		{StartsAt: start.Add(3 * time.Hour), Total: 1.0, Level: models.PriceLevelNormal},
+	}
+	nodes := []models.Consumption{
+		{From: start, Consumption: 4, UnitPrice: 0.5, Cost: 2, Currency: "NOK"},
+		{From: start.Add(time.Hour), Consumption: 2, UnitPrice: 1.0, Cost: 2},
+		// no cost reported: falls back to consumption × unit price
+		{From: start.Add(2 * time.Hour), Consumption: 2, UnitPrice: 1.5},
+		{From: start.Add(4 * time.Hour), Consumption: 2, UnitPrice: 1.0, Cost: 2},
+	}
+
+	r := BuildCostReport("2025-09", nodes, prices, 0, 2)
+
+	if r.Hours != 4 || r.Energy != 10 || r.Cost != 9 || r.Currency != "NOK" {
+		t.Errorf("Hours = %d, Energy = %v, Cost = %v, Currency = %q", r.Hours, r.Energy, r.Cost, r.Currency)
+	}
+	if r.AveragePrice != 0.9 || r.SpotAverage != 1.0 {
+		t.Errorf("AveragePrice = %v, SpotAverage = %v, want 0.9 and 1.0", r.AveragePrice, r.SpotAverage)
+	}
+	if math.Abs(r.TimingPercent+10) > 1e-9 || math.Abs(r.TimingSavings-1) > 1e-9 {
+		t.Errorf("TimingPercent = %v, TimingSavings = %v, want -10 and 1", r.TimingPercent, r.TimingSavings)
+	}
+
+	if len(r.TopHours) != 2 || r.TopHours[0].Cost != 3 || r.TopHours[0].Level != models.PriceLevelExpensive {
+		t.Errorf("TopHours = %+v, want the 3.00 EXPENSIVE hour first", r.TopHours)
+	}
+
+	want := []models.LevelBreakdown{
+		{Level: 0, Hours: 1, Energy: 2, Cost: 2, Share: 20},
+		{Level: models.PriceLevelCheap, Hours: 1, Energy: 4, Cost: 2, Share: 40},
+		{Level: models.PriceLevelNormal, Hours: 1, Energy: 2, Cost: 2, Share: 20},
+		{Level: models.PriceLevelExpensive, Hours: 1, Energy: 2, Cost: 3, Share: 20},
+	}
+	if len(r.Levels) != len(want) {
+		t.Fatalf("Levels = %+v, want %d levels", r.Levels, len(want))
+	}
+	for i := range want {
+		if r.Levels[i] != want[i] {
+			t.Errorf("Levels[%d] = %+v, want %+v", i, r.Levels[i], want[i])
+		}
+	}
+}
+
+func TestBuildCostReport_Empty(t *testing.T) {
+	r := BuildCostReport("2025-09", nil, nil, 0, DefaultTopHours)
+	if r.Hours != 0 || r.AveragePrice != 0 || len(r.TopHours) != 0 || r.Levels == nil {
+		t.Errorf("BuildCostReport(nil) = %+v, want an empty report", r)
+	}
+}
+
+func TestBuildCostReport_Tariff(t *testing.T) {
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	prices := []models.Price{{StartsAt: start, Total: 1.0, Grid: 0.4, Fees: 0.1}}
+	prices[0].SetEffective()
+	nodes := []models.Consumption{{From: start, Consumption: 2, UnitPrice: 1.0, Cost: 2}}
+
+	r := BuildCostReport("2025-09", nodes, prices, 250, DefaultTopHours)
+
+	if r.Cost != 3 || r.GridCost != 1 || r.FixedFee != 250 || r.Total != 253 {
+		t.Errorf("Cost = %v, GridCost = %v, FixedFee = %v, Total = %v, want 3, 1, 250, 253", r.Cost, r.GridCost, r.FixedFee, r.Total)
+	}
+	if r.AveragePrice != 1.5 || r.TopHours[0].Price != 1.5 {
+		t.Errorf("AveragePrice = %v, TopHours[0].Price = %v, want 1.5", r.AveragePrice, r.TopHours[0].Price)
+	}
+	// The tariff is paid whenever the energy is used, so it isn't timing
+	if r.SpotAverage != 1 || r.TimingPercent != 0 || r.TimingSavings != 0 {
+		t.Errorf("SpotAverage = %v, TimingPercent = %v, TimingSavings = %v, want 1, 0, 0", r.SpotAverage, r.TimingPercent, r.TimingSavings)
+	}
+}
+
+func TestBuildCostReport_Subsidy(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	prices := []models.Price{{StartsAt: start, Total: 1.5, Subsidy: 0.5}}
+	nodes := []models.Consumption{{From: start, Consumption: 4, UnitPrice: 2.0, Cost: 8}}
+
+	r := BuildCostReport("2025-01", nodes, prices, 0, DefaultTopHours)
+
+	if r.Cost != 6 || r.Subsidy != 2 || r.AveragePrice != 1.5 {
+		t.Errorf("Cost = %v, Subsidy = %v, AveragePrice = %v, want 6, 2, 1.5", r.Cost, r.Subsidy, r.AveragePrice)
+	}
+}
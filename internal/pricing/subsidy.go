@@ -0,0 +1,136 @@
+package pricing
+
+import (
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/config"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+// SubsidyScheme computes the subsidy for one price slot
+type SubsidyScheme interface {
+	// PerKWh returns the subsidy per kWh incl. VAT for p, given the
+	// average spot price (excl. VAT) of p's month so far
+	PerKWh(p models.Price, monthAverage float64) float64
+}
+
+// NewSubsidyScheme returns the scheme for a rule, or nil for an unknown
+// scheme
+func NewSubsidyScheme(rule config.SubsidyRule) SubsidyScheme {
+	switch rule.Scheme {
+	case config.SubsidyMonthlyAverage:
+		return monthlyAverageScheme{rule}
+	case config.SubsidyHourly:
+		return hourlyScheme{rule}
+	case config.SubsidyFixedPrice:
+		return fixedPriceScheme{rule}
+	}
+	return nil
+}
+
+type monthlyAverageScheme struct{ rule config.SubsidyRule }
+
+func (s monthlyAverageScheme) PerKWh(p models.Price, monthAverage float64) float64 {
+	return covered(s.rule, monthAverage)
+}
+
+type hourlyScheme struct{ rule config.SubsidyRule }
+
+func (s hourlyScheme) PerKWh(p models.Price, monthAverage float64) float64 {
+	return covered(s.rule, p.Energy)
+}
+
+type fixedPriceScheme struct{ rule config.SubsidyRule }
+
+func (s fixedPriceScheme) PerKWh(p models.Price, monthAverage float64) float64 {
+	return (p.Energy - s.rule.Price) * (1 + s.rule.VAT/100)
+}
+
+// covered returns the share of spot above the rule's threshold, incl. VAT
+func covered(rule config.SubsidyRule, spot float64) float64 {
+	if spot <= rule.Threshold {
+		return 0
+	}
+	return (spot - rule.Threshold) * rule.Coverage / 100 * (1 + rule.VAT/100)
+}
+
+// Subsidy applies the subsidy rules in effect at each price's start.
+// Months are delimited in loc.
+type Subsidy struct {
+	rules []config.SubsidyRule
+	from  []time.Time
+	loc   *time.Location
+}
+
+// NewSubsidy returns a subsidy for cfg, which must have passed config
+// validation
+func NewSubsidy(cfg config.SubsidyConfig, loc *time.Location) *Subsidy {
+	s := &Subsidy{rules: cfg.Rules, loc: loc}
+	for _, rule := range cfg.Rules {
+		from, _ := time.ParseInLocation("2006-01-02", rule.From, loc)
+		s.from = append(s.from, from)
+	}
+	return s
+}
+
+// Rule returns the rule in effect at at, or nil before the first rule
+func (s *Subsidy) Rule(at time.Time) *config.SubsidyRule {
+	var rule *config.SubsidyRule
+	for i := range s.rules {
+		if !at.Before(s.from[i]) {
+			rule = &s.rules[i]
+		}
+	}
+	return rule
+}
+
+// MonthlyAverages returns the average spot price (excl. VAT) of history
+// per month, keyed YYYY-MM
+func (s *Subsidy) MonthlyAverages(history []models.Price) map[string]float64 {
+	sums := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, p := range history {
+		month := p.StartsAt.In(s.loc).Format("2006-01")
+		sums[month] += p.Energy
+		counts[month]++
+	}
+
+	averages := make(map[string]float64, len(sums))
+	for month, sum := range sums {
+		averages[month] = sum / float64(counts[month])
+	}
+	return averages
+}
+
+// Apply sets Subsidy on each price and updates its Effective price. history
+// provides the monthly averages and should cover each price's month up
+// to the price; prices may be part of it.
+func (s *Subsidy) Apply(prices []models.Price, history []models.Price) {
+	averages := s.MonthlyAverages(history)
+	for i := range prices {
+		p := &prices[i]
+		rule := s.Rule(p.StartsAt)
+		if rule == nil {
+			continue
+		}
+		scheme := NewSubsidyScheme(*rule)
+		if scheme == nil {
+			continue
+		}
+		p.Subsidy = scheme.PerKWh(*p, averages[p.StartsAt.In(s.loc).Format("2006-01")])
+		p.SetEffective()
+	}
+}
+
+// ApplyInfo applies the subsidy to current, today's and tomorrow's prices.
+// history is this month's prices before today.
+func (s *Subsidy) ApplyInfo(info *models.PriceInfo, history []models.Price) {
+	all := append(append(append([]models.Price{}, history...), info.Today...), info.Tomorrow...)
+	if info.Current != nil {
+		current := []models.Price{*info.Current}
+		s.Apply(current, all)
+		*info.Current = current[0]
+	}
+	s.Apply(info.Today, all)
+	s.Apply(info.Tomorrow, all)
+}
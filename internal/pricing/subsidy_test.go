@@ -0,0 +1,83 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/config"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+func testSubsidy() *Subsidy {
+	return NewSubsidy(config.SubsidyConfig{Rules: []config.SubsidyRule{
+		{From: "2022-09-01", Scheme: config.SubsidyMonthlyAverage, Threshold: 0.70, Coverage: 90, VAT: 25},
+		{From: "2023-09-01", Scheme: config.SubsidyHourly, Threshold: 0.70, Coverage: 90, VAT: 25},
+		{From: "2025-10-01", Scheme: config.SubsidyFixedPrice, Price: 0.40, VAT: 25},
+	}}, time.UTC)
+}
+
+func TestSubsidy_Rule(t *testing.T) {
+	s := testSubsidy()
+
+	if rule := s.Rule(time.Date(2022, 8, 31, 23, 0, 0, 0, time.UTC)); rule != nil {
+		t.Errorf("Rule() before the first rule = %+v, want nil", rule)
+	}
+	if rule := s.Rule(time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)); rule == nil || rule.Scheme != config.SubsidyMonthlyAverage {
+		t.Errorf("Rule(2023-01) = %+v, want monthly_average", rule)
+	}
+	if rule := s.Rule(time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)); rule == nil || rule.Scheme != config.SubsidyFixedPrice {
+		t.Errorf("Rule(2025-10-01) = %+v, want fixed_price", rule)
+	}
+}
+
+func TestSubsidy_Apply(t *testing.T) {
+	s := testSubsidy()
+
+	tests := []struct {
+		name    string
+		start   time.Time
+		energy  float64
+		history []float64
+		want    float64
+	}{
+		// 90% of the 1.20 monthly average above 0.70, plus VAT
+		{"monthly average", time.Date(2023, 1, 10, 8, 0, 0, 0, time.UTC), 0.50, []float64{1.0, 1.4}, 0.5625},
+		{"monthly average below threshold", time.Date(2023, 1, 10, 8, 0, 0, 0, time.UTC), 2.00, []float64{0.5, 0.6}, 0},
+		// 90% of this hour's 1.50 above 0.70, plus VAT
+		{"hourly", time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC), 1.50, nil, 0.9},
+		{"hourly below threshold", time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC), 0.60, nil, 0},
+		// settled to 0.40: above it is covered, below it is paid
+		{"fixed price above", time.Date(2025, 11, 1, 8, 0, 0, 0, time.UTC), 1.00, nil, 0.75},
+		{"fixed price below", time.Date(2025, 11, 1, 8, 0, 0, 0, time.UTC), 0.20, nil, -0.25},
+		{"before rules", time.Date(2021, 1, 1, 8, 0, 0, 0, time.UTC), 3.00, nil, 0},
+	}
+
+	for _, tt := range tests {
+		var history []models.Price
+		for i, e := range tt.history {
+			history = append(history, models.Price{StartsAt: tt.start.AddDate(0, 0, -i-1), Energy: e})
+		}
+		prices := []models.Price{{StartsAt: tt.start, Energy: tt.energy, Total: 2.0}}
+
+		s.Apply(prices, history)
+
+		if math.Abs(prices[0].Subsidy-tt.want) > 1e-9 || prices[0].Total != 2.0 || math.Abs(prices[0].Payable()-(2.0-tt.want)) > 1e-9 {
+			t.Errorf("%s: Subsidy = %v, Total = %v, Payable() = %v, want %v", tt.name, prices[0].Subsidy, prices[0].Total, prices[0].Payable(), tt.want)
+		}
+	}
+}
+
+func TestSubsidy_MonthlyAverages(t *testing.T) {
+	history := []models.Price{
+		{StartsAt: time.Date(2023, 1, 31, 22, 0, 0, 0, time.UTC), Energy: 1.0},
+		{StartsAt: time.Date(2023, 1, 31, 23, 0, 0, 0, time.UTC), Energy: 2.0},
+		{StartsAt: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), Energy: 4.0},
+	}
+
+	averages := testSubsidy().MonthlyAverages(history)
+
+	if averages["2023-01"] != 1.5 || averages["2023-02"] != 4.0 {
+		t.Errorf("MonthlyAverages() = %v, want 2023-01: 1.5, 2023-02: 4", averages)
+	}
+}
@@ -0,0 +1,150 @@
+package pricing
+
+import (
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/config"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+// Tariff adds a configured grid tariff and fees to spot prices. Bands are
+// matched in loc.
+type Tariff struct {
+	cfg      config.TariffConfig
+	holidays map[string]bool
+	loc      *time.Location
+}
+
+// NewTariff returns a tariff for cfg, which must have passed config
+// validation
+func NewTariff(cfg config.TariffConfig, loc *time.Location) *Tariff {
+	t := &Tariff{cfg: cfg, holidays: make(map[string]bool), loc: loc}
+	for _, day := range cfg.Holidays {
+		t.holidays[day] = true
+	}
+	return t
+}
+
+// Rate returns the grid energy charge and fees per kWh at at, incl. VAT
+func (t *Tariff) Rate(at time.Time) (grid, fees float64) {
+	at = at.In(t.loc)
+	vat := 1 + t.cfg.VAT/100
+
+	if band := t.band(at); band != nil {
+		grid = band.Price * vat
+	}
+	return grid, t.cfg.Fees * vat
+}
+
+// MonthlyFee returns the fixed monthly fee incl. VAT
+func (t *Tariff) MonthlyFee() float64 {
+	return t.cfg.MonthlyFee * (1 + t.cfg.VAT/100)
+}
+
+// Apply sets Grid and Fees on each price and updates its Effective price
+func (t *Tariff) Apply(prices []models.Price) {
+	for i := range prices {
+		p := &prices[i]
+		p.Grid, p.Fees = t.Rate(p.StartsAt)
+		p.SetEffective()
+	}
+}
+
+// ApplyDaily is Apply for daily prices, using the mean of the day's
+// hourly rates
+func (t *Tariff) ApplyDaily(prices []models.Price) {
+	for i := range prices {
+		p := &prices[i]
+		day := p.StartsAt.In(t.loc)
+		end := day.AddDate(0, 0, 1)
+
+		var grid, fees float64
+		hours := 0
+		for at := day; at.Before(end); at = at.Add(time.Hour) {
+			g, f := t.Rate(at)
+			grid += g
+			fees += f
+			hours++
+		}
+		p.Grid, p.Fees = grid/float64(hours), fees/float64(hours)
+		p.SetEffective()
+	}
+}
+
+// ApplyInfo applies the tariff to current, today's and tomorrow's prices
+func (t *Tariff) ApplyInfo(info *models.PriceInfo) {
+	if info.Current != nil {
+		info.Current.Grid, info.Current.Fees = t.Rate(info.Current.StartsAt)
+		info.Current.SetEffective()
+	}
+	t.Apply(info.Today)
+	t.Apply(info.Tomorrow)
+}
+
+// band returns the first band of the first season covering at
+func (t *Tariff) band(at time.Time) *config.TariffBand {
+	day := t.dayKind(at)
+	minute := at.Hour()*60 + at.Minute()
+
+	for _, season := range t.cfg.Seasons {
+		if len(season.Months) > 0 && !containsMonth(season.Months, int(at.Month())) {
+			continue
+		}
+		for i := range season.Bands {
+			band := &season.Bands[i]
+			if matchesDays(band.Days, day) && inBand(band, minute) {
+				return band
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// dayKind classifies at as a holiday, weekend day or weekday
+func (t *Tariff) dayKind(at time.Time) string {
+	switch {
+	case t.holidays[at.Format("2006-01-02")]:
+		return config.TariffDaysHoliday
+	case at.Weekday() == time.Saturday || at.Weekday() == time.Sunday:
+		return config.TariffDaysWeekend
+	default:
+		return config.TariffDaysWeekday
+	}
+}
+
+func matchesDays(days, kind string) bool {
+	switch days {
+	case "", config.TariffDaysAll:
+		return true
+	case config.TariffDaysWeekend:
+		return kind == config.TariffDaysWeekend || kind == config.TariffDaysHoliday
+	default:
+		return days == kind
+	}
+}
+
+// inBand reports whether minute (after midnight) falls within the band's
+// hours, wrapping past midnight when To is before From
+func inBand(band *config.TariffBand, minute int) bool {
+	from, to := 0, 24*60
+	if band.From != "" {
+		from, _ = config.ParseClock(band.From)
+	}
+	if band.To != "" {
+		to, _ = config.ParseClock(band.To)
+	}
+	if from <= to {
+		return minute >= from && minute < to
+	}
+	return minute >= from || minute < to
+}
+
+func containsMonth(months []int, m int) bool {
+	for _, month := range months {
+		if month == m {
+			return true
+		}
+	}
+	return false
+}
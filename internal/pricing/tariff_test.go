@@ -0,0 +1,88 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/config"
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+func testTariff() *Tariff {
+	return NewTariff(config.TariffConfig{
+		VAT:        25,
+		MonthlyFee: 200,
+		Fees:       0.08,
+		Holidays:   []string{"2025-12-25"},
+		Seasons: []config.TariffSeason{
+			{
+				Name:   "winter",
+				Months: []int{1, 2, 3, 11, 12},
+				Bands: []config.TariffBand{
+					{Name: "weekend", Days: config.TariffDaysWeekend, Price: 0.30},
+					{Name: "day", From: "06:00", To: "22:00", Price: 0.50},
+					{Name: "night", Price: 0.40},
+				},
+			},
+			{
+				Name: "summer",
+				Bands: []config.TariffBand{
+					{Name: "night", From: "22:00", To: "06:00", Price: 0.20},
+					{Name: "day", Price: 0.24},
+				},
+			},
+		},
+	}, time.UTC)
+}
+
+func TestTariff_Rate(t *testing.T) {
+	tariff := testTariff()
+
+	tests := []struct {
+		name string
+		at   time.Time
+		grid float64
+	}{
+		{"winter weekday day", time.Date(2025, 12, 23, 8, 0, 0, 0, time.UTC), 0.50},
+		{"winter weekday night", time.Date(2025, 12, 23, 22, 0, 0, 0, time.UTC), 0.40},
+		{"winter weekend", time.Date(2025, 12, 27, 8, 0, 0, 0, time.UTC), 0.30},
+		{"holiday billed as weekend", time.Date(2025, 12, 25, 8, 0, 0, 0, time.UTC), 0.30},
+		{"summer night wraps midnight", time.Date(2025, 7, 1, 2, 0, 0, 0, time.UTC), 0.20},
+		{"summer day", time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC), 0.24},
+	}
+
+	for _, tt := range tests {
+		grid, fees := tariff.Rate(tt.at)
+		if math.Abs(grid-tt.grid*1.25) > 1e-9 || math.Abs(fees-0.1) > 1e-9 {
+			t.Errorf("%s: Rate() = %v, %v, want %v, 0.1", tt.name, grid, fees, tt.grid*1.25)
+		}
+	}
+
+	if fee := tariff.MonthlyFee(); fee != 250 {
+		t.Errorf("MonthlyFee() = %v, want 250", fee)
+	}
+}
+
+func TestTariff_Apply(t *testing.T) {
+	start := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	prices := []models.Price{{StartsAt: start, Total: 1.0}}
+
+	testTariff().Apply(prices)
+
+	if math.Abs(prices[0].Grid-0.3) > 1e-9 || math.Abs(prices[0].Fees-0.1) > 1e-9 || prices[0].Total != 1.0 || math.Abs(prices[0].Payable()-1.4) > 1e-9 {
+		t.Errorf("Apply() = %+v, want grid 0.3, fees 0.1, total 1.0, effective 1.4", prices[0])
+	}
+}
+
+func TestTariff_ApplyDaily(t *testing.T) {
+	// 8 night hours at 0.20 and 16 day hours at 0.24, plus VAT
+	prices := []models.Price{{StartsAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), Total: 1.0}}
+
+	testTariff().ApplyDaily(prices)
+
+	want := (8*0.20 + 16*0.24) / 24 * 1.25
+	if math.Abs(prices[0].Grid-want) > 1e-9 {
+		t.Errorf("ApplyDaily() Grid = %v, want %v", prices[0].Grid, want)
+	}
+}
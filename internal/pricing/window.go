@@ -0,0 +1,70 @@
+package pricing
+
+import (
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+// Window is a run of consecutive price slots
+type Window struct {
+	Start   time.Time
+	End     time.Time
+	Average float64
+	Prices  []models.Price
+}
+
+// CheapestWindow finds the run of n consecutive slots with the lowest
+// average total price among those not yet over at now. Slots must be in
+// order; a slot lasts until the next one starts and the last lasts an hour.
+// It returns nil if fewer than n slots remain.
+func CheapestWindow(prices []models.Price, now time.Time, n int) *Window {
+	if n < 1 {
+		return nil
+	}
+
+	first := len(prices)
+	for i := range prices {
+		if slotEnd(prices, i).After(now) {
+			first = i
+			break
+		}
+	}
+	upcoming := prices[first:]
+	if len(upcoming) < n {
+		return nil
+	}
+
+	best, bestSum := -1, 0.0
+	sum := 0.0
+	for i := range upcoming {
+		sum += upcoming[i].Payable()
+		if i >= n {
+			sum -= upcoming[i-n].Payable()
+		}
+		if i >= n-1 && (best < 0 || sum < bestSum) {
+			best, bestSum = i-n+1, sum
+		}
+	}
+
+	// Sum the winner afresh so the running sum's rounding doesn't leak out
+	w := upcoming[best : best+n]
+	total := 0.0
+	for _, p := range w {
+		total += p.Payable()
+	}
+	return &Window{
+		Start:   w[0].StartsAt,
+		End:     slotEnd(upcoming, best+n-1),
+		Average: total / float64(n),
+		Prices:  w,
+	}
+}
+
+// slotEnd is when prices[i] ends
+func slotEnd(prices []models.Price, i int) time.Time {
+	if i+1 < len(prices) {
+		return prices[i+1].StartsAt
+	}
+	return prices[i].StartsAt.Add(time.Hour)
+}
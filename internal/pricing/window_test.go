@@ -0,0 +1,37 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+func TestCheapestWindow(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var prices []models.Price
+	for i, total := range []float64{0.5, 0.4, 0.9, 0.3, 0.2, 0.8, 0.1} {
+		prices = append(prices, models.Price{StartsAt: start.Add(time.Duration(i) * time.Hour), Total: total})
+	}
+
+	w := CheapestWindow(prices, start, 2)
+	if w == nil || !w.Start.Equal(start.Add(3*time.Hour)) || !w.End.Equal(start.Add(5*time.Hour)) {
+		t.Fatalf("CheapestWindow(2) = %+v, want 03:00–05:00", w)
+	}
+	if w.Average != 0.25 || len(w.Prices) != 2 {
+		t.Errorf("Average = %v with %d prices, want 0.25 and 2", w.Average, len(w.Prices))
+	}
+
+	// The slot in progress still counts; earlier ones don't
+	w = CheapestWindow(prices, start.Add(6*time.Hour+30*time.Minute), 1)
+	if w == nil || w.Average != 0.1 || !w.End.Equal(start.Add(7*time.Hour)) {
+		t.Errorf("CheapestWindow(1) in the last slot = %+v", w)
+	}
+
+	if w := CheapestWindow(prices, start.Add(5*time.Hour), 3); w != nil {
+		t.Errorf("CheapestWindow() with too few slots left = %+v, want nil", w)
+	}
+	if w := CheapestWindow(nil, start, 1); w != nil {
+		t.Errorf("CheapestWindow(nil) = %+v, want nil", w)
+	}
+}
@@ -0,0 +1,180 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Check verifies that every field selected in a GraphQL document exists in
+// the schema and that objects, and only objects, have selection sets.
+// Arguments and variables are not type-checked, and fragments are not
+// supported.
+func (s *Schema) Check(document string) error {
+	p := &docParser{tokens: tokenize(document)}
+
+	root := s.QueryType
+	switch p.peek() {
+	case "mutation":
+		root = s.MutationType
+	case "subscription":
+		root = s.SubscriptionType
+	}
+	if root == nil {
+		return fmt.Errorf("schema has no root type for %q", p.peek())
+	}
+
+	if p.peek() != "{" {
+		p.next() // operation keyword
+		if p.peek() != "{" && p.peek() != "(" {
+			p.next() // operation name
+		}
+		if p.peek() == "(" {
+			p.skipBalanced("(", ")")
+		}
+	}
+
+	t := s.Type(root.Name)
+	if t == nil {
+		return fmt.Errorf("root type %s not found", root.Name)
+	}
+	if err := s.checkSelection(p, t); err != nil {
+		return err
+	}
+	if p.peek() != "" {
+		return fmt.Errorf("unexpected %q after operation", p.peek())
+	}
+	return nil
+}
+
+func (s *Schema) checkSelection(p *docParser, t *Type) error {
+	if tok := p.next(); tok != "{" {
+		return fmt.Errorf("expected { after %s, got %q", t.Name, tok)
+	}
+
+	for {
+		name := p.next()
+		switch name {
+		case "}":
+			return nil
+		case "":
+			return fmt.Errorf("unterminated selection on %s", t.Name)
+		case "...":
+			return fmt.Errorf("fragments are not supported")
+		}
+
+		if p.peek() == ":" { // alias
+			p.next()
+			name = p.next()
+		}
+		if p.peek() == "(" {
+			p.skipBalanced("(", ")")
+		}
+		if name == "__typename" {
+			continue
+		}
+
+		f := t.Field(name)
+		if f == nil {
+			return fmt.Errorf("unknown field %s on %s", name, t.Name)
+		}
+
+		named := s.Type(f.Type.Named().Name)
+		if named == nil {
+			return fmt.Errorf("type %s of %s.%s not found", f.Type.Named().Name, t.Name, name)
+		}
+
+		composite := named.Kind == KindObject || named.Kind == KindInterface
+		switch {
+		case p.peek() == "{" && !composite:
+			return fmt.Errorf("%s.%s is a %s and cannot have a selection", t.Name, name, named.Name)
+		case p.peek() == "{":
+			if err := s.checkSelection(p, named); err != nil {
+				return err
+			}
+		case composite:
+			return fmt.Errorf("%s.%s is a %s and needs a selection", t.Name, name, named.Name)
+		}
+	}
+}
+
+// docParser walks the tokens of a GraphQL document
+type docParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *docParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *docParser) next() string {
+	tok := p.peek()
+	if tok != "" {
+		p.pos++
+	}
+	return tok
+}
+
+// skipBalanced skips from an open token to its matching close token
+func (p *docParser) skipBalanced(open, close string) {
+	depth := 0
+	for tok := p.next(); tok != ""; tok = p.next() {
+		switch tok {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// tokenize splits a document into names, punctuation and string literals,
+// dropping whitespace, commas and comments
+func tokenize(doc string) []string {
+	var tokens []string
+	runes := []rune(doc)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r) || r == ',':
+			i++
+		case r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '"':
+			j := i + 1
+			for j < len(runes) && runes[j] != '"' {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			tokens = append(tokens, string(runes[i:min(j+1, len(runes))]))
+			i = j + 1
+		case strings.HasPrefix(string(runes[i:min(i+3, len(runes))]), "..."):
+			tokens = append(tokens, "...")
+			i += 3
+		case r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && (runes[j] == '_' || runes[j] == '.' || runes[j] == '-' || unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		default:
+			tokens = append(tokens, string(r))
+			i++
+		}
+	}
+
+	return tokens
+}
@@ -0,0 +1,553 @@
+package schema
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GenConfig describes which schema types to generate Go code for. Paths are
+// relative to the config file.
+type GenConfig struct {
+	// Schema is the introspection snapshot to generate from
+	Schema string `yaml:"schema"`
+
+	// Models receives enum constants and struct types
+	Models GenOutput `yaml:"models"`
+
+	// Selections receives a selection set constant per struct type, for
+	// building query documents that match the models
+	Selections GenOutput `yaml:"selections"`
+
+	// Enums lists schema enums to generate constants for
+	Enums []EnumSpec `yaml:"enums"`
+
+	// Types lists the structs to generate, in output order
+	Types []TypeSpec `yaml:"types"`
+}
+
+// GenOutput is a generated Go file
+type GenOutput struct {
+	Package string `yaml:"package"`
+	Output  string `yaml:"output"`
+}
+
+// EnumSpec describes one generated enum. A plain string entry generates
+// untyped string constants.
+type EnumSpec struct {
+	Name string `yaml:"name"`
+
+	// Typed generates an ordered Go type with JSON (un)marshaling, backed by
+	// the models package enum helper, and uses it for fields of this enum
+	Typed bool `yaml:"typed"`
+
+	// Order overrides the schema order of a typed enum's values, which
+	// defines how values compare
+	Order []string `yaml:"order"`
+}
+
+// UnmarshalYAML accepts either an enum name or a mapping
+func (spec *EnumSpec) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		spec.Name = node.Value
+		return nil
+	}
+	type plain EnumSpec
+	return node.Decode((*plain)(spec))
+}
+
+// TypeSpec describes one generated struct
+type TypeSpec struct {
+	// Name is the Go type name; Schema is the schema type if it differs
+	Name   string `yaml:"name"`
+	Schema string `yaml:"schema"`
+	Doc    string `yaml:"doc"`
+
+	// Fields are schema field names, optionally followed by a Go type
+	// overriding the mapped one, e.g. "startsAt time.Time"
+	Fields []string `yaml:"fields"`
+
+	// Omitempty lists fields tagged omitempty
+	Omitempty []string `yaml:"omitempty"`
+
+	// Optional makes nullable input fields pointers tagged omitempty, so
+	// unset fields are left out of mutations
+	Optional bool `yaml:"optional"`
+
+	// Extra are Go-only fields appended after the schema fields
+	Extra []ExtraField `yaml:"extra"`
+}
+
+// ExtraField is a field the CLI fills in itself rather than reading from
+// the API. It is tagged omitempty and never selected in queries.
+type ExtraField struct {
+	// Name is the JSON name; the Go name is derived from it
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+	Doc  string `yaml:"doc"`
+}
+
+// LoadGenConfig reads a generator config file
+func LoadGenConfig(path string) (*GenConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generator config: %w", err)
+	}
+
+	var cfg GenConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse generator config: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	cfg.Schema = filepath.Join(dir, cfg.Schema)
+	cfg.Models.Output = filepath.Join(dir, cfg.Models.Output)
+	if cfg.Selections.Output != "" {
+		cfg.Selections.Output = filepath.Join(dir, cfg.Selections.Output)
+	}
+	return &cfg, nil
+}
+
+// scalarTypes maps built-in scalars to Go types
+var scalarTypes = map[string]string{
+	"String":  "string",
+	"ID":      "string",
+	"Int":     "int",
+	"Float":   "float64",
+	"Boolean": "bool",
+}
+
+const genHeader = "// Code generated by schemagen from the Tibber schema snapshot. DO NOT EDIT.\n\n"
+
+// Generate returns the models and selections files for cfg
+func Generate(s *Schema, cfg *GenConfig) (models, selections []byte, err error) {
+	goNames := make(map[string]string) // schema type -> Go type
+	for _, spec := range cfg.Types {
+		goNames[spec.schemaName()] = spec.Name
+	}
+	for _, spec := range cfg.Enums {
+		if spec.Typed {
+			goNames[spec.Name] = spec.Name
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString(genHeader)
+	fmt.Fprintf(&b, "package %s\n\n", cfg.Models.Package)
+
+	var body bytes.Buffer
+	for _, spec := range cfg.Enums {
+		genFn := genEnum
+		if spec.Typed {
+			genFn = genTypedEnum
+		}
+		if err := genFn(&body, s, spec); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, spec := range cfg.Types {
+		if err := genStruct(&body, s, spec, goNames); err != nil {
+			return nil, nil, err
+		}
+	}
+	if bytes.Contains(body.Bytes(), []byte("time.Time")) {
+		b.WriteString("import \"time\"\n\n")
+	}
+	b.Write(body.Bytes())
+
+	models, err = format.Source(b.Bytes())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to format models: %w", err)
+	}
+
+	if cfg.Selections.Output == "" {
+		return models, nil, nil
+	}
+
+	b.Reset()
+	b.WriteString(genHeader)
+	fmt.Fprintf(&b, "package %s\n\n", cfg.Selections.Package)
+	b.WriteString("// Selection sets matching the generated models\nconst (\n")
+	for _, spec := range cfg.Types {
+		if isInput(s, spec) {
+			continue
+		}
+		sel, err := selection(s, spec, cfg.Types)
+		if err != nil {
+			return nil, nil, err
+		}
+		fmt.Fprintf(&b, "\t%sFields = %q\n", lowerFirst(spec.Name), sel)
+	}
+	b.WriteString(")\n")
+
+	selections, err = format.Source(b.Bytes())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to format selections: %w", err)
+	}
+	return models, selections, nil
+}
+
+func (spec TypeSpec) schemaName() string {
+	if spec.Schema != "" {
+		return spec.Schema
+	}
+	return spec.Name
+}
+
+func isInput(s *Schema, spec TypeSpec) bool {
+	t := s.Type(spec.schemaName())
+	return t != nil && t.Kind == KindInputObject
+}
+
+// genEnum writes string constants and a list of values for an enum
+func genEnum(b *bytes.Buffer, s *Schema, spec EnumSpec) error {
+	name := spec.Name
+	t := s.Type(name)
+	if t == nil || t.Kind != KindEnum {
+		return fmt.Errorf("enum %s not found in schema", name)
+	}
+
+	if t.Description != "" {
+		fmt.Fprintf(b, "// %s values. %s\n", name, t.Description)
+	} else {
+		fmt.Fprintf(b, "// %s values\n", name)
+	}
+	b.WriteString("const (\n")
+	names := make([]string, len(t.EnumValues))
+	for i, v := range t.EnumValues {
+		names[i] = name + goName(v.Name)
+		fmt.Fprintf(b, "\t%s = %q\n", names[i], v.Name)
+	}
+	b.WriteString(")\n\n")
+
+	fmt.Fprintf(b, "// %s are all %s values in schema order\n", plural(name), name)
+	fmt.Fprintf(b, "var %s = []string{%s}\n\n", plural(name), strings.Join(names, ", "))
+	return nil
+}
+
+// genTypedEnum writes an ordered enum type backed by the models enum helper
+func genTypedEnum(b *bytes.Buffer, s *Schema, spec EnumSpec) error {
+	name := spec.Name
+	t := s.Type(name)
+	if t == nil || t.Kind != KindEnum {
+		return fmt.Errorf("enum %s not found in schema", name)
+	}
+
+	values := spec.Order
+	if len(values) == 0 {
+		for _, v := range t.EnumValues {
+			values = append(values, v.Name)
+		}
+	} else {
+		if len(values) != len(t.EnumValues) {
+			return fmt.Errorf("order of %s lists %d values, schema has %d", name, len(values), len(t.EnumValues))
+		}
+		for _, v := range values {
+			if !hasEnumValue(t, v) {
+				return fmt.Errorf("order of %s lists unknown value %s", name, v)
+			}
+		}
+	}
+
+	helper := lowerFirst(name) + "Enum"
+	consts := make([]string, len(values))
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		consts[i] = name + goName(v)
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+
+	if t.Description != "" {
+		fmt.Fprintf(b, "// %s is the %s schema enum. %s\n", name, name, t.Description)
+	} else {
+		fmt.Fprintf(b, "// %s is the %s schema enum\n", name, name)
+	}
+	fmt.Fprintf(b, "type %s int\n\n", name)
+
+	fmt.Fprintf(b, "// %s values, in ascending order\n", name)
+	b.WriteString("const (\n")
+	fmt.Fprintf(b, "\t%s %s = iota + 1\n", consts[0], name)
+	for _, c := range consts[1:] {
+		fmt.Fprintf(b, "\t%s\n", c)
+	}
+	b.WriteString(")\n\n")
+
+	fmt.Fprintf(b, "var %s = newEnum(%q, %s)\n\n", helper, name, strings.Join(quoted, ", "))
+
+	fmt.Fprintf(b, "// %s are all known %s values in ascending order\n", plural(name), name)
+	fmt.Fprintf(b, "var %s = []%s{%s}\n\n", plural(name), name, strings.Join(consts, ", "))
+
+	fmt.Fprintf(b, `// %[1]sNames returns the names of all known %[1]s values
+func %[1]sNames() []string { return append([]string{}, %[2]s.names...) }
+
+// Parse%[1]s parses a %[1]s name case-insensitively, rejecting unknown values
+func Parse%[1]s(s string) (%[1]s, error) {
+	code, err := %[2]s.parse(s)
+	return %[1]s(code), err
+}
+
+// String returns the schema name of the value, including unknown values
+func (v %[1]s) String() string { return %[2]s.name(int(v)) }
+
+// Label returns a human-readable name
+func (v %[1]s) Label() string { return %[2]s.label(int(v)) }
+
+// Known reports whether v is a value from the schema snapshot
+func (v %[1]s) Known() bool { return %[2]s.known(int(v)) }
+
+// MarshalJSON encodes the schema name
+func (v %[1]s) MarshalJSON() ([]byte, error) { return %[2]s.marshal(int(v)) }
+
+// UnmarshalJSON decodes a schema name, preserving unknown values
+func (v *%[1]s) UnmarshalJSON(data []byte) error {
+	code, err := %[2]s.unmarshal(data)
+	*v = %[1]s(code)
+	return err
+}
+
+`, name, helper)
+	return nil
+}
+
+func hasEnumValue(t *Type, name string) bool {
+	for _, v := range t.EnumValues {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// genStruct writes a struct for spec
+func genStruct(b *bytes.Buffer, s *Schema, spec TypeSpec, goNames map[string]string) error {
+	t := s.Type(spec.schemaName())
+	if t == nil {
+		return fmt.Errorf("type %s not found in schema", spec.schemaName())
+	}
+
+	doc := spec.Doc
+	if doc == "" {
+		doc = t.Description
+	}
+	if doc == "" {
+		doc = "is the " + t.Name + " schema type"
+	}
+	fmt.Fprintf(b, "// %s %s\n", spec.Name, doc)
+	fmt.Fprintf(b, "type %s struct {\n", spec.Name)
+
+	for _, entry := range spec.Fields {
+		fieldName, override, _ := strings.Cut(entry, " ")
+
+		var ref TypeRef
+		var desc string
+		if t.Kind == KindInputObject {
+			f := t.InputField(fieldName)
+			if f == nil {
+				return fmt.Errorf("unknown field %s on %s", fieldName, t.Name)
+			}
+			ref, desc = f.Type, f.Description
+		} else {
+			f := t.Field(fieldName)
+			if f == nil {
+				return fmt.Errorf("unknown field %s on %s", fieldName, t.Name)
+			}
+			ref, desc = f.Type, f.Description
+		}
+
+		goType := strings.TrimSpace(override)
+		if goType == "" {
+			var err error
+			if goType, err = mapType(s, ref, goNames); err != nil {
+				return fmt.Errorf("%s.%s: %w", t.Name, fieldName, err)
+			}
+		}
+
+		tag := fieldName
+		if contains(spec.Omitempty, fieldName) {
+			tag += ",omitempty"
+		}
+		if spec.Optional && !ref.NonNull() {
+			if !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "*") {
+				goType = "*" + goType
+			}
+			tag += ",omitempty"
+		}
+
+		if desc != "" {
+			fmt.Fprintf(b, "\t// %s\n", desc)
+		}
+		fmt.Fprintf(b, "\t%s %s `json:%q`\n", goName(fieldName), goType, tag)
+	}
+
+	for _, extra := range spec.Extra {
+		if extra.Name == "" || extra.Type == "" {
+			return fmt.Errorf("extra field of %s needs a name and type", spec.Name)
+		}
+		if extra.Doc != "" {
+			fmt.Fprintf(b, "\t// %s\n", extra.Doc)
+		}
+		fmt.Fprintf(b, "\t%s %s `json:%q`\n", goName(extra.Name), extra.Type, extra.Name+",omitempty")
+	}
+
+	b.WriteString("}\n\n")
+	return nil
+}
+
+// mapType returns the Go type for a schema type reference. Nullable objects
+// become pointers; scalars and enums use zero values for null.
+func mapType(s *Schema, ref TypeRef, goNames map[string]string) (string, error) {
+	nonNull := false
+	if ref.Kind == KindNonNull {
+		nonNull = true
+		ref = *ref.OfType
+	}
+
+	if ref.Kind == KindList {
+		elem, err := mapType(s, *ref.OfType, goNames)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + strings.TrimPrefix(elem, "*"), nil
+	}
+
+	if goType, ok := scalarTypes[ref.Name]; ok {
+		return goType, nil
+	}
+
+	t := s.Type(ref.Name)
+	if t == nil {
+		return "", fmt.Errorf("type %s not found", ref.Name)
+	}
+	if t.Kind == KindScalar {
+		return "string", nil
+	}
+
+	goType, ok := goNames[ref.Name]
+	if t.Kind == KindEnum {
+		if !ok {
+			return "string", nil
+		}
+		return goType, nil
+	}
+	if !ok {
+		return "", fmt.Errorf("type %s is not generated; add it to types or override the field", ref.Name)
+	}
+	if nonNull {
+		return goType, nil
+	}
+	return "*" + goType, nil
+}
+
+// selection returns the selection set for a generated struct, nesting the
+// selections of generated struct fields
+func selection(s *Schema, spec TypeSpec, specs []TypeSpec) (string, error) {
+	t := s.Type(spec.schemaName())
+	if t == nil {
+		return "", fmt.Errorf("type %s not found in schema", spec.schemaName())
+	}
+
+	parts := make([]string, 0, len(spec.Fields))
+	for _, entry := range spec.Fields {
+		fieldName, _, _ := strings.Cut(entry, " ")
+		f := t.Field(fieldName)
+		if f == nil {
+			return "", fmt.Errorf("unknown field %s on %s", fieldName, t.Name)
+		}
+
+		named := s.Type(f.Type.Named().Name)
+		if named == nil || named.Kind != KindObject {
+			parts = append(parts, fieldName)
+			continue
+		}
+
+		var nested *TypeSpec
+		for i := range specs {
+			if specs[i].schemaName() == named.Name {
+				nested = &specs[i]
+			}
+		}
+		if nested == nil {
+			return "", fmt.Errorf("%s.%s selects %s, which is not generated", t.Name, fieldName, named.Name)
+		}
+		sel, err := selection(s, *nested, specs)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, fieldName+" { "+sel+" }")
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+// commonInitialisms are kept upper case in Go names
+var commonInitialisms = map[string]string{"Id": "ID", "Vat": "VAT", "Url": "URL"}
+
+// goName converts a schema name (camelCase or SCREAMING_SNAKE) to an exported
+// Go identifier
+func goName(name string) string {
+	var words []string
+	if strings.ToUpper(name) == name {
+		for _, w := range strings.Split(strings.ToLower(name), "_") {
+			if w != "" {
+				words = append(words, upperFirst(w))
+			}
+		}
+	} else {
+		start := 0
+		runes := []rune(name)
+		for i := 1; i < len(runes); i++ {
+			if unicode.IsUpper(runes[i]) && !unicode.IsUpper(runes[i-1]) {
+				words = append(words, upperFirst(string(runes[start:i])))
+				start = i
+			}
+		}
+		words = append(words, upperFirst(string(runes[start:])))
+	}
+
+	for i, w := range words {
+		if initialism, ok := commonInitialisms[w]; ok {
+			words[i] = initialism
+		}
+	}
+	return strings.Join(words, "")
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func plural(name string) string {
+	if strings.HasSuffix(name, "s") {
+		return name + "es"
+	}
+	return name + "s"
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,367 @@
+// Package schema reads GraphQL introspection results, renders them as SDL,
+// checks query documents against them and generates Go models from them.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Type kinds reported by introspection
+const (
+	KindScalar      = "SCALAR"
+	KindObject      = "OBJECT"
+	KindInterface   = "INTERFACE"
+	KindUnion       = "UNION"
+	KindEnum        = "ENUM"
+	KindInputObject = "INPUT_OBJECT"
+	KindList        = "LIST"
+	KindNonNull     = "NON_NULL"
+)
+
+// IntrospectionQuery fetches the full schema
+const IntrospectionQuery = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+    directives {
+      name
+      description
+      locations
+      args { ...InputValue }
+    }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  description
+  fields(includeDeprecated: true) {
+    name
+    description
+    args { ...InputValue }
+    type { ...TypeRef }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes { ...TypeRef }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// Schema is the __schema object of an introspection result
+type Schema struct {
+	QueryType        *TypeName `json:"queryType"`
+	MutationType     *TypeName `json:"mutationType"`
+	SubscriptionType *TypeName `json:"subscriptionType"`
+	Types            []Type    `json:"types"`
+}
+
+// TypeName names a root operation type
+type TypeName struct {
+	Name string `json:"name"`
+}
+
+// Type is a named schema type
+type Type struct {
+	Kind          string       `json:"kind"`
+	Name          string       `json:"name"`
+	Description   string       `json:"description"`
+	Fields        []Field      `json:"fields"`
+	InputFields   []InputValue `json:"inputFields"`
+	Interfaces    []TypeRef    `json:"interfaces"`
+	EnumValues    []EnumValue  `json:"enumValues"`
+	PossibleTypes []TypeRef    `json:"possibleTypes"`
+}
+
+// Field is a field of an object or interface type
+type Field struct {
+	Name              string       `json:"name"`
+	Description       string       `json:"description"`
+	Args              []InputValue `json:"args"`
+	Type              TypeRef      `json:"type"`
+	IsDeprecated      bool         `json:"isDeprecated"`
+	DeprecationReason string       `json:"deprecationReason"`
+}
+
+// InputValue is an argument or input object field
+type InputValue struct {
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	Type         TypeRef `json:"type"`
+	DefaultValue *string `json:"defaultValue"`
+}
+
+// EnumValue is one value of an enum type
+type EnumValue struct {
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	IsDeprecated      bool   `json:"isDeprecated"`
+	DeprecationReason string `json:"deprecationReason"`
+}
+
+// TypeRef references a type, possibly wrapped in LIST and NON_NULL
+type TypeRef struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	OfType *TypeRef `json:"ofType"`
+}
+
+// Named returns the innermost named type
+func (r TypeRef) Named() TypeRef {
+	for r.OfType != nil {
+		r = *r.OfType
+	}
+	return r
+}
+
+// NonNull reports whether the reference is a non-null wrapper
+func (r TypeRef) NonNull() bool {
+	return r.Kind == KindNonNull
+}
+
+// String renders the reference in SDL notation, e.g. "[Price]!"
+func (r TypeRef) String() string {
+	switch r.Kind {
+	case KindNonNull:
+		return r.OfType.String() + "!"
+	case KindList:
+		return "[" + r.OfType.String() + "]"
+	default:
+		return r.Name
+	}
+}
+
+// Parse decodes an introspection result. Both the bare data object
+// ({"__schema": ...}) and a full response ({"data": {"__schema": ...}}) are
+// accepted.
+func Parse(data []byte) (*Schema, error) {
+	var doc struct {
+		Schema *Schema `json:"__schema"`
+		Data   *struct {
+			Schema *Schema `json:"__schema"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+
+	s := doc.Schema
+	if s == nil && doc.Data != nil {
+		s = doc.Data.Schema
+	}
+	if s == nil {
+		return nil, fmt.Errorf("no __schema in introspection result")
+	}
+	return s, nil
+}
+
+// Load reads and parses an introspection result from a file
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+	return Parse(data)
+}
+
+// Type returns the named type, or nil if it does not exist
+func (s *Schema) Type(name string) *Type {
+	for i := range s.Types {
+		if s.Types[i].Name == name {
+			return &s.Types[i]
+		}
+	}
+	return nil
+}
+
+// Field returns the named field of an object type, or nil
+func (t *Type) Field(name string) *Field {
+	for i := range t.Fields {
+		if t.Fields[i].Name == name {
+			return &t.Fields[i]
+		}
+	}
+	return nil
+}
+
+// InputField returns the named field of an input object type, or nil
+func (t *Type) InputField(name string) *InputValue {
+	for i := range t.InputFields {
+		if t.InputFields[i].Name == name {
+			return &t.InputFields[i]
+		}
+	}
+	return nil
+}
+
+// builtinScalars are left out of SDL output
+var builtinScalars = map[string]bool{
+	"String": true, "Int": true, "Float": true, "Boolean": true, "ID": true,
+}
+
+// SDL renders the schema as GraphQL schema definition language, with types
+// sorted by name so dumps diff cleanly
+func (s *Schema) SDL() string {
+	var b strings.Builder
+
+	b.WriteString("schema {\n")
+	for _, root := range []struct {
+		op   string
+		name *TypeName
+	}{{"query", s.QueryType}, {"mutation", s.MutationType}, {"subscription", s.SubscriptionType}} {
+		if root.name != nil {
+			fmt.Fprintf(&b, "  %s: %s\n", root.op, root.name.Name)
+		}
+	}
+	b.WriteString("}\n")
+
+	types := append([]Type{}, s.Types...)
+	sort.Slice(types, func(i, j int) bool { return types[i].Name < types[j].Name })
+
+	for _, t := range types {
+		if strings.HasPrefix(t.Name, "__") || builtinScalars[t.Name] {
+			continue
+		}
+
+		b.WriteString("\n")
+		writeDescription(&b, "", t.Description)
+
+		switch t.Kind {
+		case KindScalar:
+			fmt.Fprintf(&b, "scalar %s\n", t.Name)
+		case KindEnum:
+			fmt.Fprintf(&b, "enum %s {\n", t.Name)
+			for _, v := range t.EnumValues {
+				writeDescription(&b, "  ", v.Description)
+				fmt.Fprintf(&b, "  %s%s\n", v.Name, deprecated(v.IsDeprecated, v.DeprecationReason))
+			}
+			b.WriteString("}\n")
+		case KindInputObject:
+			fmt.Fprintf(&b, "input %s {\n", t.Name)
+			for _, f := range t.InputFields {
+				writeDescription(&b, "  ", f.Description)
+				fmt.Fprintf(&b, "  %s\n", inputValueSDL(f))
+			}
+			b.WriteString("}\n")
+		case KindUnion:
+			names := make([]string, len(t.PossibleTypes))
+			for i, p := range t.PossibleTypes {
+				names[i] = p.Name
+			}
+			fmt.Fprintf(&b, "union %s = %s\n", t.Name, strings.Join(names, " | "))
+		default:
+			keyword := "type"
+			if t.Kind == KindInterface {
+				keyword = "interface"
+			}
+			fmt.Fprintf(&b, "%s %s%s {\n", keyword, t.Name, implements(t.Interfaces))
+			for _, f := range t.Fields {
+				writeDescription(&b, "  ", f.Description)
+				fmt.Fprintf(&b, "  %s%s: %s%s\n", f.Name, argsSDL(f.Args), f.Type, deprecated(f.IsDeprecated, f.DeprecationReason))
+			}
+			b.WriteString("}\n")
+		}
+	}
+
+	return b.String()
+}
+
+func writeDescription(b *strings.Builder, indent, desc string) {
+	if desc == "" {
+		return
+	}
+	if strings.Contains(desc, "\n") || strings.Contains(desc, `"`) {
+		fmt.Fprintf(b, "%s\"\"\"\n%s%s\n%s\"\"\"\n", indent, indent, strings.ReplaceAll(desc, "\n", "\n"+indent), indent)
+		return
+	}
+	fmt.Fprintf(b, "%s\"%s\"\n", indent, desc)
+}
+
+func inputValueSDL(v InputValue) string {
+	s := fmt.Sprintf("%s: %s", v.Name, v.Type)
+	if v.DefaultValue != nil {
+		s += " = " + *v.DefaultValue
+	}
+	return s
+}
+
+func argsSDL(args []InputValue) string {
+	if len(args) == 0 {
+		return ""
+	}
+	parts := make([]string, len(args))
+	for i, a := range args {
+		parts[i] = inputValueSDL(a)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func implements(interfaces []TypeRef) string {
+	if len(interfaces) == 0 {
+		return ""
+	}
+	names := make([]string, len(interfaces))
+	for i, iface := range interfaces {
+		names[i] = iface.Name
+	}
+	return " implements " + strings.Join(names, " & ")
+}
+
+func deprecated(isDeprecated bool, reason string) string {
+	if !isDeprecated {
+		return ""
+	}
+	if reason == "" {
+		return " @deprecated"
+	}
+	return fmt.Sprintf(" @deprecated(reason: %q)", reason)
+}
@@ -0,0 +1,178 @@
+package schema
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+const snapshotPath = "tibber.json"
+
+func loadSnapshot(t *testing.T) *Schema {
+	t.Helper()
+	s, err := Load(snapshotPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return s
+}
+
+func TestParse_AcceptsDataEnvelope(t *testing.T) {
+	bare := `{"__schema": {"queryType": {"name": "Query"}, "types": []}}`
+	wrapped := `{"data": ` + bare + `}`
+
+	for _, input := range []string{bare, wrapped} {
+		s, err := Parse([]byte(input))
+		if err != nil {
+			t.Fatalf("Parse(%s) error = %v", input, err)
+		}
+		if s.QueryType == nil || s.QueryType.Name != "Query" {
+			t.Errorf("QueryType = %+v, want Query", s.QueryType)
+		}
+	}
+
+	if _, err := Parse([]byte(`{"data": {}}`)); err == nil {
+		t.Error("Parse() should fail without __schema")
+	}
+}
+
+func TestSDL(t *testing.T) {
+	sdl := loadSnapshot(t).SDL()
+
+	for _, want := range []string{
+		"schema {\n  query: Query\n  mutation: RootMutation\n  subscription: RootSubscription\n}",
+		"enum PriceLevel {\n  NORMAL\n",
+		"  today: [Price]!\n",
+		"  home(id: ID!): Home!\n",
+		"input UpdateHomeInput {\n  homeId: ID!\n",
+	} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("SDL missing %q", want)
+		}
+	}
+	if strings.Contains(sdl, "scalar String") {
+		t.Error("SDL should leave out built-in scalars")
+	}
+
+	// The checked-in SDL is rendered from the JSON snapshot
+	checkedIn, err := os.ReadFile("tibber.graphql")
+	if err != nil {
+		t.Fatalf("failed to read tibber.graphql: %v", err)
+	}
+	if string(checkedIn) != sdl {
+		t.Error("tibber.graphql is out of date with tibber.json; run powerctl schema dump")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	s := loadSnapshot(t)
+
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{"valid query", `{ viewer { homes { id address { city } } } }`, ""},
+		{"named operation with variables", `query Home($id: ID!) { viewer { home(id: $id) { appNickname } } }`, ""},
+		{"alias and typename", `{ viewer { me: name __typename } }`, ""},
+		{"mutation", `mutation($input: PushNotificationInput!) { sendPushNotification(input: $input) { successful } }`, ""},
+		{"unknown field", `{ viewer { homes { nickname } } }`, "unknown field nickname on Home"},
+		{"selection on scalar", `{ viewer { name { first } } }`, "cannot have a selection"},
+		{"object without selection", `{ viewer { homes } }`, "needs a selection"},
+		{"fragment", `{ viewer { ...V } }`, "fragments are not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Check(tt.doc)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Check() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Check() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"id":               "ID",
+		"homeId":           "HomeID",
+		"unitPriceVAT":     "UnitPriceVAT",
+		"currentL1":        "CurrentL1",
+		"VERY_CHEAP":       "VeryCheap",
+		"AIR2AIR_HEATPUMP": "Air2airHeatpump",
+	}
+	for in, want := range tests {
+		if got := goName(in); got != want {
+			t.Errorf("goName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenerate_UpToDate(t *testing.T) {
+	cfg, err := LoadGenConfig("../models/schemagen.yaml")
+	if err != nil {
+		t.Fatalf("LoadGenConfig() error = %v", err)
+	}
+	s, err := Load(cfg.Schema)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	models, selections, err := Generate(s, cfg)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for path, want := range map[string][]byte{cfg.Models.Output: models, cfg.Selections.Output: selections} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date; run go generate ./...", path)
+		}
+	}
+}
+
+func TestGenerate_UnknownField(t *testing.T) {
+	s := loadSnapshot(t)
+	cfg := &GenConfig{
+		Models: GenOutput{Package: "models"},
+		Types:  []TypeSpec{{Name: "Home", Fields: []string{"nickname"}}},
+	}
+
+	if _, _, err := Generate(s, cfg); err == nil || !strings.Contains(err.Error(), "unknown field nickname") {
+		t.Errorf("Generate() error = %v, want unknown field", err)
+	}
+}
+
+func TestGenerate_ExtraField(t *testing.T) {
+	s := loadSnapshot(t)
+	cfg := &GenConfig{
+		Models:     GenOutput{Package: "models"},
+		Selections: GenOutput{Package: "api"},
+		Types: []TypeSpec{{
+			Name:   "Price",
+			Fields: []string{"total"},
+			Extra:  []ExtraField{{Name: "grid", Type: "float64", Doc: "Grid charge"}},
+		}},
+	}
+
+	models, selections, err := Generate(s, cfg)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !strings.Contains(string(models), "Grid float64 `json:\"grid,omitempty\"`") {
+		t.Errorf("Generate() models missing extra field:\n%s", models)
+	}
+	if strings.Contains(string(selections), "grid") {
+		t.Errorf("Generate() selections should not select extra fields:\n%s", selections)
+	}
+}
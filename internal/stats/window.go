@@ -0,0 +1,149 @@
+// Package stats computes rolling statistics over live measurements.
+package stats
+
+import (
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+// DefaultSparkWidth is the number of sparkline buckets
+const DefaultSparkWidth = 30
+
+// Window keeps a sliding window of power samples
+type Window struct {
+	retention time.Duration
+	samples   []models.PowerSample
+	count     int
+	peak      models.PowerSample
+	min       models.PowerSample
+}
+
+// NewWindow creates a window that keeps samples for at least retention
+// (and never less than 15 minutes, the longest average)
+func NewWindow(retention time.Duration) *Window {
+	if retention < 15*time.Minute {
+		retention = 15 * time.Minute
+	}
+	return &Window{retention: retention}
+}
+
+// Add records a measurement and drops samples older than the retention
+func (w *Window) Add(m *models.LiveMeasurement) {
+	s := models.PowerSample{Power: m.Power, Timestamp: m.Timestamp}
+
+	if w.count == 0 || s.Power > w.peak.Power {
+		w.peak = s
+	}
+	if w.count == 0 || s.Power < w.min.Power {
+		w.min = s
+	}
+	w.count++
+
+	w.samples = append(w.samples, s)
+
+	cutoff := s.Timestamp.Add(-w.retention)
+	drop := 0
+	for drop < len(w.samples) && w.samples[drop].Timestamp.Before(cutoff) {
+		drop++
+	}
+	if drop > 0 {
+		w.samples = append(w.samples[:0], w.samples[drop:]...)
+	}
+}
+
+// latest returns the timestamp of the newest sample
+func (w *Window) latest() time.Time {
+	if len(w.samples) == 0 {
+		return time.Time{}
+	}
+	return w.samples[len(w.samples)-1].Timestamp
+}
+
+// Average returns the mean power over the last d, relative to the newest sample
+func (w *Window) Average(d time.Duration) float64 {
+	cutoff := w.latest().Add(-d)
+	var sum float64
+	n := 0
+	for _, s := range w.samples {
+		if s.Timestamp.After(cutoff) {
+			sum += s.Power
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+// Sparkline buckets the last d into width averages, oldest first. Gaps
+// repeat the previous bucket; leading buckets without data are omitted.
+func (w *Window) Sparkline(d time.Duration, width int) []float64 {
+	if len(w.samples) == 0 || width <= 0 {
+		return nil
+	}
+
+	end := w.latest()
+	start := end.Add(-d)
+	bucket := d / time.Duration(width)
+
+	sums := make([]float64, width)
+	counts := make([]int, width)
+	for _, s := range w.samples {
+		if !s.Timestamp.After(start) {
+			continue
+		}
+		i := int(s.Timestamp.Sub(start) / bucket)
+		if i >= width {
+			i = width - 1
+		}
+		sums[i] += s.Power
+		counts[i]++
+	}
+
+	var values []float64
+	for i := range sums {
+		switch {
+		case counts[i] > 0:
+			values = append(values, sums[i]/float64(counts[i]))
+		case len(values) > 0:
+			values = append(values, values[len(values)-1])
+		}
+	}
+	return values
+}
+
+// Stats summarizes the window. latest supplies the current-hour energy and
+// price (may be nil) is used to project the cost of the current hour.
+func (w *Window) Stats(latest *models.LiveMeasurement, price *models.Price, sparkMinutes int) *models.LiveStats {
+	stats := &models.LiveStats{
+		Type:         "stats",
+		Samples:      w.count,
+		Avg1m:        w.Average(time.Minute),
+		Avg5m:        w.Average(5 * time.Minute),
+		Avg15m:       w.Average(15 * time.Minute),
+		Peak:         w.peak,
+		Min:          w.min,
+		Sparkline:    w.Sparkline(time.Duration(sparkMinutes)*time.Minute, DefaultSparkWidth),
+		SparkMinutes: sparkMinutes,
+		Price:        price,
+	}
+
+	if latest != nil {
+		stats.HourEnergy = latest.AccumulatedConsumptionLastHour
+		stats.ProjectedHourEnergy = ProjectHour(latest.Timestamp, stats.HourEnergy, stats.Avg5m)
+		if price != nil {
+			stats.ProjectedHourCost = stats.ProjectedHourEnergy * price.Payable()
+		}
+	}
+
+	return stats
+}
+
+// ProjectHour extrapolates the energy (kWh) of the hour containing t, given
+// the energy used so far and the current average power in watts
+func ProjectHour(t time.Time, soFar, avgPower float64) float64 {
+	hourEnd := t.Truncate(time.Hour).Add(time.Hour)
+	return soFar + avgPower/1000*hourEnd.Sub(t).Hours()
+}
@@ -0,0 +1,100 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kristofferrisa/powerctl-cli/internal/models"
+)
+
+var base = time.Date(2025, 9, 1, 14, 0, 0, 0, time.UTC)
+
+func sample(offset time.Duration, power float64) *models.LiveMeasurement {
+	return &models.LiveMeasurement{Timestamp: base.Add(offset), Power: power}
+}
+
+func TestWindow_Averages(t *testing.T) {
+	w := NewWindow(15 * time.Minute)
+
+	// 1000 W for 10 minutes, then 3000 W for the last minute
+	for i := 0; i < 600; i += 10 {
+		w.Add(sample(time.Duration(i)*time.Second, 1000))
+	}
+	for i := 600; i <= 660; i += 10 {
+		w.Add(sample(time.Duration(i)*time.Second, 3000))
+	}
+
+	if got := w.Average(time.Minute); got != 3000 {
+		t.Errorf("Average(1m) = %v, want 3000", got)
+	}
+	if got := w.Average(15 * time.Minute); got <= 1000 || got >= 3000 {
+		t.Errorf("Average(15m) = %v, want between 1000 and 3000", got)
+	}
+}
+
+func TestWindow_PeakAndMin(t *testing.T) {
+	w := NewWindow(15 * time.Minute)
+	w.Add(sample(0, 500))
+	w.Add(sample(time.Minute, 4000))
+	w.Add(sample(2*time.Minute, 200))
+
+	stats := w.Stats(nil, nil, 15)
+
+	if stats.Peak.Power != 4000 || !stats.Peak.Timestamp.Equal(base.Add(time.Minute)) {
+		t.Errorf("Peak = %+v, want 4000 W at +1m", stats.Peak)
+	}
+	if stats.Min.Power != 200 || !stats.Min.Timestamp.Equal(base.Add(2*time.Minute)) {
+		t.Errorf("Min = %+v, want 200 W at +2m", stats.Min)
+	}
+	if stats.Samples != 3 || stats.Type != "stats" {
+		t.Errorf("Samples = %d, Type = %q, want 3 and stats", stats.Samples, stats.Type)
+	}
+}
+
+func TestWindow_DropsOldSamples(t *testing.T) {
+	w := NewWindow(15 * time.Minute)
+	w.Add(sample(0, 9000))
+	w.Add(sample(20*time.Minute, 100))
+
+	if got := w.Average(time.Hour); got != 100 {
+		t.Errorf("Average(1h) = %v, want 100 (old sample should be dropped)", got)
+	}
+}
+
+func TestWindow_SparklineFillsGaps(t *testing.T) {
+	w := NewWindow(15 * time.Minute)
+	w.Add(sample(0, 100))
+	w.Add(sample(2*time.Minute, 300))
+	w.Add(sample(10*time.Minute, 500))
+
+	line := w.Sparkline(10*time.Minute, 10)
+	if len(line) == 0 || line[len(line)-1] != 500 {
+		t.Fatalf("Sparkline() = %v, want last bucket 500", line)
+	}
+	for i := 1; i < len(line)-1; i++ {
+		if line[i] != 300 {
+			t.Errorf("Sparkline()[%d] = %v, want gap filled with 300", i, line[i])
+		}
+	}
+}
+
+func TestWindow_ProjectedHourCost(t *testing.T) {
+	w := NewWindow(15 * time.Minute)
+	m := &models.LiveMeasurement{
+		Timestamp:                      base.Add(30 * time.Minute),
+		Power:                          2000,
+		AccumulatedConsumptionLastHour: 0.5,
+	}
+	w.Add(m)
+
+	stats := w.Stats(m, &models.Price{Total: 2.0}, 15)
+
+	// 0.5 kWh so far + 2 kW for the remaining half hour
+	if math.Abs(stats.ProjectedHourEnergy-1.5) > 1e-9 {
+		t.Errorf("ProjectedHourEnergy = %v, want 1.5", stats.ProjectedHourEnergy)
+	}
+	if math.Abs(stats.ProjectedHourCost-3.0) > 1e-9 {
+		t.Errorf("ProjectedHourCost = %v, want 3.0", stats.ProjectedHourCost)
+	}
+}
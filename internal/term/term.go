@@ -0,0 +1,21 @@
+// Package term detects terminals and switches them in and out of the modes
+// used by interactive views.
+package term
+
+import "errors"
+
+// ErrUnsupported is returned on platforms without terminal support
+var ErrUnsupported = errors.New("terminal control is not supported on this platform")
+
+// DefaultWidth is assumed when the terminal width is unknown
+const DefaultWidth = 80
+
+// Width returns the width of the terminal on fd, or DefaultWidth if fd is
+// not a terminal
+func Width(fd uintptr) int {
+	width, _, err := Size(fd)
+	if err != nil || width <= 0 {
+		return DefaultWidth
+	}
+	return width
+}
@@ -0,0 +1,28 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly && !windows
+
+package term
+
+import "os"
+
+// IsTerminal always reports false on this platform
+func IsTerminal(fd uintptr) bool {
+	return false
+}
+
+// Size is not supported on this platform
+func Size(fd uintptr) (width, height int, err error) {
+	return 0, 0, ErrUnsupported
+}
+
+// RawInput is not supported on this platform
+func RawInput(fd uintptr) (restore func() error, err error) {
+	return nil, ErrUnsupported
+}
+
+// EnableVT does nothing on this platform
+func EnableVT(fd uintptr) error {
+	return nil
+}
+
+// NotifyResize does nothing on this platform
+func NotifyResize(c chan<- os.Signal) {}
@@ -0,0 +1,59 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package term
+
+import (
+	"os"
+	"os/signal"
+
+	"golang.org/x/sys/unix"
+)
+
+// IsTerminal reports whether fd refers to a terminal
+func IsTerminal(fd uintptr) bool {
+	_, err := unix.IoctlGetTermios(int(fd), ioctlReadTermios)
+	return err == nil
+}
+
+// Size returns the width and height of the terminal on fd
+func Size(fd uintptr) (width, height int, err error) {
+	ws, err := unix.IoctlGetWinsize(int(fd), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(ws.Col), int(ws.Row), nil
+}
+
+// RawInput turns off echo and line buffering on fd so keys can be read as
+// they are pressed. Output processing and signals such as Ctrl+C are left
+// alone. The returned function restores the previous mode.
+func RawInput(fd uintptr) (restore func() error, err error) {
+	old, err := unix.IoctlGetTermios(int(fd), ioctlReadTermios)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := *old
+	raw.Iflag &^= unix.ICRNL | unix.IXON
+	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.IEXTEN
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(int(fd), ioctlWriteTermios, &raw); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		return unix.IoctlSetTermios(int(fd), ioctlWriteTermios, old)
+	}, nil
+}
+
+// EnableVT prepares fd for ANSI escape sequences; terminals here always
+// support them
+func EnableVT(fd uintptr) error {
+	return nil
+}
+
+// NotifyResize sends to c when the terminal is resized
+func NotifyResize(c chan<- os.Signal) {
+	signal.Notify(c, unix.SIGWINCH)
+}
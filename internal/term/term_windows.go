@@ -0,0 +1,54 @@
+package term
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// IsTerminal reports whether fd refers to a console
+func IsTerminal(fd uintptr) bool {
+	var mode uint32
+	return windows.GetConsoleMode(windows.Handle(fd), &mode) == nil
+}
+
+// Size returns the width and height of the console window on fd
+func Size(fd uintptr) (width, height int, err error) {
+	var info windows.ConsoleScreenBufferInfo
+	if err := windows.GetConsoleScreenBufferInfo(windows.Handle(fd), &info); err != nil {
+		return 0, 0, err
+	}
+	return int(info.Window.Right-info.Window.Left) + 1, int(info.Window.Bottom-info.Window.Top) + 1, nil
+}
+
+// RawInput turns off echo and line buffering on fd and reports arrow keys
+// as escape sequences. Ctrl+C is still delivered as a signal. The returned
+// function restores the previous mode.
+func RawInput(fd uintptr) (restore func() error, err error) {
+	var old uint32
+	if err := windows.GetConsoleMode(windows.Handle(fd), &old); err != nil {
+		return nil, err
+	}
+
+	raw := old&^(windows.ENABLE_ECHO_INPUT|windows.ENABLE_LINE_INPUT) | windows.ENABLE_VIRTUAL_TERMINAL_INPUT
+	if err := windows.SetConsoleMode(windows.Handle(fd), raw); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		return windows.SetConsoleMode(windows.Handle(fd), old)
+	}, nil
+}
+
+// EnableVT turns on ANSI escape sequence processing for the console on fd
+func EnableVT(fd uintptr) error {
+	var mode uint32
+	if err := windows.GetConsoleMode(windows.Handle(fd), &mode); err != nil {
+		return err
+	}
+	return windows.SetConsoleMode(windows.Handle(fd), mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING)
+}
+
+// NotifyResize does nothing; consoles have no resize signal, so callers
+// should also poll Size
+func NotifyResize(c chan<- os.Signal) {}